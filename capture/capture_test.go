@@ -0,0 +1,140 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/plugin/pluginfakes"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/capture"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func containerMetric(appId string, instance int32) *events.Envelope {
+	return &events.Envelope{
+		Origin:    proto.String("rep"),
+		EventType: events.Envelope_ContainerMetric.Enum(),
+		Ip:        proto.String("10.0.0.1"),
+		ContainerMetric: &events.ContainerMetric{
+			ApplicationId:    proto.String(appId),
+			InstanceIndex:    proto.Int32(instance),
+			CpuPercentage:    proto.Float64(12.5),
+			MemoryBytes:      proto.Uint64(256 * 1024 * 1024),
+			DiskBytes:        proto.Uint64(128 * 1024 * 1024),
+			MemoryBytesQuota: proto.Uint64(512 * 1024 * 1024),
+			DiskBytesQuota:   proto.Uint64(1024 * 1024 * 1024),
+		},
+	}
+}
+
+var _ = Describe("Capture", func() {
+	var (
+		dir      string
+		fileName string
+		sent     []*events.Envelope
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cftop-capture")
+		Expect(err).NotTo(HaveOccurred())
+		fileName = filepath.Join(dir, "capture.bin")
+
+		sent = nil
+		for app := 0; app < 3; app++ {
+			for instance := int32(0); instance < 2; instance++ {
+				sent = append(sent, containerMetric(fmt.Sprintf("00000000-0000-0000-0000-%012d", app), instance))
+			}
+		}
+		writer, err := capture.NewWriter(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		for _, envelope := range sent {
+			Expect(writer.Write(envelope)).To(Succeed())
+		}
+		Expect(writer.Count()).To(Equal(uint64(len(sent))))
+		Expect(writer.Close()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("replays the envelopes in the order written", func() {
+		replayed := make([]*events.Envelope, 0)
+		count, err := capture.Replay(fileName, false, func(envelope *events.Envelope) {
+			replayed = append(replayed, envelope)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(uint64(len(sent))))
+		Expect(replayed).To(HaveLen(len(sent)))
+		for i := range sent {
+			Expect(proto.Equal(replayed[i], sent[i])).To(BeTrue())
+		}
+	})
+
+	It("routes the replayed envelopes to the event processor", func() {
+		cliConnection := &pluginfakes.FakeCliConnection{}
+		cliConnection.ApiEndpointReturns("https://api.example.com", nil)
+		processor := eventdata.NewEventProcessor(cliConnection, true)
+		router := eventrouting.NewEventRouter(processor)
+
+		count, err := capture.Replay(fileName, false, func(envelope *events.Envelope) {
+			router.Route(0, envelope)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(uint64(len(sent))))
+		Expect(router.GetEventCount()).To(Equal(uint64(len(sent))))
+		Eventually(func() int {
+			processor.UpdateData()
+			return len(processor.GetDisplayedEventData().AppMap)
+		}).Should(Equal(3))
+		Expect(router.GetDroppedCount()).To(BeZero())
+	})
+
+	It("validates a capture file without replaying it", func() {
+		count, err := capture.Validate(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(uint64(len(sent))))
+	})
+
+	It("rejects a file that is not a capture file", func() {
+		Expect(ioutil.WriteFile(fileName, []byte("not a capture"), 0600)).To(Succeed())
+		_, err := capture.Validate(fileName)
+		Expect(err).To(MatchError(ContainSubstring("not a cf top capture file")))
+	})
+
+	It("rejects a truncated capture file", func() {
+		info, err := os.Stat(fileName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Truncate(fileName, info.Size()-1)).To(Succeed())
+		count, err := capture.Validate(fileName)
+		Expect(err).To(MatchError(ContainSubstring("truncated record")))
+		Expect(count).To(Equal(uint64(len(sent) - 1)))
+	})
+
+	It("fails to validate a missing file", func() {
+		_, err := capture.Validate(filepath.Join(dir, "missing.bin"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,92 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Replay reads the envelopes of a capture file (see Writer) and passes each
+// to handler.  When realTime is true the original gaps between envelopes are
+// kept, otherwise envelopes are replayed as fast as handler accepts them.
+// Returns the number of envelopes replayed.
+func Replay(fileName string, realTime bool, handler func(*events.Envelope)) (uint64, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+
+	header := make([]byte, len(fileHeader))
+	if _, err := io.ReadFull(reader, header); err != nil || string(header) != fileHeader {
+		return 0, fmt.Errorf("%v: not a cf top capture file", fileName)
+	}
+
+	count := uint64(0)
+	var firstCaptureTime int64
+	var replayStartTime time.Time
+	var recordHeader [12]byte
+	for {
+		if _, err := io.ReadFull(reader, recordHeader[:]); err != nil {
+			if err == io.EOF {
+				return count, nil
+			}
+			return count, fmt.Errorf("%v: truncated record after %v envelopes", fileName, count)
+		}
+		captureTime := int64(binary.BigEndian.Uint64(recordHeader[0:8]))
+		size := binary.BigEndian.Uint32(recordHeader[8:12])
+		if size > maxRecordSize {
+			return count, fmt.Errorf("%v: invalid record size %v after %v envelopes", fileName, size, count)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return count, fmt.Errorf("%v: truncated record after %v envelopes", fileName, count)
+		}
+		envelope := &events.Envelope{}
+		if err := proto.Unmarshal(data, envelope); err != nil {
+			return count, fmt.Errorf("%v: invalid envelope after %v envelopes: %v", fileName, count, err)
+		}
+
+		if realTime {
+			if count == 0 {
+				firstCaptureTime = captureTime
+				replayStartTime = time.Now()
+			}
+			offset := time.Duration(captureTime - firstCaptureTime)
+			if wait := offset - time.Since(replayStartTime); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		handler(envelope)
+		count++
+	}
+}
+
+// Validate reads every record of a capture file without replaying it so an
+// unreadable or corrupt file is reported before the UI starts.  Returns the
+// number of envelopes in the file.
+func Validate(fileName string) (uint64, error) {
+	return Replay(fileName, false, func(*events.Envelope) {})
+}
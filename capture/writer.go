@@ -0,0 +1,118 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Capture files hold the firehose envelopes received by top so that they can
+// be replayed later without a live foundation.  The file starts with
+// fileHeader followed by one record per envelope:
+//
+//	int64  time the envelope was received (unix nanoseconds, big endian)
+//	uint32 length of the marshaled envelope (big endian)
+//	[]byte envelope marshaled as protobuf
+const fileHeader = "CFTOP-CAPTURE-1\n"
+
+// Upper bound on a single record, protects against reading a corrupt file
+const maxRecordSize = 16 * 1024 * 1024
+
+type Writer struct {
+	mu       sync.Mutex
+	fileName string
+	file     *os.File
+	buf      *bufio.Writer
+	count    uint64
+	err      error
+}
+
+func NewWriter(fileName string) (*Writer, error) {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	if _, err := buf.WriteString(fileHeader); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &Writer{fileName: fileName, file: file, buf: buf}, nil
+}
+
+func (w *Writer) FileName() string {
+	return w.fileName
+}
+
+// Number of envelopes written
+func (w *Writer) Count() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.count
+}
+
+// Write an envelope to the capture file.  Safe to call from multiple nozzles.
+// After the first error all further writes are ignored and the error returned.
+func (w *Writer) Write(envelope *events.Envelope) error {
+	data, err := proto.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil || w.buf == nil {
+		return w.err
+	}
+	var recordHeader [12]byte
+	binary.BigEndian.PutUint64(recordHeader[0:8], uint64(time.Now().UnixNano()))
+	binary.BigEndian.PutUint32(recordHeader[8:12], uint32(len(data)))
+	if _, err := w.buf.Write(recordHeader[:]); err != nil {
+		w.err = err
+		return err
+	}
+	if _, err := w.buf.Write(data); err != nil {
+		w.err = err
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Flush and close the capture file
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf == nil {
+		return nil
+	}
+	err := w.buf.Flush()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.buf = nil
+	if err != nil {
+		return fmt.Errorf("%v: %v", w.fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Source of the current time.  Time based logic (rates, windows, ages)
+// should get the time from this package instead of calling time.Now()
+// directly so it can be driven by a FakeClock in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// Real clock backed by time.Now()
+var Real Clock = realClock{}
+
+var (
+	mu      sync.RWMutex
+	current = Real
+)
+
+// Replace the clock used by Now and Since.  Passing nil restores the real clock.
+func SetClock(c Clock) {
+	mu.Lock()
+	defer mu.Unlock()
+	if c == nil {
+		c = Real
+	}
+	current = c
+}
+
+func GetClock() Clock {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+func Now() time.Time {
+	return GetClock().Now()
+}
+
+func Since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// Clock that only moves when told to
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
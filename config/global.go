@@ -20,7 +20,511 @@ package config
 const WarmUpSeconds = 60
 const StaleContainerSeconds = 80
 
+// Apps that have not sent any event in this many seconds are highlighted
+const AppLastSeenWarmSeconds = 60
+const AppLastSeenHotSeconds = 300
+
+// Number of aggregate CPU samples kept per app to compute trend
+const CpuTrendSamples = 5
+
+// Minimum change in aggregate CPU percent to be considered a trend
+const CpuTrendThreshold = 1.0
+
+// Number of memory samples kept per container to compute the app p95 memory
+const MemoryHistorySamples = 60
+
+// Number of aggregate memory samples kept per app to compute the memory
+// slope used for leak detection.  Samples are recorded at most once per
+// MemoryTrendSampleSeconds so the window covers at least 15 minutes no
+// matter how many instances (and container metrics) an app has.
+const MemoryTrendSamples = 60
+const MemoryTrendSampleSeconds = 15
+
+// Minimum samples and time span of the memory history before an app can
+// be flagged as a potential memory leak
+const MemoryLeakMinSamples = 10
+const MemoryLeakMinMinutes = 5
+
+// Memory slope, as a percent of total reserved memory per hour, at or over
+// which an app is flagged as a potential memory leak.  When reserved memory
+// is not known MemoryLeakMinMBPerHour is used instead.
+const MemoryLeakPercentPerHour = 10.0
+const MemoryLeakMinMBPerHour = 50.0
+
+// Default app health score (0-100, 100 is healthy) penalties
+const DefaultHealthCrashPenalty = 25            // per crash in the last hour
+const DefaultHealthMissingInstancePenalty = 100 // scaled by fraction of desired instances not reporting
+const DefaultHealthHttp5xxPenalty = 300         // scaled by fraction of HTTP responses that are 5xx
+
+// App list rows are tinted yellow / red by default when health score is below these
+const DefaultHealthScoreWarn = 80
+const DefaultHealthScoreBad = 50
+
+var (
+	healthCrashPenalty           = DefaultHealthCrashPenalty
+	healthMissingInstancePenalty = DefaultHealthMissingInstancePenalty
+	healthHttp5xxPenalty         = DefaultHealthHttp5xxPenalty
+	healthScoreWarn              = DefaultHealthScoreWarn
+	healthScoreBad               = DefaultHealthScoreBad
+)
+
+// Negative penalties are ignored
+func SetHealthPenalties(crash, missingInstance, http5xx int) {
+	if crash >= 0 && missingInstance >= 0 && http5xx >= 0 {
+		healthCrashPenalty = crash
+		healthMissingInstancePenalty = missingInstance
+		healthHttp5xxPenalty = http5xx
+	}
+}
+
+func HealthCrashPenalty() int {
+	return healthCrashPenalty
+}
+
+func HealthMissingInstancePenalty() int {
+	return healthMissingInstancePenalty
+}
+
+func HealthHttp5xxPenalty() int {
+	return healthHttp5xxPenalty
+}
+
+// Thresholds out of the 0-100 range or with bad above warn are ignored
+func SetHealthScoreThresholds(warn, bad int) {
+	if bad >= 0 && bad <= warn && warn <= 100 {
+		healthScoreWarn = warn
+		healthScoreBad = bad
+	}
+}
+
+func HealthScoreWarn() int {
+	return healthScoreWarn
+}
+
+func HealthScoreBad() int {
+	return healthScoreBad
+}
+
+// Cells are flagged in the cell health view when their 24 hour crash count is
+// at least this many crashes and this factor above the average of other cells
+const CellCrashAnomalyMinCount = 3
+const CellCrashAnomalyFactor = 3.0
+
+// An app instance is crash looping when it has crashed at least
+// CrashLoopMinCrashes times in a row with each crash shortly after the one
+// before.  The first interval must be within CrashLoopMaxIntervalSeconds,
+// later intervals may double to allow for the restart backoff.
+const CrashLoopMinCrashes = 3
+const CrashLoopMaxIntervalSeconds = 120
+
+// Recent app events (/v2/events) shown in the app events view: the most
+// recent events loaded per app and how long they are cached
+const AppEventsMaxCount = 100
+const AppEventsCacheSeconds = 60
+
+// Seconds before the service bindings of an app are loaded again after a
+// failed load
+const ServiceBindingRetrySeconds = 60
+
 const MaxDomainBucket = 100
 const MaxHostBucket = 10000
 const MaxUserAgentBucket = 100
 const MaxForwarderBucket = 100
+
+// Default number of apps at which top warns at startup that the foundation
+// is large and offers to apply an org/space scope (0 disables the check)
+const DefaultLargeFoundationAppCount = 10000
+
+// Default number of firehose events that can be queued waiting to be
+// processed.  When the queue is full events are dropped.
+const DefaultEventQueueCapacity = 10000
+
+// Seconds between warnings logged when events are being dropped
+const DroppedEventsWarnSeconds = 10
+
+// Seconds each shutdown step (see shutdown.Run) is given before it is abandoned
+const ShutdownHookTimeoutSeconds = 2
+
+// Show the space after app names that are used in more than one space
+var qualifyDuplicateAppNames bool
+
+func SetQualifyDuplicateAppNames(qualify bool) {
+	qualifyDuplicateAppNames = qualify
+}
+
+func QualifyDuplicateAppNames() bool {
+	return qualifyDuplicateAppNames
+}
+
+// App detail view highlights the stderr share of container log events
+// at or above this percent
+const StderrRatioWarnPercent = 25.0
+
+// Smallest terminal the UI is rendered in.  Below this only a "terminal too
+// small" message is shown until the terminal is resized.
+const MinTerminalWidth = 60
+const MinTerminalHeight = 15
+
+var eventQueueCapacity = DefaultEventQueueCapacity
+
+func SetEventQueueCapacity(capacity int) {
+	if capacity > 0 {
+		eventQueueCapacity = capacity
+	}
+}
+
+func EventQueueCapacity() int {
+	return eventQueueCapacity
+}
+
+// Default seconds without any firehose envelope before the firehose
+// connections are closed and reopened (0 disables)
+const DefaultFirehoseIdleTimeoutSeconds = 120
+
+var firehoseIdleTimeoutSeconds = DefaultFirehoseIdleTimeoutSeconds
+
+func SetFirehoseIdleTimeoutSeconds(seconds int) {
+	if seconds >= 0 {
+		firehoseIdleTimeoutSeconds = seconds
+	}
+}
+
+func FirehoseIdleTimeoutSeconds() int {
+	return firehoseIdleTimeoutSeconds
+}
+
+// Header highlights the time since the last envelope after this many seconds
+const LastEventWarnSeconds = 30
+
+// Default number of goroutines processing firehose events.  Events for the
+// same app are always processed by the same worker.
+const DefaultEventWorkers = 1
+
+const MaxEventWorkers = 32
+
+var eventWorkers = DefaultEventWorkers
+
+func SetEventWorkers(workers int) {
+	if workers > 0 && workers <= MaxEventWorkers {
+		eventWorkers = workers
+	}
+}
+
+func EventWorkers() int {
+	return eventWorkers
+}
+
+// Default window (minutes) used by the app list "crashing apps only" filter
+const DefaultCrashFilterMinutes = 10
+
+var crashFilterMinutes = DefaultCrashFilterMinutes
+
+func SetCrashFilterMinutes(minutes int) {
+	if minutes > 0 {
+		crashFilterMinutes = minutes
+	}
+}
+
+func CrashFilterMinutes() int {
+	return crashFilterMinutes
+}
+
+// Default percent of reserved memory used by a container at which the app
+// is flagged as at risk of being OOM killed
+const DefaultMemoryRiskPercent = 90
+
+var memoryRiskPercent = DefaultMemoryRiskPercent
+
+func SetMemoryRiskPercent(percent int) {
+	if percent > 0 && percent <= 100 {
+		memoryRiskPercent = percent
+	}
+}
+
+func MemoryRiskPercent() int {
+	return memoryRiskPercent
+}
+
+// CPU percent display precision (decimal places).  CpuPrecisionAuto uses
+// fewer decimal places as the value gets larger (2 below 10%, 1 below 100%).
+const CpuPrecisionAuto = -1
+const MaxCpuPrecision = 4
+
+var cpuPrecision = CpuPrecisionAuto
+
+func SetCpuPrecision(precision int) {
+	if precision >= CpuPrecisionAuto && precision <= MaxCpuPrecision {
+		cpuPrecision = precision
+	}
+}
+
+func CpuPrecision() int {
+	return cpuPrecision
+}
+
+// When set, app and container CPU percent is divided by the number of CPUs
+// of the cell the container runs on (100% is all cores of the cell busy)
+var cpuPerCore bool
+
+func SetCpuPerCore(perCore bool) {
+	cpuPerCore = perCore
+}
+
+func IsCpuPerCore() bool {
+	return cpuPerCore
+}
+
+// Screen recording (toggled with shift-V) is written to this file in
+// asciicast format.  When recordAnsi is false colors are not recorded.
+const DefaultRecordFileName = "cf-top-record.cast"
+
+var recordFileName = DefaultRecordFileName
+var recordAnsi = true
+
+func SetRecordFileName(fileName string) {
+	if fileName != "" {
+		recordFileName = fileName
+	}
+}
+
+func RecordFileName() string {
+	return recordFileName
+}
+
+func SetRecordAnsi(ansi bool) {
+	recordAnsi = ansi
+}
+
+func IsRecordAnsi() bool {
+	return recordAnsi
+}
+
+// Minutes without a key press before the display (and optionally event
+// ingestion) is automatically paused.  0 disables idle detection.  Ignored
+// in kiosk mode as unattended displays are expected to stay live.
+var idleTimeoutMinutes int
+var idlePauseIngest bool
+
+func SetIdleTimeoutMinutes(minutes int) {
+	if minutes >= 0 {
+		idleTimeoutMinutes = minutes
+	}
+}
+
+func IdleTimeoutMinutes() int {
+	return idleTimeoutMinutes
+}
+
+// When set, firehose events are discarded (not processed) while idle paused
+func SetIdlePauseIngest(pauseIngest bool) {
+	idlePauseIngest = pauseIngest
+}
+
+func IsIdlePauseIngest() bool {
+	return idlePauseIngest
+}
+
+// Firehose envelope type names (e.g., ContainerMetric) that are processed.
+// Events of any other type are discarded at ingestion -- they are still
+// counted in the event rate but do not update any stats.  Empty processes
+// all types.
+var eventTypes []string
+
+func SetEventTypes(types []string) {
+	eventTypes = types
+}
+
+func EventTypes() []string {
+	return eventTypes
+}
+
+// Metadata caches older than this are highlighted in the header
+const DefaultMetadataWarnMinutes = 60
+
+var metadataWarnMinutes = DefaultMetadataWarnMinutes
+
+func SetMetadataWarnMinutes(minutes int) {
+	if minutes > 0 {
+		metadataWarnMinutes = minutes
+	}
+}
+
+func MetadataWarnMinutes() int {
+	return metadataWarnMinutes
+}
+
+// Apps updated (e.g., pushed) within this many minutes are marked in the app list
+const DefaultRecentDeployMinutes = 30
+
+var recentDeployMinutes = DefaultRecentDeployMinutes
+
+func SetRecentDeployMinutes(minutes int) {
+	if minutes >= 0 {
+		recentDeployMinutes = minutes
+	}
+}
+
+// Zero disables marking recently deployed apps
+func RecentDeployMinutes() int {
+	return recentDeployMinutes
+}
+
+// Width of each bucket of the per-app request history charted in the
+// app HTTP view
+const RequestHistoryBucketSeconds = 10
+
+// Length of the per-app request history charted in the app HTTP view
+const DefaultRequestHistoryMinutes = 10
+
+var requestHistoryMinutes = DefaultRequestHistoryMinutes
+
+func SetRequestHistoryMinutes(minutes int) {
+	if minutes > 0 {
+		requestHistoryMinutes = minutes
+	}
+}
+
+func RequestHistoryMinutes() int {
+	return requestHistoryMinutes
+}
+
+// Number of buckets in the per-app request history
+func RequestHistoryBuckets() int {
+	return requestHistoryMinutes * 60 / RequestHistoryBucketSeconds
+}
+
+// The top talkers view ranks apps by the request and 5xx counts of the
+// request history over this window, showing the top N apps
+const TopTalkersWindowSeconds = 60
+const DefaultTopTalkersCount = 20
+
+var topTalkersCount = DefaultTopTalkersCount
+
+func SetTopTalkersCount(count int) {
+	if count > 0 {
+		topTalkersCount = count
+	}
+}
+
+func TopTalkersCount() int {
+	return topTalkersCount
+}
+
+// A display refresh step (stats post processing or a view's list data)
+// taking longer than this is logged as a warning
+const DefaultRefreshBudgetMS = 1000
+
+var refreshBudgetMS = DefaultRefreshBudgetMS
+
+func SetRefreshBudgetMS(ms int) {
+	if ms >= 0 {
+		refreshBudgetMS = ms
+	}
+}
+
+// Zero disables the slow refresh warning
+func RefreshBudgetMS() int {
+	return refreshBudgetMS
+}
+
+// Views that can be opened at startup with -start-view
+const (
+	StartViewApps         = "apps"
+	StartViewOrgs         = "orgs"
+	StartViewCells        = "cells"
+	StartViewCellHealth   = "cell-health"
+	StartViewRoutes       = "routes"
+	StartViewEventHistory = "event-history"
+	StartViewEvents       = "events"
+	StartViewCapacityPlan = "capacity-plan"
+	// App list with the log window open and debug logging enabled
+	StartViewLog = "log"
+)
+
+var StartViewNames = []string{StartViewApps, StartViewOrgs, StartViewCells, StartViewCellHealth,
+	StartViewRoutes, StartViewEventHistory, StartViewEvents, StartViewCapacityPlan, StartViewLog}
+
+// How the highlighted list row is kept in view when a refresh moves it
+// (e.g., sorting by a volatile metric like CPU)
+const (
+	// Keep the row on the same screen line, the list scrolls around it
+	HighlightTrackPin = "pin"
+	// Only scroll when the row would leave the view
+	HighlightTrackView = "view"
+	// Do not scroll, the row may move out of view
+	HighlightTrackOff = "off"
+)
+
+var HighlightTrackModes = []string{HighlightTrackPin, HighlightTrackView, HighlightTrackOff}
+
+var highlightTrackMode = HighlightTrackPin
+
+func SetHighlightTrackMode(mode string) {
+	for _, trackMode := range HighlightTrackModes {
+		if mode == trackMode {
+			highlightTrackMode = mode
+			return
+		}
+	}
+}
+
+func HighlightTrackMode() string {
+	return highlightTrackMode
+}
+
+// Columns that have both a used and a free value (container memory and
+// disk) show both, or one combined column with only the used or free value
+type UsedFreeDisplay int
+
+const (
+	UsedFreeBoth UsedFreeDisplay = iota
+	UsedFreeUsedOnly
+	UsedFreeFreeOnly
+)
+
+var usedFreeDisplay = UsedFreeBoth
+
+func SetUsedFreeDisplay(display UsedFreeDisplay) {
+	usedFreeDisplay = display
+}
+
+func GetUsedFreeDisplay() UsedFreeDisplay {
+	return usedFreeDisplay
+}
+
+// When set, rate capable columns (e.g., container log counts) show per
+// second rates instead of totals
+var perSecondDisplay bool
+
+func SetPerSecondDisplay(perSecond bool) {
+	perSecondDisplay = perSecond
+}
+
+func IsPerSecondDisplay() bool {
+	return perSecondDisplay
+}
+
+// Quiet start skips loading route and domain metadata at startup.  It is
+// loaded the first time a route dependent view is opened instead.
+var deferRouteMetadata bool
+
+func SetDeferRouteMetadata(deferLoad bool) {
+	deferRouteMetadata = deferLoad
+}
+
+func IsDeferRouteMetadata() bool {
+	return deferRouteMetadata
+}
+
+// Kiosk mode is for unattended (e.g., NOC) displays.  When set, keybindings
+// that change state (clear stats, toggle debug, inject test messages, etc.) are
+// not registered leaving only navigation and viewing.
+var kioskMode bool
+
+func SetKioskMode(isKiosk bool) {
+	kioskMode = isKiosk
+}
+
+func IsKioskMode() bool {
+	return kioskMode
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Password for a proxy URL that has a user but no password, so the
+// password doesn't need to be on the command line
+const ProxyPasswordEnv = "CF_TOP_PROXY_PASSWORD"
+
+var (
+	// nil uses the proxy environment variables
+	proxyUrl    *url.URL
+	noProxyList []string
+)
+
+// Parse a proxy URL given as [scheme://][user[:password]@]host:port.  The
+// scheme defaults to http.  Only http is supported, the websocket client
+// used for the firehose only connects through HTTP CONNECT proxies.
+func ParseProxyUrl(rawUrl string) (*url.URL, error) {
+	if !strings.Contains(rawUrl, "://") {
+		rawUrl = "http://" + rawUrl
+	}
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" {
+		return nil, fmt.Errorf("unsupported proxy scheme %v (only http proxies are supported)", u.Scheme)
+	}
+	if u.Hostname() == "" || u.Port() == "" {
+		return nil, fmt.Errorf("proxy must include a host and port")
+	}
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); !hasPassword {
+			if password := os.Getenv(ProxyPasswordEnv); password != "" {
+				u.User = url.UserPassword(u.User.Username(), password)
+			}
+		}
+	}
+	return u, nil
+}
+
+// SetProxy sets the proxy used for the firehose connections and the
+// version check.  An empty rawUrl uses the HTTPS_PROXY / HTTP_PROXY
+// environment variables.  noProxy is a comma separated list of hosts,
+// domains (e.g., .example.com), IPs or CIDRs that are connected to
+// directly, when empty with an explicit proxy NO_PROXY is used.
+func SetProxy(rawUrl string, noProxy string) error {
+	proxyUrl = nil
+	if rawUrl != "" {
+		u, err := ParseProxyUrl(rawUrl)
+		if err != nil {
+			return err
+		}
+		proxyUrl = u
+		if noProxy == "" {
+			noProxy = os.Getenv("NO_PROXY")
+		}
+		if noProxy == "" {
+			noProxy = os.Getenv("no_proxy")
+		}
+	}
+	noProxyList = make([]string, 0)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry != "" {
+			noProxyList = append(noProxyList, entry)
+		}
+	}
+	return nil
+}
+
+// Proxy URL set with SetProxy with the password removed, empty if the
+// environment variables are used
+func ProxyDescription() string {
+	if proxyUrl == nil {
+		return ""
+	}
+	u := *proxyUrl
+	if u.User != nil {
+		u.User = url.User(u.User.Username())
+	}
+	return u.String()
+}
+
+// ProxyFunc returns the proxy function to use for HTTP and websocket
+// connections (see http.Transport.Proxy)
+func ProxyFunc() func(*http.Request) (*url.URL, error) {
+	return proxyForRequest
+}
+
+func proxyForRequest(req *http.Request) (*url.URL, error) {
+	if isNoProxyHost(req.URL.Host) {
+		return nil, nil
+	}
+	if proxyUrl == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	return proxyUrl, nil
+}
+
+func isNoProxyHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, entry := range noProxyList {
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		domain := strings.TrimPrefix(entry, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 )
@@ -47,6 +48,13 @@ type AppStats struct {
 	// because container stats entries and come and go (scale up/down)
 	// but we want to keep all crash info regardless of container status
 	ContainerCrashInfo []*crashData.ContainerCrashInfo
+
+	// Time of the most recent event of any type received for this app
+	LastEventTime time.Time
+
+	// HTTP request / 5xx counts over config.RequestHistoryMinutes,
+	// nil until the first HTTP event
+	RequestHistory *RequestHistory
 }
 
 func NewAppStats(appId string) *AppStats {
@@ -67,8 +75,7 @@ func ConvertFromMap(statsMap map[string]*AppStats, appMdMgr *app.AppMetadataMana
 	return s
 }
 
-func (as *AppStats) AddCrashInfo(containerIndex int, crashTime *time.Time, exitDescription string) {
-	crashInfo := crashData.NewContainerCrashInfo(containerIndex, crashTime, exitDescription)
+func (as *AppStats) AddCrashInfo(crashInfo *crashData.ContainerCrashInfo) {
 	if as.ContainerCrashInfo == nil {
 		as.ContainerCrashInfo = make([]*crashData.ContainerCrashInfo, 0, 10)
 	}
@@ -79,7 +86,7 @@ func (as *AppStats) CrashSince(since time.Duration) []*crashData.ContainerCrashI
 
 	crashInfoList := as.ContainerCrashInfo
 	if crashInfoList != nil {
-		sinceTime := time.Now().Add(since)
+		sinceTime := clock.Now().Add(since)
 		crashInfoSize := len(crashInfoList)
 		filteredCrashInfoList := make([]*crashData.ContainerCrashInfo, 0)
 		for i := range crashInfoList {
@@ -0,0 +1,100 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventApp
+
+import "time"
+
+// Bounded history of an app's HTTP request and 5xx response counts in fixed
+// time buckets.  The buckets are a ring indexed by bucket number so memory
+// does not grow with the length of the session.  Fields are exported so the
+// history is copied when the event data is cloned.
+type RequestHistory struct {
+	BucketSeconds int64
+	Buckets       []RequestHistoryBucket
+}
+
+type RequestHistoryBucket struct {
+	// Unix time / BucketSeconds of the counts in this bucket
+	Number   int64
+	Count    int64
+	Count5xx int64
+}
+
+func NewRequestHistory(bucketSeconds int64, bucketCount int) *RequestHistory {
+	return &RequestHistory{BucketSeconds: bucketSeconds, Buckets: make([]RequestHistoryBucket, bucketCount)}
+}
+
+func (rh *RequestHistory) bucketNumber(t time.Time) int64 {
+	return t.Unix() / rh.BucketSeconds
+}
+
+func (rh *RequestHistory) Record(t time.Time, statusCode int32) {
+	number := rh.bucketNumber(t)
+	bucket := &rh.Buckets[number%int64(len(rh.Buckets))]
+	if bucket.Number != number {
+		*bucket = RequestHistoryBucket{Number: number}
+	}
+	bucket.Count++
+	if statusCode >= 500 && statusCode < 600 {
+		bucket.Count5xx++
+	}
+}
+
+// Request and 5xx counts of the last seconds / BucketSeconds buckets ending
+// with the bucket holding now (which is still being filled).  span is the
+// time actually covered: the full buckets plus the part of the current
+// bucket up to now, so it is between seconds - BucketSeconds and seconds.
+func (rh *RequestHistory) CountsSince(now time.Time, seconds int64) (count int64, count5xx int64, span time.Duration) {
+	last := rh.bucketNumber(now)
+	first := last - seconds/rh.BucketSeconds + 1
+	if last-first >= int64(len(rh.Buckets)) {
+		first = last - int64(len(rh.Buckets)) + 1
+	}
+	bucketDuration := time.Duration(rh.BucketSeconds) * time.Second
+	span = time.Duration(last-first)*bucketDuration + now.Sub(time.Unix(last*rh.BucketSeconds, 0))
+	for number := first; number <= last; number++ {
+		if number < 0 {
+			continue
+		}
+		bucket := rh.Buckets[number%int64(len(rh.Buckets))]
+		if bucket.Number == number {
+			count += bucket.Count
+			count5xx += bucket.Count5xx
+		}
+	}
+	return count, count5xx, span
+}
+
+// Request and 5xx counts of each bucket in the window ending with the bucket
+// holding now, oldest first.  Buckets with no requests are zero.
+func (rh *RequestHistory) Series(now time.Time) (counts []int64, counts5xx []int64) {
+	bucketCount := len(rh.Buckets)
+	counts = make([]int64, bucketCount)
+	counts5xx = make([]int64, bucketCount)
+	last := rh.bucketNumber(now)
+	for i := 0; i < bucketCount; i++ {
+		number := last - int64(bucketCount-1-i)
+		if number < 0 {
+			continue
+		}
+		bucket := rh.Buckets[number%int64(bucketCount)]
+		if bucket.Number == number {
+			counts[i] = bucket.Count
+			counts5xx[i] = bucket.Count5xx
+		}
+	}
+	return counts, counts5xx
+}
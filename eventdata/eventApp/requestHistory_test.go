@@ -0,0 +1,122 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventApp_test
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RequestHistory", func() {
+	var (
+		history *eventApp.RequestHistory
+		// Start of a 10 second bucket
+		start time.Time
+	)
+
+	BeforeEach(func() {
+		history = eventApp.NewRequestHistory(10, 6)
+		start = time.Unix(1500000000, 0)
+	})
+
+	Describe("Record", func() {
+
+		It("counts requests and 5xx responses in the bucket of the time", func() {
+			history.Record(start, 200)
+			history.Record(start.Add(9*time.Second), 500)
+			history.Record(start.Add(9*time.Second), 599)
+			history.Record(start.Add(10*time.Second), 600)
+			counts, counts5xx := history.Series(start.Add(10 * time.Second))
+			Expect(counts[4:]).To(Equal([]int64{3, 1}))
+			Expect(counts5xx[4:]).To(Equal([]int64{2, 0}))
+		})
+
+		It("reuses the bucket of a time that has rolled out of the window", func() {
+			history.Record(start, 200)
+			history.Record(start, 200)
+			// Same slot as start, 6 buckets later
+			history.Record(start.Add(60*time.Second), 503)
+			counts, counts5xx := history.Series(start.Add(60 * time.Second))
+			Expect(counts).To(Equal([]int64{0, 0, 0, 0, 0, 1}))
+			Expect(counts5xx).To(Equal([]int64{0, 0, 0, 0, 0, 1}))
+		})
+	})
+
+	Describe("Series", func() {
+
+		It("lists the buckets oldest first ending with the current one", func() {
+			history.Record(start.Add(-50*time.Second), 200)
+			history.Record(start.Add(-20*time.Second), 502)
+			history.Record(start, 200)
+			counts, counts5xx := history.Series(start)
+			Expect(counts).To(Equal([]int64{1, 0, 0, 1, 0, 1}))
+			Expect(counts5xx).To(Equal([]int64{0, 0, 0, 1, 0, 0}))
+		})
+
+		It("is zero for buckets older than the last request", func() {
+			history.Record(start, 200)
+			counts, _ := history.Series(start.Add(100 * time.Second))
+			Expect(counts).To(Equal([]int64{0, 0, 0, 0, 0, 0}))
+		})
+	})
+
+	Describe("CountsSince", func() {
+
+		BeforeEach(func() {
+			history.Record(start.Add(-25*time.Second), 200)
+			history.Record(start.Add(-15*time.Second), 503)
+			history.Record(start.Add(-5*time.Second), 200)
+			history.Record(start.Add(2*time.Second), 500)
+			history.Record(start.Add(3*time.Second), 404)
+		})
+
+		It("counts the current bucket up to now", func() {
+			count, count5xx, span := history.CountsSince(start.Add(4*time.Second), 10)
+			Expect(count).To(Equal(int64(2)))
+			Expect(count5xx).To(Equal(int64(1)))
+			Expect(span).To(Equal(4 * time.Second))
+		})
+
+		It("adds the full buckets before the current one", func() {
+			// The -25s bucket is before the 3 buckets of the 30 seconds
+			count, count5xx, span := history.CountsSince(start.Add(4*time.Second), 30)
+			Expect(count).To(Equal(int64(4)))
+			Expect(count5xx).To(Equal(int64(2)))
+			Expect(span).To(Equal(24 * time.Second))
+		})
+
+		It("covers no more than the kept buckets", func() {
+			_, _, span := history.CountsSince(start.Add(4*time.Second), 600)
+			Expect(span).To(Equal(54 * time.Second))
+		})
+
+		It("does not count buckets that have rolled out of the window", func() {
+			count, _, _ := history.CountsSince(start.Add(40*time.Second), 60)
+			// The -25s and -15s buckets are older than the 6 kept buckets
+			Expect(count).To(Equal(int64(3)))
+		})
+
+		It("is zero without requests", func() {
+			count, count5xx, _ := eventApp.NewRequestHistory(10, 6).CountsSince(start, 60)
+			Expect(count).To(Equal(int64(0)))
+			Expect(count5xx).To(Equal(int64(0)))
+		})
+	})
+
+})
@@ -29,6 +29,8 @@ type HttpInfo struct {
 	LastAcivity    *time.Time
 	// Last response time in nano-seconds
 	LastResponseTime int64
+	// Total response body bytes (HttpStartStop contentLength)
+	ResponseContentLength int64
 }
 
 type TrafficStats struct {
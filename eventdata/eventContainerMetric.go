@@ -16,9 +16,8 @@
 package eventdata
 
 import (
-	"time"
-
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 )
 
 func (ed *EventData) containerMetricEvent(msg *events.Envelope) {
@@ -28,9 +27,10 @@ func (ed *EventData) containerMetricEvent(msg *events.Envelope) {
 	appId := containerMetric.GetApplicationId()
 
 	appStats := ed.getAppStats(appId)
+	appStats.LastEventTime = clock.Now()
 	instNum := int(*containerMetric.InstanceIndex)
 	containerStats := ed.getContainerStats(appStats, instNum)
-	containerStats.LastUpdate = time.Now()
+	containerStats.LastUpdate = clock.Now()
 	containerStats.Ip = msg.GetIp()
 	containerStats.ContainerMetric = containerMetric
 
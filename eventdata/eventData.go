@@ -18,11 +18,13 @@ package eventdata
 import (
 	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"regexp"
 	"sync"
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventEventType"
@@ -33,6 +35,21 @@ import (
 	"github.com/mohae/deepcopy"
 )
 
+// Number of shards app stats updates are spread across (by app GUID)
+const eventDataShards = 64
+
+// Storage for updates made while mu is held shared.  Guarded by the shard
+// lock, or by mu held exclusive.  Merged into the EventData maps when mu is
+// held exclusive (see mergeShards) so app scoped updates never write the
+// shared maps.
+type eventDataShard struct {
+	mu sync.Mutex
+	// Apps first seen while mu is held shared
+	newApps map[string]*eventApp.AppStats
+	// Event counts since the last merge
+	eventTypeMap map[events.Envelope_EventType]*eventEventType.EventTypeStats
+}
+
 type EventData struct {
 
 	// This time it set at clone time
@@ -46,16 +63,19 @@ type EventData struct {
 
 	EnableRouteTracking bool
 	TotalEvents         int64
-	mu                  *sync.Mutex
-	logHttpAccess       *EventLogHttpAccess
-	eventProcessor      *EventProcessor
-	apiUrl              string
-	apiUrlRegexp        *regexp.Regexp
-	urlPCF17Regexp      *regexp.Regexp
-	routeUrlRegexp      *regexp.Regexp
+	// Held shared while updating the stats of a single app (along with that
+	// app's shard lock) and exclusive for anything touching multiple apps
+	mu             *sync.RWMutex
+	shards         [eventDataShards]eventDataShard
+	logHttpAccess  *EventLogHttpAccess
+	eventProcessor *EventProcessor
+	apiUrl         string
+	apiUrlRegexp   *regexp.Regexp
+	urlPCF17Regexp *regexp.Regexp
+	routeUrlRegexp *regexp.Regexp
 }
 
-func NewEventData(mu *sync.Mutex, eventProcessor *EventProcessor) *EventData {
+func NewEventData(mu *sync.RWMutex, eventProcessor *EventProcessor) *EventData {
 
 	logHttpAccess := NewEventLogHttpAccess()
 	apiUrl := util.GetApiEndpointNoProtocol(eventProcessor.cliConnection)
@@ -91,10 +111,25 @@ func NewEventData(mu *sync.Mutex, eventProcessor *EventProcessor) *EventData {
 
 func (ed *EventData) Process(instanceId int, msg *events.Envelope) {
 
-	ed.mu.Lock()
-	defer ed.mu.Unlock()
-
-	ed.UpdateEventStats(msg)
+	ed.mu.RLock()
+	appId := ed.appScopeId(msg)
+	if appId == "" {
+		ed.mu.RUnlock()
+		ed.mu.Lock()
+		defer ed.mu.Unlock()
+		// Shared state handlers may iterate AppMap
+		ed.mergeShards()
+		ed.UpdateEventStats(ed.EventTypeMap, msg)
+	} else {
+		defer ed.mu.RUnlock()
+		shard := ed.shard(appId)
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+		if shard.eventTypeMap == nil {
+			shard.eventTypeMap = make(map[events.Envelope_EventType]*eventEventType.EventTypeStats)
+		}
+		ed.UpdateEventStats(shard.eventTypeMap, msg)
+	}
 
 	eventType := msg.GetEventType()
 	switch eventType {
@@ -117,21 +152,68 @@ func (ed *EventData) Process(instanceId int, msg *events.Envelope) {
 
 }
 
-func (ed *EventData) UpdateEventStats(msg *events.Envelope) {
-	eventTypeStats := ed.FindEventTypeStats(msg.GetEventType())
+// Id of the only app whose stats are updated by processing this event or
+// empty if the event touches shared state (cells, routes, metadata refresh
+// queue) and must be processed with exclusive access.  Caller holds mu.
+func (ed *EventData) appScopeId(msg *events.Envelope) string {
+	switch msg.GetEventType() {
+	case events.Envelope_ContainerMetric:
+		return msg.GetContainerMetric().GetApplicationId()
+	case events.Envelope_LogMessage:
+		logMessage := msg.GetLogMessage()
+		if logMessage.GetSourceType() == "API" {
+			return ""
+		}
+		return logMessage.GetAppId()
+	case events.Envelope_HttpStartStop:
+		httpEvent := msg.GetHttpStartStop()
+		if ed.EnableRouteTracking || httpEvent.GetApplicationId() == nil || httpEvent.GetInstanceId() == "" {
+			return ""
+		}
+		return formatUUID(httpEvent.GetApplicationId())
+	}
+	return ""
+}
+
+func (ed *EventData) shard(appId string) *eventDataShard {
+	hash := fnv.New32a()
+	hash.Write([]byte(appId))
+	return &ed.shards[hash.Sum32()%eventDataShards]
+}
+
+// Move the apps and event counts of all shards into AppMap and
+// EventTypeMap.  Caller holds mu exclusive.
+func (ed *EventData) mergeShards() {
+	for i := range ed.shards {
+		shard := &ed.shards[i]
+		for appId, appStats := range shard.newApps {
+			if ed.AppMap[appId] == nil {
+				ed.AppMap[appId] = appStats
+			}
+		}
+		shard.newApps = nil
+		for eventType, shardTypeStats := range shard.eventTypeMap {
+			FindEventTypeStats(ed.EventTypeMap, eventType).Merge(shardTypeStats)
+		}
+		shard.eventTypeMap = nil
+	}
+}
+
+func (ed *EventData) UpdateEventStats(eventTypeMap map[events.Envelope_EventType]*eventEventType.EventTypeStats, msg *events.Envelope) {
+	eventTypeStats := FindEventTypeStats(eventTypeMap, msg.GetEventType())
 	originStats := eventTypeStats.FindEventOriginStats(msg.GetOrigin())
 	eventDetailStats := originStats.FindEventDetailStats(msg)
 	eventDetailStats.EventCount = eventDetailStats.EventCount + 1
-	eventDetailStats.LastEventTime = time.Now()
-	// TODO: Replace above time.Now() with time from msg
+	eventDetailStats.LastEventTime = clock.Now()
+	// TODO: Replace above clock.Now() with time from msg
 	// eventDetailStats.LastEventTime = msg.GetTimestamp
 }
 
-func (ed *EventData) FindEventTypeStats(eventType events.Envelope_EventType) *eventEventType.EventTypeStats {
-	eventTypeStats := ed.EventTypeMap[eventType]
+func FindEventTypeStats(eventTypeMap map[events.Envelope_EventType]*eventEventType.EventTypeStats, eventType events.Envelope_EventType) *eventEventType.EventTypeStats {
+	eventTypeStats := eventTypeMap[eventType]
 	if eventTypeStats == nil {
 		eventTypeStats = eventEventType.NewEventTypeStats(eventType)
-		ed.EventTypeMap[eventType] = eventTypeStats
+		eventTypeMap[eventType] = eventTypeStats
 	}
 	return eventTypeStats
 }
@@ -143,8 +225,9 @@ func (ed *EventData) Clone() *EventData {
 	ed.mu.Lock()
 	defer ed.mu.Unlock()
 
+	ed.mergeShards()
 	clone := deepcopy.Copy(ed).(*EventData)
-	clone.StatsTime = time.Now()
+	clone.StatsTime = clock.Now()
 	clone.eventProcessor = ed.eventProcessor
 
 	for _, appStat := range ed.AppMap {
@@ -232,6 +315,7 @@ func (ed *EventData) Clear() {
 	ed.mu.Lock()
 	defer ed.mu.Unlock()
 
+	ed.mergeShards()
 	ed.AppMap = make(map[string]*eventApp.AppStats)
 	ed.CellMap = make(map[string]*eventCell.CellStats)
 	ed.DomainMap = make(map[string]*eventRoute.DomainStats)
@@ -250,13 +334,23 @@ func (ed *EventData) handleHttpAccessLogLine(logLine string) {
 	ed.logHttpAccess.parseHttpAccessLogLine(logLine)
 }
 
+// Caller holds mu exclusive, or shared with the app's shard lock.  AppMap
+// is only written with mu exclusive so new apps are added to the shard.
 func (ed *EventData) getAppStats(appId string) *eventApp.AppStats {
 
 	appStats := ed.AppMap[appId]
+	if appStats != nil {
+		return appStats
+	}
+	shard := ed.shard(appId)
+	appStats = shard.newApps[appId]
 	if appStats == nil {
 		// New app we haven't seen yet
 		appStats = eventApp.NewAppStats(appId)
-		ed.AppMap[appId] = appStats
+		if shard.newApps == nil {
+			shard.newApps = make(map[string]*eventApp.AppStats)
+		}
+		shard.newApps[appId] = appStats
 	}
 	return appStats
 }
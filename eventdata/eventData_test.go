@@ -0,0 +1,160 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventdata
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"code.cloudfoundry.org/cli/plugin/pluginfakes"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const benchmarkApps = 1000
+
+func newBenchmarkEventData() *EventData {
+	cliConnection := &pluginfakes.FakeCliConnection{}
+	cliConnection.ApiEndpointReturns("https://api.example.com", nil)
+	ep := &EventProcessor{mu: &sync.RWMutex{}, cliConnection: cliConnection}
+	return NewEventData(ep.mu, ep)
+}
+
+// Container metrics and app stdout logs for benchmarkApps apps with 4 instances each
+func benchmarkEnvelopes() []*events.Envelope {
+	envelopes := make([]*events.Envelope, 0, benchmarkApps*8)
+	for app := 0; app < benchmarkApps; app++ {
+		appId := fmt.Sprintf("00000000-0000-0000-0000-%012d", app)
+		for instance := 0; instance < 4; instance++ {
+			envelopes = append(envelopes, &events.Envelope{
+				Origin:    proto.String("rep"),
+				EventType: events.Envelope_ContainerMetric.Enum(),
+				Ip:        proto.String(fmt.Sprintf("10.0.0.%v", instance)),
+				ContainerMetric: &events.ContainerMetric{
+					ApplicationId:    proto.String(appId),
+					InstanceIndex:    proto.Int32(int32(instance)),
+					CpuPercentage:    proto.Float64(12.5),
+					MemoryBytes:      proto.Uint64(256 * 1024 * 1024),
+					DiskBytes:        proto.Uint64(128 * 1024 * 1024),
+					MemoryBytesQuota: proto.Uint64(512 * 1024 * 1024),
+					DiskBytesQuota:   proto.Uint64(1024 * 1024 * 1024),
+				},
+			})
+			envelopes = append(envelopes, &events.Envelope{
+				Origin:    proto.String("rep"),
+				EventType: events.Envelope_LogMessage.Enum(),
+				Ip:        proto.String(fmt.Sprintf("10.0.0.%v", instance)),
+				LogMessage: &events.LogMessage{
+					Message:        []byte("log line"),
+					MessageType:    events.LogMessage_OUT.Enum(),
+					Timestamp:      proto.Int64(1),
+					AppId:          proto.String(appId),
+					SourceType:     proto.String("APP/PROC/WEB"),
+					SourceInstance: proto.String(fmt.Sprintf("%v", instance)),
+				},
+			})
+		}
+	}
+	return envelopes
+}
+
+// Baseline: all events processed by a single worker
+func BenchmarkProcessSingleWorker(b *testing.B) {
+	ed := newBenchmarkEventData()
+	envelopes := benchmarkEnvelopes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ed.Process(0, envelopes[i%len(envelopes)])
+	}
+}
+
+// App scoped events processed concurrently by GOMAXPROCS workers (run with
+// -cpu 1,4,8 to compare with the single worker baseline)
+func BenchmarkProcessWorkers(b *testing.B) {
+	ed := newBenchmarkEventData()
+	envelopes := benchmarkEnvelopes()
+	next := int64(0)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := atomic.AddInt64(&next, 1)
+			ed.Process(0, envelopes[int(i)%len(envelopes)])
+		}
+	})
+}
+
+var _ = Describe("EventData", func() {
+	It("merges the apps and event counts of concurrent workers", func() {
+		ed := newBenchmarkEventData()
+		envelopes := benchmarkEnvelopes()
+		var wg sync.WaitGroup
+		workers := 8
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := w; i < len(envelopes); i += workers {
+					ed.Process(0, envelopes[i])
+				}
+			}(w)
+		}
+		wg.Wait()
+
+		ed.mergeShards()
+		Expect(ed.AppMap).To(HaveLen(benchmarkApps))
+		for _, eventType := range []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_LogMessage} {
+			count := int64(0)
+			for _, originStats := range ed.EventTypeMap[eventType].EventOriginStatsMap {
+				for _, detailStats := range originStats.EventDetailStatsMap {
+					count += detailStats.EventCount
+				}
+			}
+			Expect(count).To(Equal(int64(benchmarkApps * 4)))
+		}
+	})
+})
+
+var _ = Describe("EventProcessor", func() {
+	It("processes all event types until the needed ones are set", func() {
+		ep := &EventProcessor{}
+		Expect(ep.IsEventTypeEnabled(events.Envelope_LogMessage)).To(BeTrue())
+	})
+
+	It("processes only the needed event types", func() {
+		ep := &EventProcessor{}
+		ep.SetNeededEventTypes([]events.Envelope_EventType{events.Envelope_ContainerMetric})
+		Expect(ep.IsEventTypeEnabled(events.Envelope_ContainerMetric)).To(BeTrue())
+		Expect(ep.IsEventTypeEnabled(events.Envelope_LogMessage)).To(BeFalse())
+
+		ep.SetNeededEventTypes(nil)
+		Expect(ep.IsEventTypeEnabled(events.Envelope_LogMessage)).To(BeTrue())
+	})
+
+	It("keeps discarding the event types not selected with -event-types", func() {
+		ep := &EventProcessor{configuredEventTypes: map[events.Envelope_EventType]bool{events.Envelope_ContainerMetric: true}}
+		ep.SetNeededEventTypes([]events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_LogMessage})
+		Expect(ep.IsEventTypeEnabled(events.Envelope_ContainerMetric)).To(BeTrue())
+		Expect(ep.IsEventTypeEnabled(events.Envelope_LogMessage)).To(BeFalse())
+
+		ep.SetNeededEventTypes(nil)
+		Expect(ep.IsEventTypeEnabled(events.Envelope_ContainerMetric)).To(BeTrue())
+		Expect(ep.IsEventTypeEnabled(events.Envelope_HttpStartStop)).To(BeFalse())
+	})
+})
@@ -37,6 +37,20 @@ func (os *EventOriginStats) Id() string {
 	return os.Origin
 }
 
+func (os *EventOriginStats) Merge(other *EventOriginStats) {
+	for edKey, otherDetailStats := range other.EventDetailStatsMap {
+		eventDetailStats := os.EventDetailStatsMap[edKey]
+		if eventDetailStats == nil {
+			os.EventDetailStatsMap[edKey] = otherDetailStats
+			continue
+		}
+		eventDetailStats.EventCount = eventDetailStats.EventCount + otherDetailStats.EventCount
+		if otherDetailStats.LastEventTime.After(eventDetailStats.LastEventTime) {
+			eventDetailStats.LastEventTime = otherDetailStats.LastEventTime
+		}
+	}
+}
+
 func (os *EventOriginStats) FindEventDetailStats(msg *events.Envelope) *EventDetailStats {
 
 	edKey := &EventDetailMapKey{
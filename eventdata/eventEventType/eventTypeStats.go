@@ -39,6 +39,18 @@ func (ets *EventTypeStats) Id() string {
 	return ets.EventType.String()
 }
 
+// Add the counts of other, which are from a different set of events
+func (ets *EventTypeStats) Merge(other *EventTypeStats) {
+	for origin, otherOriginStats := range other.EventOriginStatsMap {
+		originStats := ets.EventOriginStatsMap[origin]
+		if originStats == nil {
+			ets.EventOriginStatsMap[origin] = otherOriginStats
+			continue
+		}
+		originStats.Merge(otherOriginStats)
+	}
+}
+
 func (ets *EventTypeStats) FindEventOriginStats(origin string) *EventOriginStats {
 	originStats := ets.EventOriginStatsMap[origin]
 	if originStats == nil {
@@ -17,9 +17,11 @@ package eventdata
 
 import (
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
@@ -80,7 +82,7 @@ func (ed *EventData) httpStartStopEventForApp(msg *events.Envelope) {
 	//toplog.Debug("index: %v\n", instIndex)
 	//toplog.Debug("index mem: %v\n", msg.GetHttpStartStop().InstanceIndex)
 	//fmt.Printf("index: %v\n", instIndex)
-	ed.TotalEvents++
+	atomic.AddInt64(&ed.TotalEvents, 1)
 	appId := formatUUID(appUUID)
 	//c.ui.Say("**** appId:%v ****", appId)
 
@@ -88,6 +90,7 @@ func (ed *EventData) httpStartStopEventForApp(msg *events.Envelope) {
 	if appStats.AppUUID == nil {
 		appStats.AppUUID = appUUID
 	}
+	appStats.LastEventTime = clock.Now()
 
 	containerTraffic := ed.getContainerTraffic(appStats, instId)
 
@@ -115,6 +118,12 @@ func (ed *EventData) httpStartStopEventForApp(msg *events.Envelope) {
 	now := time.Unix(0, msg.GetTimestamp())
 	httpInfo.LastAcivity = &now
 	httpInfo.LastResponseTime = responseTimeNano
+	httpInfo.ResponseContentLength = httpInfo.ResponseContentLength + httpStartStopEvent.GetContentLength()
+
+	if appStats.RequestHistory == nil {
+		appStats.RequestHistory = eventApp.NewRequestHistory(config.RequestHistoryBucketSeconds, config.RequestHistoryBuckets())
+	}
+	appStats.RequestHistory.Record(appStats.LastEventTime, statusCode)
 }
 
 // Format of HttpStartStop in PCF 1.6
@@ -346,7 +355,7 @@ func (ed *EventData) updateRouteStats(domain string, host string, port string, p
 	//appRouteStats.HttpMethod[httpEvent.GetMethod()] = appRouteStats.HttpMethod[httpEvent.GetMethod()] + 1
 
 	// TODO: Should this really come from msg.GetTimestamp() instead of marking it with when we processed the event?
-	httpMethodStats.LastAccess = time.Now()
+	httpMethodStats.LastAccess = clock.Now()
 
 	httpMethodStats.HttpStatusCode[httpEvent.GetStatusCode()] = httpMethodStats.HttpStatusCode[httpEvent.GetStatusCode()] + 1
 
@@ -22,6 +22,8 @@ import (
 
 	"github.com/Jeffail/gabs"
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
 
@@ -30,6 +32,7 @@ func (ed *EventData) logMessageEvent(msg *events.Envelope) {
 	logMessage := msg.GetLogMessage()
 	appId := logMessage.GetAppId()
 	appStats := ed.getAppStats(appId)
+	appStats.LastEventTime = clock.Now()
 	sourceType := logMessage.GetSourceType()
 	switch {
 	case sourceType == "CELL":
@@ -142,10 +145,31 @@ func (ed *EventData) logApiCall(msg *events.Envelope) {
 		if crashTimestampField != nil {
 			timestamp64 := crashTimestampField.Data().(float64)
 			timestamp := time.Unix(0, int64(timestamp64))
-			appStats.AddCrashInfo(instNum, &timestamp, exitDescription)
+			crashInfo := crashData.NewContainerCrashInfo(instNum, &timestamp, exitDescription)
+			crashInfo.ExitReason = reason
+			crashInfo.InstanceGuid = stringField(fields["instance"])
+			crashInfo.CellId = stringField(fields["cell_id"])
+			if instNum >= 0 && instNum < len(appStats.ContainerArray) && appStats.ContainerArray[instNum] != nil {
+				crashInfo.CellIp = appStats.ContainerArray[instNum].Ip
+			}
+			if crashCountField := fields["crash_count"]; crashCountField != nil {
+				if crashCount, ok := crashCountField.Data().(float64); ok {
+					crashInfo.CrashCount = int(crashCount)
+				}
+			}
+			appStats.AddCrashInfo(crashInfo)
 		}
 		toplog.Info("CRASH of app %v exit desc: %v", appMetadata.Name, exitDescription)
 
 	}
 
 }
+
+// String value of an optional JSON field, empty if missing or not a string
+func stringField(field *gabs.Container) string {
+	if field == nil {
+		return ""
+	}
+	value, _ := field.Data().(string)
+	return value
+}
@@ -17,7 +17,9 @@ package eventdata
 
 import (
 	"regexp"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin"
@@ -25,6 +27,8 @@ import (
 	"strings"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventRoute"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata"
@@ -36,7 +40,8 @@ import (
 
 type EventProcessor struct {
 	eventCount         int64
-	mu                 *sync.Mutex
+	lastEventTime      int64 // unix nanos of the last envelope, 0 if none yet
+	mu                 *sync.RWMutex
 	currentEventData   *EventData
 	displayedEventData *EventData
 	cliConnection      plugin.CliConnection
@@ -47,21 +52,33 @@ type EventProcessor struct {
 
 	eventRateCounterMap     map[events.Envelope_EventType]*util.RateCounter
 	eventRateCounterMapLock sync.Mutex
+
+	// Event types selected with -event-types (nil selects all), see config.EventTypes
+	configuredEventTypes map[events.Envelope_EventType]bool
+	// Event types that are processed: the configured types the open views
+	// use (see SetNeededEventTypes).  Holds a map[events.Envelope_EventType]bool,
+	// a nil map processes all types.
+	enabledEventTypes atomic.Value
+	// Sorted names of the enabled types, used to log changes
+	enabledEventTypeNames string
+	enabledEventTypesMu   sync.Mutex
 }
 
 func NewEventProcessor(cliConnection plugin.CliConnection, privileged bool) *EventProcessor {
 
-	mu := &sync.Mutex{}
+	mu := &sync.RWMutex{}
 
 	metadataManager := metadata.NewGlobalManager(cliConnection)
 
 	ep := &EventProcessor{
-		mu:                  mu,
-		cliConnection:       cliConnection,
-		privileged:          privileged,
-		metadataManager:     metadataManager,
-		eventRateCounterMap: make(map[events.Envelope_EventType]*util.RateCounter),
+		mu:                   mu,
+		cliConnection:        cliConnection,
+		privileged:           privileged,
+		metadataManager:      metadataManager,
+		eventRateCounterMap:  make(map[events.Envelope_EventType]*util.RateCounter),
+		configuredEventTypes: loadConfiguredEventTypes(),
 	}
+	ep.enabledEventTypes.Store(ep.configuredEventTypes)
 
 	ep.currentEventData = NewEventData(mu, ep)
 	ep.displayedEventData = NewEventData(mu, ep)
@@ -73,6 +90,7 @@ func NewEventProcessor(cliConnection plugin.CliConnection, privileged bool) *Eve
 
 func (ep *EventProcessor) Process(instanceId int, msg *events.Envelope) {
 
+	atomic.StoreInt64(&ep.lastEventTime, clock.Now().UnixNano())
 	eventType := msg.GetEventType()
 	ep.eventRateCounterMapLock.Lock()
 	eventCounter := ep.eventRateCounterMap[eventType]
@@ -82,9 +100,82 @@ func (ep *EventProcessor) Process(instanceId int, msg *events.Envelope) {
 	}
 	ep.eventRateCounterMapLock.Unlock()
 	eventCounter.Incr()
+	if !ep.IsEventTypeEnabled(eventType) && !isApiLogMessage(msg) {
+		return
+	}
 	ep.currentEventData.Process(instanceId, msg)
 }
 
+// API log messages carry the app state changes and crashes that all views
+// rely on.  They are few so they are processed even when LogMessage is not.
+func isApiLogMessage(msg *events.Envelope) bool {
+	return msg.GetEventType() == events.Envelope_LogMessage && msg.GetLogMessage().GetSourceType() == "API"
+}
+
+func loadConfiguredEventTypes() map[events.Envelope_EventType]bool {
+	typeNames := config.EventTypes()
+	if len(typeNames) == 0 {
+		return nil
+	}
+	enabled := make(map[events.Envelope_EventType]bool)
+	for _, name := range typeNames {
+		if value, ok := events.Envelope_EventType_value[name]; ok {
+			enabled[events.Envelope_EventType(value)] = true
+		}
+	}
+	toplog.Info("Only processing firehose event types: %v", strings.Join(typeNames, ","))
+	return enabled
+}
+
+// IsEventTypeEnabled returns false if events of this type are discarded
+func (ep *EventProcessor) IsEventTypeEnabled(eventType events.Envelope_EventType) bool {
+	enabled, _ := ep.enabledEventTypes.Load().(map[events.Envelope_EventType]bool)
+	return enabled == nil || enabled[eventType]
+}
+
+// SetNeededEventTypes limits processing to the given event types (those the
+// open views use).  Types not selected with -event-types stay discarded.
+// A nil list processes all configured types.
+func (ep *EventProcessor) SetNeededEventTypes(eventTypes []events.Envelope_EventType) {
+	var enabled map[events.Envelope_EventType]bool
+	if eventTypes == nil {
+		enabled = ep.configuredEventTypes
+	} else {
+		enabled = make(map[events.Envelope_EventType]bool)
+		for _, eventType := range eventTypes {
+			if ep.configuredEventTypes == nil || ep.configuredEventTypes[eventType] {
+				enabled[eventType] = true
+			}
+		}
+	}
+	ep.enabledEventTypes.Store(enabled)
+
+	names := "all"
+	if enabled != nil {
+		nameList := make([]string, 0, len(enabled))
+		for eventType := range enabled {
+			nameList = append(nameList, eventType.String())
+		}
+		sort.Strings(nameList)
+		names = strings.Join(nameList, ",")
+	}
+	ep.enabledEventTypesMu.Lock()
+	defer ep.enabledEventTypesMu.Unlock()
+	if names != ep.enabledEventTypeNames {
+		ep.enabledEventTypeNames = names
+		toplog.Info("Processing firehose event types: %v", names)
+	}
+}
+
+// Time the last envelope was processed, zero time if none yet
+func (ep *EventProcessor) GetLastEventTime() time.Time {
+	lastEventTime := atomic.LoadInt64(&ep.lastEventTime)
+	if lastEventTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, lastEventTime)
+}
+
 func (ep *EventProcessor) GetCliConnection() plugin.CliConnection {
 	return ep.cliConnection
 }
@@ -125,9 +216,25 @@ func (ep *EventProcessor) LoadCacheAndSeedData() {
 	ep.SeedStatsFromMetadata()
 }
 
-func (ep *EventProcessor) FlushCache() {
-	ep.metadataManager.FlushCache()
+// LoadDeferredRouteData loads route metadata in the background if it was
+// skipped at startup (quiet start) and seeds the route stats once loaded
+func (ep *EventProcessor) LoadDeferredRouteData() {
+	if ep.metadataManager.IsRouteMetadataLoaded() {
+		return
+	}
+	go func() {
+		if ep.metadataManager.LoadRouteMetadataIfDeferred() {
+			ep.SeedStatsFromMetadata()
+		}
+	}()
+}
+
+func (ep *EventProcessor) FlushCache() bool {
+	if !ep.metadataManager.FlushCache() {
+		return false
+	}
 	ep.SeedStatsFromMetadata()
+	return true
 }
 
 func (ep *EventProcessor) SeedStatsFromMetadata() {
@@ -149,6 +256,7 @@ func (ep *EventProcessor) SeedStatsFromMetadata() {
 
 func (ep *EventProcessor) seedAppMap() {
 
+	ep.currentEventData.mergeShards()
 	currentStatsMap := ep.currentEventData.AppMap
 	for _, app := range ep.metadataManager.GetAppMdManager().AllApps() {
 		appId := app.Guid
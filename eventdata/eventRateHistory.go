@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/mohae/deepcopy"
 )
@@ -125,7 +126,7 @@ func (erh *EventRateHistory) GetCurrentRate() int {
 
 func (erh *EventRateHistory) start() {
 	ticker := time.NewTicker(time.Second)
-	erh.lastTimeHistoryCapture = time.Now()
+	erh.lastTimeHistoryCapture = clock.Now()
 	go func() {
 		toplog.Info("EventRateHistory tracking started")
 		for t := range ticker.C {
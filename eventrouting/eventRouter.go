@@ -16,24 +16,56 @@
 package eventrouting
 
 import (
+	"hash/fnv"
 	"sync/atomic"
 	"time"
 
 	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
 
 type EventRouter struct {
-	eventCount uint64
-	startTime  time.Time
-	processor  *eventdata.EventProcessor
+	eventCount   uint64
+	droppedCount uint64
+	// Non-zero while ingestion is paused (see SetIngestPaused)
+	ingestPaused int32
+	startTime    time.Time
+	processor    *eventdata.EventProcessor
+
+	// Bounded queues between the nozzles and the processor, one per worker
+	// (see config.EventWorkers).  If processing can not keep up with the
+	// firehose, events are dropped (and counted) instead of growing memory
+	// without bound.
+	queues        []chan *routedEvent
+	queueCapacity int
+}
+
+type routedEvent struct {
+	instanceId int
+	msg        *events.Envelope
 }
 
 func NewEventRouter(processor *eventdata.EventProcessor) *EventRouter {
-	return &EventRouter{
-		processor: processor,
-		startTime: time.Now(),
+	workers := config.EventWorkers()
+	// The configured capacity is shared by all the worker queues
+	workerCapacity := config.EventQueueCapacity() / workers
+	if workerCapacity < 1 {
+		workerCapacity = 1
 	}
+	er := &EventRouter{
+		processor:     processor,
+		startTime:     time.Now(),
+		queues:        make([]chan *routedEvent, workers),
+		queueCapacity: workerCapacity * workers,
+	}
+	for i := range er.queues {
+		er.queues[i] = make(chan *routedEvent, workerCapacity)
+		go er.processQueue(er.queues[i])
+	}
+	go er.warnDroppedEvents()
+	return er
 }
 
 func (er *EventRouter) GetProcessor() *eventdata.EventProcessor {
@@ -44,17 +76,95 @@ func (er *EventRouter) GetEventCount() uint64 {
 	return atomic.LoadUint64(&er.eventCount)
 }
 
+// Number of events dropped because the event queue was full
+func (er *EventRouter) GetDroppedCount() uint64 {
+	return atomic.LoadUint64(&er.droppedCount)
+}
+
 func (er *EventRouter) GetStartTime() time.Time {
 	return er.startTime
 }
 
 func (er *EventRouter) Clear() {
 	atomic.StoreUint64(&er.eventCount, 0)
+	atomic.StoreUint64(&er.droppedCount, 0)
 	er.startTime = time.Now()
 	er.processor.ClearStats()
 }
 
+// Pause / resume ingestion.  While paused events received from the nozzles
+// are discarded without being counted or processed.  The nozzles stay
+// connected so the firehose keeps sending events.
+func (er *EventRouter) SetIngestPaused(paused bool) {
+	value := int32(0)
+	if paused {
+		value = 1
+	}
+	atomic.StoreInt32(&er.ingestPaused, value)
+}
+
+func (er *EventRouter) IsIngestPaused() bool {
+	return atomic.LoadInt32(&er.ingestPaused) != 0
+}
+
 func (er *EventRouter) Route(instanceId int, msg *events.Envelope) {
+	if er.IsIngestPaused() {
+		return
+	}
 	atomic.AddUint64(&er.eventCount, 1)
-	er.processor.Process(instanceId, msg)
+	queue := er.queues[er.workerIndex(msg)]
+	select {
+	case queue <- &routedEvent{instanceId: instanceId, msg: msg}:
+	default:
+		atomic.AddUint64(&er.droppedCount, 1)
+	}
+}
+
+// Events for the same app go to the same worker so they are processed in
+// the order received.  Events not tied to an app all go to the first worker.
+func (er *EventRouter) workerIndex(msg *events.Envelope) int {
+	if len(er.queues) == 1 {
+		return 0
+	}
+	hash := fnv.New32a()
+	switch msg.GetEventType() {
+	case events.Envelope_ContainerMetric:
+		hash.Write([]byte(msg.GetContainerMetric().GetApplicationId()))
+	case events.Envelope_LogMessage:
+		hash.Write([]byte(msg.GetLogMessage().GetAppId()))
+	case events.Envelope_HttpStartStop:
+		appUUID := msg.GetHttpStartStop().GetApplicationId()
+		if appUUID == nil {
+			return 0
+		}
+		low, high := appUUID.GetLow(), appUUID.GetHigh()
+		hash.Write([]byte{byte(low), byte(low >> 8), byte(low >> 16), byte(low >> 24),
+			byte(high), byte(high >> 8), byte(high >> 16), byte(high >> 24)})
+	default:
+		return 0
+	}
+	return int(hash.Sum32() % uint32(len(er.queues)))
+}
+
+func (er *EventRouter) processQueue(queue chan *routedEvent) {
+	for event := range queue {
+		er.processor.Process(event.instanceId, event.msg)
+	}
+}
+
+func (er *EventRouter) warnDroppedEvents() {
+	interval := time.Duration(config.DroppedEventsWarnSeconds) * time.Second
+	lastDroppedCount := uint64(0)
+	for range time.Tick(interval) {
+		droppedCount := er.GetDroppedCount()
+		if droppedCount < lastDroppedCount {
+			// Stats were cleared
+			lastDroppedCount = 0
+		}
+		if droppedCount > lastDroppedCount {
+			toplog.Warn("Event queue full (capacity: %v) - dropped %v events in last %v (total dropped: %v)",
+				er.queueCapacity, droppedCount-lastDroppedCount, interval, droppedCount)
+		}
+		lastDroppedCount = droppedCount
+	}
 }
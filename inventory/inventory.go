@@ -0,0 +1,118 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
+)
+
+// Metadata of one app from the app metadata cache with the org, space and
+// stack names resolved.  Unlike a snapshot (see snapshot package) there
+// are no live stats.  Names that can not be resolved are the GUID.
+type App struct {
+	Guid        string
+	Name        string
+	OrgGuid     string
+	OrgName     string
+	SpaceGuid   string
+	SpaceName   string
+	State       string
+	Instances   int
+	MemoryMB    int
+	DiskQuotaMB int
+	Buildpack   string
+	StackGuid   string
+	StackName   string
+}
+
+func (a *App) Id() string {
+	return a.Guid
+}
+
+// All apps in the app metadata cache sorted by org, space and app name
+func Build(appMetadataList []*app.AppMetadata) []*App {
+	apps := make([]*App, 0, len(appMetadataList))
+	for _, appMetadata := range appMetadataList {
+		apps = append(apps, newApp(appMetadata))
+	}
+	sort.Sort(appsByOrgSpaceName(apps))
+	return apps
+}
+
+type appsByOrgSpaceName []*App
+
+func (s appsByOrgSpaceName) Len() int      { return len(s) }
+func (s appsByOrgSpaceName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s appsByOrgSpaceName) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	switch {
+	case a.OrgName != b.OrgName:
+		return a.OrgName < b.OrgName
+	case a.SpaceName != b.SpaceName:
+		return a.SpaceName < b.SpaceName
+	}
+	return a.Name < b.Name
+}
+
+func newApp(appMetadata *app.AppMetadata) *App {
+	spaceName := appMetadata.SpaceName
+	if spaceName == "" {
+		spaceName = space.FindSpaceMetadata(appMetadata.SpaceGuid).Name
+	}
+	orgGuid, orgName := appMetadata.OrgGuid, appMetadata.OrgName
+	if orgName == "" {
+		orgGuid = space.FindSpaceMetadata(appMetadata.SpaceGuid).OrgGuid
+		orgName = org.FindOrgMetadata(orgGuid).Name
+	}
+	buildpack := appMetadata.Buildpack
+	if buildpack == "" {
+		buildpack = appMetadata.DetectedBuildpack
+	}
+	return &App{
+		Guid:        appMetadata.Guid,
+		Name:        nameOrGuid(appMetadata.Name, appMetadata.Guid),
+		OrgGuid:     orgGuid,
+		OrgName:     nameOrGuid(orgName, orgGuid),
+		SpaceGuid:   appMetadata.SpaceGuid,
+		SpaceName:   nameOrGuid(spaceName, appMetadata.SpaceGuid),
+		State:       appMetadata.State,
+		Instances:   int(appMetadata.Instances),
+		MemoryMB:    int(appMetadata.MemoryMB),
+		DiskQuotaMB: int(appMetadata.DiskQuotaMB),
+		Buildpack:   buildpack,
+		StackGuid:   appMetadata.StackGuid,
+		StackName:   nameOrGuid(stack.FindStackMetadata(appMetadata.StackGuid).Name, appMetadata.StackGuid),
+	}
+}
+
+func nameOrGuid(name, guid string) string {
+	if name == "" {
+		return guid
+	}
+	return name
+}
+
+// File name used when exporting from the UI, e.g., top-inventory-20170301-154500.csv
+func DefaultFileName(takenAt time.Time, format string) string {
+	return fmt.Sprintf("top-inventory-%v.%v", takenAt.Format("20060102-150405"), format)
+}
@@ -16,7 +16,9 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	// _ "net/http/pprof"
@@ -24,6 +26,10 @@ import (
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/snapshot"
 	"github.com/ecsteam/cloudfoundry-top-plugin/top"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/simonleung8/flags"
@@ -53,10 +59,58 @@ func (c *TopCmd) GetMetadata() plugin.PluginMetadata {
 				UsageDetails: plugin.Usage{
 					Usage: "cf top",
 					Options: map[string]string{
-						"no-top-check": "-ntc, do not check if there are other instances of top running on this OS",
-						"cygwin":       "-c, force run under cygwin (Use this to run: 'cmd /c start cf top -cygwin' )",
-						"nozzles":      "-n, specify the number of nozzle instances (default: 2)",
-						"debug":        "-d, enable debugging",
+						"no-top-check":           "-ntc, do not check if there are other instances of top running on this OS",
+						"cygwin":                 "-c, force run under cygwin (Use this to run: 'cmd /c start cf top -cygwin' )",
+						"nozzles":                "-n, specify the number of nozzle instances (default: 2)",
+						"event-queue-size":       "-eqs, number of events queued for processing before events are dropped (default: 10000)",
+						"event-workers":          "-ew, number of goroutines processing events, events for an app are always processed in order (default: 1, max: 32)",
+						"subscription-id":        "-sid, firehose subscription id (default: random per process). Instances sharing an id split the firehose",
+						"large-foundation-apps":  "-lfa, warn and offer an org/space scope at startup when the foundation has more apps than this (default: 10000, 0 disables)",
+						"no-scope-prompt":        "-nsp, do not prompt for an org/space scope on large foundations",
+						"api-version":            "-av, CC API version used to load app metadata: auto, v2 or v3 (default: auto)",
+						"crash-filter-minutes":   "-cfm, window in minutes used by the app list crashing apps only filter (default: 10)",
+						"apps-path":              "-ap, CC API base path used to load apps (default: /v2/apps)",
+						"routes-path":            "-rp, CC API base path used to load routes (default: /v2/routes)",
+						"results-per-page":       "-rpp, results-per-page used when loading apps and routes, fewer pages on large foundations (default: CC default, max: 100)",
+						"inline-relations-depth": "-ird, return space (1) or space and org (2) with app metadata instead of loading the space / org lists, fewer requests but larger responses, spaces / orgs without apps are not listed (default: 0)",
+						"api-query":              "-aq, additional query parameters added when loading apps and routes, e.g., -aq inline-relations-depth=1",
+						"record-file":            "-rf, file screen recording (shift-V) is written to in asciicast format (default: cf-top-record.cast)",
+						"record-no-ansi":         "-rna, record plain text frames without colors",
+						"memory-risk-percent":    "-mrp, flag apps with a container using this percent or more of its reserved memory (default: 90)",
+						"cpu-precision":          "-cp, decimal places shown for CPU percent: auto or 0-4 (default: auto)",
+						"cpu-per-core":           "-cpc, show app and container CPU percent divided by the number of cell CPUs",
+						"idle-timeout":           "-it, minutes without a key press before the display is paused, any key resumes (default: 0, disabled)",
+						"idle-pause-ingest":      "-ipi, also discard firehose events while paused by idle timeout (the firehose stays connected)",
+						"capture-file":           "-cap, write all received firehose envelopes to this file for later replay",
+						"replay-file":            "-rpl, read firehose envelopes from a capture file instead of connecting to the firehose",
+						"replay-fast":            "-rplf, replay the capture file as fast as possible instead of with its original timing",
+						"log-wrap":               "-lw, start with line wrap on in the log view (toggle with 'l' in the log view, the toggle is saved)",
+						"log-short-time":         "-lst, start with short time-only timestamps in the log view (toggle with 's' in the log view, the toggle is saved)",
+						"log-scroll-resume":      "-lsr, resume auto scroll in the log view after this many seconds without scrolling (default: 0, disabled)",
+						"firehose-idle-timeout":  "-fit, reconnect the firehose after this many seconds without any event (default: 120, 0 disables)",
+						"api-trace":              "-at, log method, url, status, duration and size of every CC API request in the log view (enables debug logging)",
+						"api-trace-verbose":      "-atv, also log the CC API response bodies (use with -api-trace)",
+						"metadata-warn-minutes":  "-mwm, highlight the metadata age shown in the header when older than this many minutes (default: 60)",
+						"start-view":             "-sv, view opened at startup: apps, orgs, cells, cell-health, routes, event-history, events, capacity-plan or log (app list with debug log open) (default: apps)",
+						"highlight-track":        "-ht, how a refresh that moves the highlighted row keeps it in view: pin (same screen line), view (scroll only when it leaves the view) or off (default: pin)",
+						"scope":                  "-sc, start scoped to an org or space, e.g., -sc myorg or -sc myorg/myspace. App, space, route and crash metadata is only loaded for the scope",
+						"recent-deploy-minutes":  "-rdm, mark apps in the app list updated (pushed, scaled, restaged) within this many minutes (default: 30, 0 disables)",
+						"request-chart-minutes":  "-rcm, minutes of request and 5xx counts charted in the app HTTP view (default: 10)",
+						"refresh-budget-ms":      "-rbm, log a warning with the view name and row count when a display refresh step starts taking longer than this many milliseconds, logged again when it is back within budget (default: 1000, 0 disables)",
+						"event-types":            "-et, comma separated firehose event types to process, e.g., -et ContainerMetric,LogMessage (default: all)",
+						"proxy":                  "-px, proxy for the firehose connections, e.g., -px http://user@proxy.example.com:3128 (password from CF_TOP_PROXY_PASSWORD if not in the URL) (default: HTTPS_PROXY / HTTP_PROXY environment variables)",
+						"no-proxy":               "-np, comma separated hosts, domains (.example.com), IPs or CIDRs connected to without the proxy (default: NO_PROXY environment variable)",
+						"skip-ssl-validation":    "-ssv, skip TLS certificate verification for the firehose connections -- insecure, for dev / test foundations with self-signed certificates (CC API requests follow 'cf api --skip-ssl-validation')",
+						"ca-bundle":              "-cab, PEM file of CA certificates trusted for the firehose connections in addition to the system roots",
+						"top-talkers-count":      "-ttc, number of apps ranked in the top talkers view (default: 20)",
+						"health-thresholds":      "-hst, health scores below which app list rows are tinted yellow and red: warn,bad (default: 80,50)",
+						"health-penalties":       "-hp, health score penalties: per crash in the last hour, all desired instances not reporting, all HTTP responses 5xx: crash,missing,5xx (default: 25,100,300)",
+						"quiet-start":            "-qs, faster startup on large foundations -- route/domain metadata is loaded when the route view is first opened",
+						"kiosk":                  "-k, kiosk mode for unattended displays -- disables all keys that change state",
+						"report":                 "-rpt, run without the UI for this many seconds, then print a one line JSON summary (events, log message counts, worst app) to stdout and exit",
+						"diff-snapshots":         "-ds, compare two exported snapshots and exit, e.g., -ds before.json,after.json",
+						"debug":                  "-d, enable debugging",
+						"test-messages":          "-tm, enable keys that inject test messages into the log (development use)",
 					},
 				},
 			},
@@ -94,6 +148,12 @@ func (c *TopCmd) Run(cliConnection plugin.CliConnection, args []string) {
 		return
 	}
 
+	if len(options.DiffSnapshotFiles) == 2 {
+		// Offline mode -- no firehose connection needed
+		c.diffSnapshots(options.DiffSnapshotFiles[0], options.DiffSnapshotFiles[1])
+		return
+	}
+
 	if options.Nozzles > 10 {
 		c.ui.Failed("Can not specify more then 10 nozzle instances")
 		return
@@ -102,6 +162,14 @@ func (c *TopCmd) Run(cliConnection plugin.CliConnection, args []string) {
 		c.ui.Failed("Can not specify less then 1 nozzle instance")
 		return
 	}
+	if options.EventQueueSize < 1 {
+		c.ui.Failed("Can not specify an event queue size less than 1")
+		return
+	}
+	if options.EventWorkers < 1 || options.EventWorkers > config.MaxEventWorkers {
+		c.ui.Failed("Can not specify event workers less than 1 or more than %v", config.MaxEventWorkers)
+		return
+	}
 
 	// TODO: THis is for testing only
 	/*
@@ -167,18 +235,129 @@ func (c *TopCmd) Run(cliConnection plugin.CliConnection, args []string) {
 	client.Start()
 }
 
+func (c *TopCmd) diffSnapshots(beforeFile, afterFile string) {
+	before, err := snapshot.Load(beforeFile)
+	if err != nil {
+		c.ui.Failed(err.Error())
+		return
+	}
+	after, err := snapshot.Load(afterFile)
+	if err != nil {
+		c.ui.Failed(err.Error())
+		return
+	}
+	fmt.Printf("Before: %v (%v apps)\n", before.TakenAt.Format("01-02-2006 15:04:05"), len(before.Apps))
+	fmt.Printf("After:  %v (%v apps)\n\n", after.TakenAt.Format("01-02-2006 15:04:05"), len(after.Apps))
+	fmt.Print(snapshot.DiffTable(snapshot.Diff(before, after)))
+}
+
 func (c *TopCmd) buildClientOptions(args []string) *top.ClientOptions {
 	var debug bool
 	var noTopCheck bool
 	var cygwin bool
 	var nozzles int
+	var testMessages bool
+	var subscriptionID string
+	var kiosk bool
+	var diffSnapshotFiles []string
+	var eventQueueSize int
+	var eventWorkers int
+	var largeFoundationAppCount int
+	var noScopePrompt bool
+	var apiVersion string
+	var quietStart bool
+	var crashFilterMinutes int
+	var logScrollResume int
+	var firehoseIdleTimeout int
+	var appsPath string
+	var routesPath string
+	var resultsPerPage int
+	var apiQuery string
+	var inlineRelationsDepth int
+	var idleTimeoutMinutes int
+	var memoryRiskPercent int
+	var recordFileName string
+	var recordNoAnsi bool
+	var cpuPrecision int
+	var cpuPerCore bool
+	var idlePauseIngest bool
+	var captureFile string
+	var replayFile string
+	var replayFast bool
+	var logWrap bool
+	var logShortTime bool
+	var eventTypes []string
+	var metadataWarnMinutes int
+	var recentDeployMinutes int
+	var refreshBudgetMS int
+	var requestHistoryMinutes int
+	var startView string
+	var highlightTrack string
+	var scope string
+	var apiTrace bool
+	var apiTraceVerbose bool
+	var reportSeconds int
+	var proxy string
+	var noProxy string
+	var skipSSLValidation bool
+	var caBundleFile string
+	var topTalkersCount int
+	healthThresholds := []int{config.DefaultHealthScoreWarn, config.DefaultHealthScoreBad}
+	healthPenalties := []int{config.DefaultHealthCrashPenalty, config.DefaultHealthMissingInstancePenalty, config.DefaultHealthHttp5xxPenalty}
 
 	fc := flags.New()
 	fc.NewBoolFlag("debug", "d", "used for debugging")
 	fc.NewBoolFlag("no-top-check", "ntc", "Do not check if there are other instances of top running")
 	fc.NewBoolFlag("cygwin", "c", "force run under cygwin (Use this to run: 'cmd /c start cf top -cygwin' )")
 	fc.NewIntFlagWithDefault("nozzles", "n", "number of nozzles", 2)
-	//fc.NewStringFlag("filter", "f", "specify message filter such as LogMessage, ValueMetric, CounterEvent, HttpStartStop")
+	fc.NewIntFlagWithDefault("event-queue-size", "eqs", "number of events queued for processing before events are dropped", config.DefaultEventQueueCapacity)
+	fc.NewIntFlagWithDefault("event-workers", "ew", "number of goroutines processing events", config.DefaultEventWorkers)
+	fc.NewIntFlagWithDefault("large-foundation-apps", "lfa", "warn at startup when foundation has more apps than this (0 disables)", config.DefaultLargeFoundationAppCount)
+	fc.NewBoolFlag("no-scope-prompt", "nsp", "do not prompt for an org/space scope on large foundations")
+	fc.NewStringFlag("api-version", "av", "CC API version used to load app metadata: auto, v2 or v3 (default: auto)")
+	fc.NewIntFlagWithDefault("crash-filter-minutes", "cfm", "window in minutes used by the crashing apps only filter", config.DefaultCrashFilterMinutes)
+	fc.NewStringFlag("apps-path", "ap", "CC API base path used to load apps (default: /v2/apps)")
+	fc.NewStringFlag("routes-path", "rp", "CC API base path used to load routes (default: /v2/routes)")
+	fc.NewIntFlagWithDefault("results-per-page", "rpp", "results-per-page used when loading apps and routes (0 uses CC default)", 0)
+	fc.NewIntFlagWithDefault("inline-relations-depth", "ird", "return space (1) or space and org (2) names with app metadata", 0)
+	fc.NewStringFlag("api-query", "aq", "additional query parameters added when loading apps and routes")
+	fc.NewStringFlag("record-file", "rf", "file screen recording is written to (default: cf-top-record.cast)")
+	fc.NewBoolFlag("record-no-ansi", "rna", "record plain text frames without colors")
+	fc.NewIntFlagWithDefault("memory-risk-percent", "mrp", "flag apps with a container using this percent or more of its reserved memory", config.DefaultMemoryRiskPercent)
+	fc.NewStringFlag("cpu-precision", "cp", "decimal places shown for CPU percent: auto or 0-4 (default: auto)")
+	fc.NewBoolFlag("cpu-per-core", "cpc", "show CPU percent divided by the number of cell CPUs")
+	fc.NewIntFlagWithDefault("idle-timeout", "it", "minutes without a key press before display is paused (0 disables)", 0)
+	fc.NewBoolFlag("idle-pause-ingest", "ipi", "discard firehose events while paused by idle timeout")
+	fc.NewStringFlag("capture-file", "cap", "write all received firehose envelopes to this file")
+	fc.NewStringFlag("replay-file", "rpl", "read firehose envelopes from a capture file")
+	fc.NewBoolFlag("replay-fast", "rplf", "replay the capture file as fast as possible")
+	fc.NewBoolFlag("log-wrap", "lw", "start with line wrap on in the log view")
+	fc.NewBoolFlag("log-short-time", "lst", "start with short time-only timestamps in the log view")
+	fc.NewIntFlagWithDefault("log-scroll-resume", "lsr", "resume auto scroll in the log view after this many seconds without scrolling", 0)
+	fc.NewIntFlagWithDefault("firehose-idle-timeout", "fit", "reconnect the firehose after this many seconds without events (0 disables)", config.DefaultFirehoseIdleTimeoutSeconds)
+	fc.NewBoolFlag("api-trace", "at", "log every CC API request in the log view (enables debug logging)")
+	fc.NewBoolFlag("api-trace-verbose", "atv", "also log CC API response bodies (requires api-trace)")
+	fc.NewIntFlagWithDefault("metadata-warn-minutes", "mwm", "highlight metadata age in header when older than this", config.DefaultMetadataWarnMinutes)
+	fc.NewStringFlag("start-view", "sv", "view opened at startup (default: apps)")
+	fc.NewStringFlag("highlight-track", "ht", "keep the highlighted row in view on refresh: pin, view or off (default: pin)")
+	fc.NewStringFlag("scope", "sc", "start scoped to an org or space: org or org/space")
+	fc.NewIntFlagWithDefault("recent-deploy-minutes", "rdm", "mark apps updated within this many minutes (0 disables)", config.DefaultRecentDeployMinutes)
+	fc.NewIntFlagWithDefault("request-chart-minutes", "rcm", "length of the request rate chart in the app HTTP view", config.DefaultRequestHistoryMinutes)
+	fc.NewIntFlagWithDefault("refresh-budget-ms", "rbm", "warn in the log when a display refresh step takes longer than this (0 disables)", config.DefaultRefreshBudgetMS)
+	fc.NewStringFlag("event-types", "et", "comma separated firehose event types to process, others are discarded (default: all)")
+	fc.NewStringFlag("proxy", "px", "proxy URL for the firehose connections (default: HTTPS_PROXY environment variable)")
+	fc.NewStringFlag("no-proxy", "np", "comma separated hosts, domains, IPs or CIDRs not proxied (default: NO_PROXY environment variable)")
+	fc.NewBoolFlag("skip-ssl-validation", "ssv", "skip TLS certificate verification for the firehose connections (insecure)")
+	fc.NewStringFlag("ca-bundle", "cab", "PEM file of additional CA certificates trusted for the firehose connections")
+	fc.NewIntFlagWithDefault("top-talkers-count", "ttc", "number of apps ranked in the top talkers view", config.DefaultTopTalkersCount)
+	fc.NewStringFlag("health-thresholds", "hst", "health scores below which app rows are tinted yellow and red: warn,bad (default: 80,50)")
+	fc.NewStringFlag("health-penalties", "hp", "health score penalties: crash,missing,5xx (default: 25,100,300)")
+	fc.NewBoolFlag("quiet-start", "qs", "skip route metadata at startup, load when route view is opened")
+	fc.NewBoolFlag("test-messages", "tm", "enable keys that inject test messages into the log")
+	fc.NewStringFlag("subscription-id", "sid", "firehose subscription id")
+	fc.NewBoolFlag("kiosk", "k", "disable all keys that change state")
+	fc.NewIntFlagWithDefault("report", "rpt", "run headless for this many seconds and print a one line JSON summary", 0)
+	fc.NewStringFlag("diff-snapshots", "ds", "compare two exported snapshots: before.json,after.json")
 	err := fc.Parse(args[1:]...)
 
 	if err != nil {
@@ -194,18 +373,347 @@ func (c *TopCmd) buildClientOptions(args []string) *top.ClientOptions {
 	if fc.IsSet("cygwin") {
 		cygwin = fc.Bool("cygwin")
 	}
+	if fc.IsSet("test-messages") {
+		testMessages = fc.Bool("test-messages")
+	}
+	if fc.IsSet("kiosk") {
+		kiosk = fc.Bool("kiosk")
+	}
+	if fc.IsSet("quiet-start") {
+		quietStart = fc.Bool("quiet-start")
+	}
+	if fc.IsSet("record-file") {
+		recordFileName = fc.String("record-file")
+	}
+	if fc.IsSet("record-no-ansi") {
+		recordNoAnsi = fc.Bool("record-no-ansi")
+	}
+	if fc.IsSet("cpu-per-core") {
+		cpuPerCore = fc.Bool("cpu-per-core")
+	}
+	if fc.IsSet("idle-pause-ingest") {
+		idlePauseIngest = fc.Bool("idle-pause-ingest")
+	}
+	if fc.IsSet("log-wrap") {
+		logWrap = fc.Bool("log-wrap")
+	}
+	if fc.IsSet("log-short-time") {
+		logShortTime = fc.Bool("log-short-time")
+	}
+	if fc.IsSet("api-trace") {
+		apiTrace = fc.Bool("api-trace")
+	}
+	if fc.IsSet("api-trace-verbose") {
+		apiTraceVerbose = fc.Bool("api-trace-verbose")
+	}
+	if fc.IsSet("capture-file") {
+		captureFile = fc.String("capture-file")
+	}
+	if fc.IsSet("replay-file") {
+		replayFile = fc.String("replay-file")
+	}
+	if fc.IsSet("replay-fast") {
+		replayFast = fc.Bool("replay-fast")
+	}
+	if fc.IsSet("event-types") {
+		eventTypes, err = parseEventTypes(fc.String("event-types"))
+		if err != nil {
+			c.ui.Failed(err.Error())
+			return nil
+		}
+	}
+	if captureFile != "" && replayFile != "" {
+		c.ui.Failed("capture-file and replay-file can not be used together")
+		return nil
+	}
+	if fc.IsSet("no-scope-prompt") {
+		noScopePrompt = fc.Bool("no-scope-prompt")
+	}
+	if fc.IsSet("diff-snapshots") {
+		diffSnapshotFiles = strings.Split(fc.String("diff-snapshots"), ",")
+		if len(diffSnapshotFiles) != 2 {
+			c.ui.Failed("diff-snapshots requires two comma separated files: before.json,after.json")
+			return nil
+		}
+	}
+	reportSeconds = fc.Int("report")
+	if reportSeconds < 0 {
+		c.ui.Failed("report must be 0 (disabled) or greater")
+		return nil
+	}
+	apiVersion = common.API_AUTO
+	if fc.IsSet("api-version") {
+		apiVersion = strings.ToLower(fc.String("api-version"))
+		if apiVersion != common.API_AUTO && apiVersion != common.API_V2 && apiVersion != common.API_V3 {
+			c.ui.Failed("api-version must be one of: auto, v2, v3")
+			return nil
+		}
+	}
+	if fc.IsSet("apps-path") {
+		appsPath = fc.String("apps-path")
+	}
+	if fc.IsSet("routes-path") {
+		routesPath = fc.String("routes-path")
+	}
+	if fc.IsSet("api-query") {
+		apiQuery = fc.String("api-query")
+	}
+	// Not set (0) uses the CC default, an explicit value must be in range
+	resultsPerPage = fc.Int("results-per-page")
+	if fc.IsSet("results-per-page") && (resultsPerPage < 1 || resultsPerPage > common.MaxResultsPerPage) {
+		c.ui.Failed(fmt.Sprintf("results-per-page must be between 1 and %v", common.MaxResultsPerPage))
+		return nil
+	}
+	inlineRelationsDepth = fc.Int("inline-relations-depth")
+	if inlineRelationsDepth < 0 || inlineRelationsDepth > common.MaxInlineRelationsDepth {
+		c.ui.Failed(fmt.Sprintf("inline-relations-depth must be between 0 and %v", common.MaxInlineRelationsDepth))
+		return nil
+	}
+	memoryRiskPercent = fc.Int("memory-risk-percent")
+	if memoryRiskPercent < 1 || memoryRiskPercent > 100 {
+		c.ui.Failed("memory-risk-percent must be between 1 and 100")
+		return nil
+	}
+	cpuPrecision = config.CpuPrecisionAuto
+	if fc.IsSet("cpu-precision") && strings.ToLower(fc.String("cpu-precision")) != "auto" {
+		cpuPrecision, err = strconv.Atoi(fc.String("cpu-precision"))
+		if err != nil || cpuPrecision < 0 || cpuPrecision > config.MaxCpuPrecision {
+			c.ui.Failed(fmt.Sprintf("cpu-precision must be auto or between 0 and %v", config.MaxCpuPrecision))
+			return nil
+		}
+	}
+	idleTimeoutMinutes = fc.Int("idle-timeout")
+	if idleTimeoutMinutes < 0 {
+		c.ui.Failed("idle-timeout must be 0 (disabled) or greater")
+		return nil
+	}
+	if fc.IsSet("subscription-id") {
+		subscriptionID = fc.String("subscription-id")
+	}
 
 	nozzles = fc.Int("nozzles")
-
-	/*
-		if fc.IsSet("filter") {
-			filter = fc.String("filter")
+	eventQueueSize = fc.Int("event-queue-size")
+	eventWorkers = fc.Int("event-workers")
+	largeFoundationAppCount = fc.Int("large-foundation-apps")
+	if largeFoundationAppCount < 0 {
+		c.ui.Failed("large-foundation-apps must be 0 (disabled) or greater")
+		return nil
+	}
+	crashFilterMinutes = fc.Int("crash-filter-minutes")
+	if crashFilterMinutes < 1 {
+		c.ui.Failed("crash-filter-minutes must be 1 or greater")
+		return nil
+	}
+	logScrollResume = fc.Int("log-scroll-resume")
+	if logScrollResume < 0 {
+		c.ui.Failed("log-scroll-resume must be 0 (disabled) or greater")
+		return nil
+	}
+	firehoseIdleTimeout = fc.Int("firehose-idle-timeout")
+	if firehoseIdleTimeout < 0 {
+		c.ui.Failed("firehose-idle-timeout must be 0 (disabled) or greater")
+		return nil
+	}
+	metadataWarnMinutes = fc.Int("metadata-warn-minutes")
+	if metadataWarnMinutes < 1 {
+		c.ui.Failed("metadata-warn-minutes must be 1 or greater")
+		return nil
+	}
+	startView = config.StartViewApps
+	if fc.IsSet("start-view") {
+		startView = strings.ToLower(fc.String("start-view"))
+		if !isStartViewName(startView) {
+			c.ui.Failed("start-view must be one of: " + strings.Join(config.StartViewNames, ", "))
+			return nil
 		}
-	*/
+	}
+	highlightTrack = config.HighlightTrackPin
+	if fc.IsSet("highlight-track") {
+		highlightTrack = strings.ToLower(fc.String("highlight-track"))
+		if !isHighlightTrackMode(highlightTrack) {
+			c.ui.Failed("highlight-track must be one of: " + strings.Join(config.HighlightTrackModes, ", "))
+			return nil
+		}
+	}
+	if fc.IsSet("scope") {
+		scope = strings.Trim(fc.String("scope"), "/")
+		if scope == "" || strings.Count(scope, "/") > 1 {
+			c.ui.Failed("scope must be an org name or org/space, e.g., -sc myorg/myspace")
+			return nil
+		}
+	}
+	recentDeployMinutes = fc.Int("recent-deploy-minutes")
+	if recentDeployMinutes < 0 {
+		c.ui.Failed("recent-deploy-minutes must be 0 (disabled) or greater")
+		return nil
+	}
+	requestHistoryMinutes = fc.Int("request-chart-minutes")
+	if requestHistoryMinutes < 1 {
+		c.ui.Failed("request-chart-minutes must be 1 or greater")
+		return nil
+	}
+	refreshBudgetMS = fc.Int("refresh-budget-ms")
+	if refreshBudgetMS < 0 {
+		c.ui.Failed("refresh-budget-ms must be 0 (disabled) or greater")
+		return nil
+	}
+	if fc.IsSet("proxy") {
+		proxy = fc.String("proxy")
+		if _, err := config.ParseProxyUrl(proxy); err != nil {
+			c.ui.Failed("proxy: " + err.Error())
+			return nil
+		}
+	}
+	if fc.IsSet("no-proxy") {
+		noProxy = fc.String("no-proxy")
+	}
+	if fc.IsSet("skip-ssl-validation") {
+		skipSSLValidation = fc.Bool("skip-ssl-validation")
+	}
+	if fc.IsSet("ca-bundle") {
+		caBundleFile = fc.String("ca-bundle")
+		if _, err := os.Stat(caBundleFile); err != nil {
+			c.ui.Failed("ca-bundle: " + err.Error())
+			return nil
+		}
+	}
+	topTalkersCount = fc.Int("top-talkers-count")
+	if topTalkersCount < 1 {
+		c.ui.Failed("top-talkers-count must be 1 or greater")
+		return nil
+	}
+	if fc.IsSet("health-thresholds") {
+		healthThresholds, err = parseIntList(fc.String("health-thresholds"), 2)
+		if err != nil || healthThresholds[0] > 100 || healthThresholds[1] > healthThresholds[0] {
+			c.ui.Failed("health-thresholds must be warn,bad scores between 0 and 100 with bad not above warn, e.g., -hst 80,50")
+			return nil
+		}
+	}
+	if fc.IsSet("health-penalties") {
+		healthPenalties, err = parseIntList(fc.String("health-penalties"), 3)
+		if err != nil {
+			c.ui.Failed("health-penalties must be three penalties of 0 or greater: crash,missing,5xx, e.g., -hp 25,100,300")
+			return nil
+		}
+	}
 	return &top.ClientOptions{
-		Debug:      debug,
-		NoTopCheck: noTopCheck,
-		Cygwin:     cygwin,
-		Nozzles:    nozzles,
+		Debug:                   debug,
+		NoTopCheck:              noTopCheck,
+		Cygwin:                  cygwin,
+		Nozzles:                 nozzles,
+		TestMessages:            testMessages,
+		SubscriptionID:          subscriptionID,
+		Kiosk:                   kiosk,
+		DiffSnapshotFiles:       diffSnapshotFiles,
+		EventQueueSize:          eventQueueSize,
+		EventWorkers:            eventWorkers,
+		LargeFoundationAppCount: largeFoundationAppCount,
+		QuietStart:              quietStart,
+		ApiVersion:              apiVersion,
+		NoScopePrompt:           noScopePrompt,
+		CrashFilterMinutes:      crashFilterMinutes,
+		AppsPath:                appsPath,
+		RoutesPath:              routesPath,
+		ResultsPerPage:          resultsPerPage,
+		ApiQuery:                apiQuery,
+		InlineRelationsDepth:    inlineRelationsDepth,
+		IdleTimeoutMinutes:      idleTimeoutMinutes,
+		IdlePauseIngest:         idlePauseIngest,
+		MemoryRiskPercent:       memoryRiskPercent,
+		RecordFileName:          recordFileName,
+		RecordNoAnsi:            recordNoAnsi,
+		CpuPrecision:            cpuPrecision,
+		CpuPerCore:              cpuPerCore,
+		CaptureFile:             captureFile,
+		ReplayFile:              replayFile,
+		ReplayFast:              replayFast,
+		LogWrap:                 logWrap,
+		LogShortTime:            logShortTime,
+		LogScrollResumeSeconds:  logScrollResume,
+		FirehoseIdleSeconds:     firehoseIdleTimeout,
+		EventTypes:              eventTypes,
+		MetadataWarnMinutes:     metadataWarnMinutes,
+		RecentDeployMinutes:     recentDeployMinutes,
+		RefreshBudgetMS:         refreshBudgetMS,
+		RequestHistoryMinutes:   requestHistoryMinutes,
+		StartView:               startView,
+		HighlightTrack:          highlightTrack,
+		Scope:                   scope,
+		ApiTrace:                apiTrace,
+		ApiTraceVerbose:         apiTraceVerbose,
+		ReportSeconds:           reportSeconds,
+		Proxy:                   proxy,
+		NoProxy:                 noProxy,
+		SkipSSLValidation:       skipSSLValidation,
+		CaBundleFile:            caBundleFile,
+		TopTalkersCount:         topTalkersCount,
+		HealthScoreWarn:         healthThresholds[0],
+		HealthScoreBad:          healthThresholds[1],
+		HealthCrashPenalty:      healthPenalties[0],
+		HealthMissingPenalty:    healthPenalties[1],
+		Health5xxPenalty:        healthPenalties[2],
+	}
+}
+
+// parseIntList parses a comma separated list of count integers of 0 or greater
+func parseIntList(list string, count int) ([]int, error) {
+	parts := strings.Split(list, ",")
+	if len(parts) != count {
+		return nil, fmt.Errorf("expected %v comma separated values", count)
+	}
+	values := make([]int, count)
+	for i, part := range parts {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("negative value %v", value)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func isStartViewName(name string) bool {
+	for _, startViewName := range config.StartViewNames {
+		if name == startViewName {
+			return true
+		}
+	}
+	return false
+}
+
+func isHighlightTrackMode(mode string) bool {
+	for _, trackMode := range config.HighlightTrackModes {
+		if mode == trackMode {
+			return true
+		}
+	}
+	return false
+}
+
+// parseEventTypes validates a comma separated list of firehose envelope
+// type names (case insensitive) and returns them in their canonical form
+func parseEventTypes(list string) ([]string, error) {
+	canonical := make(map[string]string)
+	for name := range events.Envelope_EventType_value {
+		canonical[strings.ToLower(name)] = name
+	}
+	eventTypes := make([]string, 0)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		typeName, ok := canonical[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("event-types: unknown event type %v (use HttpStartStop, LogMessage, ValueMetric, CounterEvent, Error or ContainerMetric)", name)
+		}
+		eventTypes = append(eventTypes, typeName)
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("event-types requires at least one event type")
 	}
+	return eventTypes, nil
 }
@@ -15,13 +15,22 @@
 
 package app
 
-import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+import (
+	"encoding/json"
+	"time"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+)
+
+// Resources are left raw so each one can be decoded on its own and a
+// single malformed record does not cause the whole page to be dropped
 type AppResponse struct {
-	Count     int           `json:"total_results"`
-	Pages     int           `json:"total_pages"`
-	NextUrl   string        `json:"next_url"`
-	Resources []AppResource `json:"resources"`
+	Count     int               `json:"total_results"`
+	Pages     int               `json:"total_pages"`
+	NextUrl   string            `json:"next_url"`
+	Resources []json.RawMessage `json:"resources"`
 }
 
 type AppResource struct {
@@ -42,6 +51,9 @@ type App struct {
 	Instances   float64                `json:"instances,omitempty"`
 	State       string                 `json:"state,omitempty"`
 	EnableSsh   bool                   `json:"enable_ssh,omitempty"`
+	// The v3 app resource does not include enable_ssh, EnableSsh is false
+	// but not known
+	SshUnknown bool `json:"-"`
 
 	PackageState        string `json:"package_state,omitempty"`
 	StagingFailedReason string `json:"staging_failed_reason,omitempty"`
@@ -54,9 +66,10 @@ type App struct {
 	Buildpack         string `json:"buildpack,omitempty"`
 	DetectedBuildpack string `json:"detected_buildpack,omitempty"`
 
-	HealthcheckType    string  `json:"health_check_type,omitempty"`
-	HealthcheckTimeout float64 `json:"health_check_timeout,omitempty"`
-	Production         bool    `json:"production,omitempty"`
+	HealthcheckType         string  `json:"health_check_type,omitempty"`
+	HealthcheckTimeout      float64 `json:"health_check_timeout,omitempty"`
+	HealthcheckHttpEndpoint string  `json:"health_check_http_endpoint,omitempty"`
+	Production              bool    `json:"production,omitempty"`
 	//app.crash event fields
 	//Index           float64 `json:"index,omitempty"`
 	//ExitStatus      string  `json:"exit_status,omitempty"`
@@ -64,4 +77,100 @@ type App struct {
 	//ExitReason      string  `json:"reason,omitempty"`
 	// "package_updated_at": "2016-11-15T19:56:52Z",
 	PackageUpdatedAt string `json:"package_updated_at,omitempty"`
+	// From the resource metadata (not the entity), see applyMeta.  Zero
+	// if not reported.  UpdatedAt changes on any app update, e.g., push,
+	// scale or restage.
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Only returned when requested with inline-relations-depth (see
+	// common.EndpointConfig).  Cleared by applyInlineRelations.
+	Space *inlineSpaceResource `json:"space,omitempty"`
+
+	// Populated from the inline space / org when available, otherwise
+	// empty and the space and org caches are used
+	SpaceName string `json:"-"`
+	OrgGuid   string `json:"-"`
+	OrgName   string `json:"-"`
+}
+
+// The full space (and org) entity is returned inline so the space and org
+// caches can be filled from the app list (see inlineRelations)
+type inlineSpaceResource struct {
+	Meta   common.Meta `json:"metadata"`
+	Entity struct {
+		space.Space
+		Organization *org.OrgResource `json:"organization,omitempty"`
+	} `json:"entity"`
+}
+
+// Spaces and orgs returned inline with the apps of a list, key: guid
+type inlineRelations struct {
+	spaces map[string]space.Space
+	orgs   map[string]org.Org
+}
+
+func newInlineRelations() *inlineRelations {
+	return &inlineRelations{
+		spaces: make(map[string]space.Space),
+		orgs:   make(map[string]org.Org),
+	}
+}
+
+// Depth of the relations returned: 2 if orgs were returned, 1 if only
+// spaces, 0 if none
+func (relations *inlineRelations) depth() int {
+	switch {
+	case len(relations.spaces) > 0 && len(relations.orgs) > 0:
+		return 2
+	case len(relations.spaces) > 0:
+		return 1
+	}
+	return 0
+}
+
+func (relations *inlineRelations) spaceList() []space.Space {
+	spaces := make([]space.Space, 0, len(relations.spaces))
+	for _, s := range relations.spaces {
+		spaces = append(spaces, s)
+	}
+	return spaces
+}
+
+func (relations *inlineRelations) orgList() []org.Org {
+	orgs := make([]org.Org, 0, len(relations.orgs))
+	for _, o := range relations.orgs {
+		orgs = append(orgs, o)
+	}
+	return orgs
+}
+
+// Copy the fields only returned in the resource metadata to the app
+func (app *App) applyMeta(meta common.Meta) {
+	app.Guid = meta.Guid
+	app.CreatedAt = meta.CreatedTime()
+	app.UpdatedAt = meta.UpdatedTime()
+}
+
+// Copy space and org names returned inline with the app to the app fields
+// and, if relations is not nil, add the space and org to it
+func (app *App) applyInlineRelations(relations *inlineRelations) {
+	if app.Space == nil {
+		return
+	}
+	inlineSpace := app.Space.Entity.Space
+	inlineSpace.Guid = app.Space.Meta.Guid
+	app.SpaceName = inlineSpace.Name
+	app.OrgGuid = inlineSpace.OrgGuid
+	if relations != nil && inlineSpace.Guid != "" {
+		relations.spaces[inlineSpace.Guid] = inlineSpace
+	}
+	if inlineOrg := app.Space.Entity.Organization; inlineOrg != nil {
+		app.OrgName = inlineOrg.Entity.Name
+		if relations != nil && inlineOrg.Meta.Guid != "" {
+			inlineOrg.Entity.Guid = inlineOrg.Meta.Guid
+			relations.orgs[inlineOrg.Entity.Guid] = inlineOrg.Entity
+		}
+	}
+	app.Space = nil
 }
@@ -15,7 +15,11 @@
 
 package app
 
-import "time"
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+)
 
 const MEGABYTE = (1024 * 1024)
 
@@ -30,7 +34,7 @@ func NewAppMetadata(app App) *AppMetadata {
 
 	appMetadata := &AppMetadata{}
 	appMetadata.App = &app
-	appMetadata.CacheTime = time.Now()
+	appMetadata.CacheTime = clock.Now()
 	return appMetadata
 }
 
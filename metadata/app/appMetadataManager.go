@@ -17,17 +17,46 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
 
 type AppMetadataManager struct {
+	// Guards appMetadataMap and the values computed from it.  The map is
+	// replaced by the metadata thread and read by the UI.
 	appMetadataMap map[string]*AppMetadata
 	mu             sync.Mutex
+
+	// Reserved totals for all STARTED apps.  These are recomputed each
+	// time the app metadata cache is successfully reloaded.
+	totalMemoryAllStartedApps float64
+	totalDiskAllStartedApps   float64
+
+	// Set after the first successful load so later loads can be
+	// compared to log apps created / deleted during the session
+	appCacheLoaded bool
+
+	// Time the app cache was last (fully) loaded, guarded by mu
+	cacheTime *time.Time
+
+	// App names used by apps in more than one space
+	duplicateNames map[string]bool
+
+	// Depth of the space / org relations returned inline by the last app
+	// cache load and used to fill the space (1) and org (2) caches
+	inlineRelationsDepth int
 }
 
 func NewAppMetadataManager() *AppMetadataManager {
@@ -39,14 +68,54 @@ func NewAppMetadataManager() *AppMetadataManager {
 }
 
 func (mdMgr *AppMetadataManager) AppMetadataSize() int {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
 	return len(mdMgr.appMetadataMap)
 }
 
+// The map is replaced on each app cache load and must not be modified, use
+// SetAppMetadata / DeleteAppMetadata
 func (mdMgr *AppMetadataManager) GetAppMetadataMap() map[string]*AppMetadata {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
 	return mdMgr.appMetadataMap
 }
 
+// Replace the cached metadata of one (reloaded) app
+func (mdMgr *AppMetadataManager) SetAppMetadata(appMetadata *AppMetadata) {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	metadataMap := mdMgr.copyAppMetadataMap()
+	metadataMap[appMetadata.Guid] = appMetadata
+	mdMgr.appMetadataMap = metadataMap
+	mdMgr.computeStartedAppTotals()
+	mdMgr.computeDuplicateNames()
+}
+
+// Remove an app that no longer exists from the cache
+func (mdMgr *AppMetadataManager) DeleteAppMetadata(appId string) {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	metadataMap := mdMgr.copyAppMetadataMap()
+	delete(metadataMap, appId)
+	mdMgr.appMetadataMap = metadataMap
+	mdMgr.computeStartedAppTotals()
+	mdMgr.computeDuplicateNames()
+}
+
+// The map is copied on change so maps returned by GetAppMetadataMap can
+// be read without the lock.  Caller must hold mdMgr.mu
+func (mdMgr *AppMetadataManager) copyAppMetadataMap() map[string]*AppMetadata {
+	metadataMap := make(map[string]*AppMetadata, len(mdMgr.appMetadataMap)+1)
+	for appId, appMetadata := range mdMgr.appMetadataMap {
+		metadataMap[appId] = appMetadata
+	}
+	return metadataMap
+}
+
 func (mdMgr *AppMetadataManager) AllApps() []*AppMetadata {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
 	appsMetadataArray := []*AppMetadata{}
 	for _, appMetadata := range mdMgr.appMetadataMap {
 		appsMetadataArray = append(appsMetadataArray, appMetadata)
@@ -54,12 +123,42 @@ func (mdMgr *AppMetadataManager) AllApps() []*AppMetadata {
 	return appsMetadataArray
 }
 
+// Total reserved memory (bytes) of all instances of all STARTED apps
+func (mdMgr *AppMetadataManager) GetTotalMemoryAllStartedApps() float64 {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	return mdMgr.totalMemoryAllStartedApps
+}
+
+// Total reserved disk (bytes) of all instances of all STARTED apps
+func (mdMgr *AppMetadataManager) GetTotalDiskAllStartedApps() float64 {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	return mdMgr.totalDiskAllStartedApps
+}
+
+// Caller must hold mdMgr.mu
+func (mdMgr *AppMetadataManager) computeStartedAppTotals() {
+	totalMemory := float64(0)
+	totalDisk := float64(0)
+	for _, appMetadata := range mdMgr.appMetadataMap {
+		if appMetadata.State == "STARTED" {
+			totalMemory = totalMemory + ((appMetadata.MemoryMB * util.MEGABYTE) * appMetadata.Instances)
+			totalDisk = totalDisk + ((appMetadata.DiskQuotaMB * util.MEGABYTE) * appMetadata.Instances)
+		}
+	}
+	mdMgr.totalMemoryAllStartedApps = totalMemory
+	mdMgr.totalDiskAllStartedApps = totalDisk
+}
+
 func (mdMgr *AppMetadataManager) FindAppMetadata(appId string) *AppMetadata {
 	return mdMgr.FindAppMetadataInternal(appId, true)
 }
 
 func (mdMgr *AppMetadataManager) FindAppMetadataInternal(appId string, requestLoadIfNotFound bool) *AppMetadata {
+	mdMgr.mu.Lock()
 	appMetadata := mdMgr.appMetadataMap[appId]
+	mdMgr.mu.Unlock()
 	if appMetadata == nil {
 		appMetadata = NewAppMetadataById(appId)
 		if requestLoadIfNotFound {
@@ -72,12 +171,27 @@ func (mdMgr *AppMetadataManager) FindAppMetadataInternal(appId string, requestLo
 	return appMetadata
 }
 
+// Load all apps.  With inline relations the spaces (and orgs) returned
+// with the apps replace the space (and org) caches, see InlineRelationsDepth.
 func (mdMgr *AppMetadataManager) LoadAppCache(cliConnection plugin.CliConnection) {
-	appMetadataArray, err := mdMgr.getAppsMetadata(cliConnection)
+	mdMgr.inlineRelationsDepth = 0
+	appMetadataArray, relations, skipped, err := mdMgr.getAppsMetadata(cliConnection)
 	if err != nil {
 		toplog.Warn("*** app metadata error: %v", err.Error())
 		return
 	}
+	if relations != nil {
+		mdMgr.inlineRelationsDepth = relations.depth()
+		if mdMgr.inlineRelationsDepth >= 1 {
+			space.SetCache(relations.spaceList())
+		}
+		if mdMgr.inlineRelationsDepth >= 2 {
+			org.SetCache(relations.orgList())
+		}
+	}
+	if skipped > 0 {
+		toplog.Info("App metadata loaded with %v malformed record(s) skipped", skipped)
+	}
 
 	metadataMap := make(map[string]*AppMetadata)
 	for _, appMetadata := range appMetadataArray {
@@ -85,11 +199,114 @@ func (mdMgr *AppMetadataManager) LoadAppCache(cliConnection plugin.CliConnection
 		metadataMap[appMetadata.Guid] = appMetadata
 	}
 
+	mdMgr.mu.Lock()
+	oldMetadataMap := mdMgr.appMetadataMap
 	mdMgr.appMetadataMap = metadataMap
+	mdMgr.computeStartedAppTotals()
+	mdMgr.computeDuplicateNames()
+	now := clock.Now()
+	mdMgr.cacheTime = &now
+	mdMgr.mu.Unlock()
+
+	if mdMgr.appCacheLoaded {
+		logAppChanges(oldMetadataMap, metadataMap)
+	}
+	mdMgr.appCacheLoaded = true
+}
+
+// Find the app names used in more than one space.  The names are logged
+// when the set of duplicate names changes.  Caller must hold mdMgr.mu
+func (mdMgr *AppMetadataManager) computeDuplicateNames() {
+	spacesByName := make(map[string]map[string]bool)
+	for _, appMetadata := range mdMgr.appMetadataMap {
+		spaces := spacesByName[appMetadata.Name]
+		if spaces == nil {
+			spaces = make(map[string]bool)
+			spacesByName[appMetadata.Name] = spaces
+		}
+		spaces[appMetadata.SpaceGuid] = true
+	}
+
+	duplicateNames := make(map[string]bool)
+	names := make([]string, 0)
+	changed := false
+	for name, spaces := range spacesByName {
+		if len(spaces) > 1 {
+			duplicateNames[name] = true
+			names = append(names, name)
+			changed = changed || !mdMgr.duplicateNames[name]
+		}
+	}
+	changed = changed || len(duplicateNames) != len(mdMgr.duplicateNames)
+	mdMgr.duplicateNames = duplicateNames
+
+	if changed && len(names) > 0 {
+		sort.Strings(names)
+		shown := names
+		if len(shown) > 10 {
+			shown = append(shown[:10:10], "...")
+		}
+		toplog.Info("%v app name(s) used in more than one space (shift-N in the app list shows the space): %v",
+			len(names), strings.Join(shown, ", "))
+	}
+}
+
+func (mdMgr *AppMetadataManager) IsDuplicateName(name string) bool {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	return mdMgr.duplicateNames[name]
+}
+
+// App name for display, followed by the space name when the name is used
+// in more than one space and config.QualifyDuplicateAppNames is on
+func (mdMgr *AppMetadataManager) DisplayName(appMetadata *AppMetadata) string {
+	if config.QualifyDuplicateAppNames() && mdMgr.IsDuplicateName(appMetadata.Name) {
+		return fmt.Sprintf("%v (%v)", appMetadata.Name, space.FindSpaceName(appMetadata.SpaceGuid))
+	}
+	return appMetadata.Name
+}
+
+// Depth of the relations returned inline by the last app cache load: the
+// space cache was filled (1) or the space and org caches (2).  The caller
+// only needs to load the caches not filled.  Spaces and orgs without any
+// app are not returned inline so they are not in the caches.
+func (mdMgr *AppMetadataManager) InlineRelationsDepth() int {
+	return mdMgr.inlineRelationsDepth
+}
+
+func (mdMgr *AppMetadataManager) GetCacheTime() *time.Time {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	return mdMgr.cacheTime
+}
+
+// Compare the previous and newly loaded app metadata and log any apps that
+// have been created or deleted.  This is based on a diff of the metadata
+// so it does not require access to the CC audit events.
+func logAppChanges(oldMetadataMap, newMetadataMap map[string]*AppMetadata) {
+	for appId, appMetadata := range newMetadataMap {
+		if oldMetadataMap[appId] == nil {
+			toplog.Info("App created: %v", appDescription(appMetadata))
+		}
+	}
+	for appId, appMetadata := range oldMetadataMap {
+		if newMetadataMap[appId] == nil {
+			toplog.Info("App deleted: %v", appDescription(appMetadata))
+		}
+	}
+}
+
+func appDescription(appMetadata *AppMetadata) string {
+	orgName := org.FindOrgNameBySpaceGuid(appMetadata.SpaceGuid)
+	spaceName := space.FindSpaceName(appMetadata.SpaceGuid)
+	return fmt.Sprintf("%v org: %v space: %v", appMetadata.Name, orgName, spaceName)
 }
 
 func (mdMgr *AppMetadataManager) GetAppMetadataInternal(cliConnection plugin.CliConnection, appId string) (*AppMetadata, error) {
-	url := "/v2/apps/" + appId
+	if common.IsV3Api() {
+		return getAppMetadataV3(cliConnection, appId)
+	}
+	url := common.GetEndpointConfig().AppUrl(appId)
 	emptyApp := NewAppMetadataById(appId)
 
 	outputStr, err := common.CallAPI(cliConnection, url)
@@ -102,36 +319,74 @@ func (mdMgr *AppMetadataManager) GetAppMetadataInternal(cliConnection plugin.Cli
 	if err != nil {
 		return emptyApp, err
 	}
-	appResource.Entity.Guid = appResource.Meta.Guid
+	appResource.Entity.applyMeta(appResource.Meta)
+	appResource.Entity.applyInlineRelations(nil)
 	appMetadata := NewAppMetadata(appResource.Entity)
 	return appMetadata, nil
 }
 
-func (mdMgr *AppMetadataManager) getAppsMetadata(cliConnection plugin.CliConnection) ([]*AppMetadata, error) {
-	return GetAppsMetadataFromUrl(cliConnection, "/v2/apps")
+// GetTotalAppCount returns the total_results reported by the first page of
+// the apps list without loading all the app metadata
+func GetTotalAppCount(cliConnection plugin.CliConnection) (int, error) {
+	if common.IsV3Api() {
+		return getTotalAppCountV3(cliConnection)
+	}
+	url := common.GetEndpointConfig().AppsPath + "?results-per-page=1"
+	output, err := common.CallAPI(cliConnection, url)
+	if err != nil {
+		return 0, err
+	}
+	var appResp AppResponse
+	err = json.Unmarshal([]byte(output), &appResp)
+	if err != nil {
+		toplog.Warn("*** %v unmarshal parsing output: %v", url, output)
+		return 0, err
+	}
+	return appResp.Count, nil
 }
 
-func GetAppsMetadataFromUrl(cliConnection plugin.CliConnection, url string) ([]*AppMetadata, error) {
+// The spaces and orgs returned inline are nil unless inline relations
+// are requested (v2 only)
+func (mdMgr *AppMetadataManager) getAppsMetadata(cliConnection plugin.CliConnection) ([]*AppMetadata, *inlineRelations, int, error) {
+	if common.IsV3Api() {
+		apps, skipped, err := getAppsMetadataV3(cliConnection)
+		return apps, nil, skipped, err
+	}
+	var relations *inlineRelations
+	if common.GetEndpointConfig().InlineRelationsDepth > 0 {
+		relations = newInlineRelations()
+	}
+	apps, skipped, err := getAppsMetadataFromUrl(cliConnection, common.GetEndpointConfig().AppsUrl(), relations)
+	return apps, relations, skipped, err
+}
+
+// GetAppsMetadataFromUrl returns the apps that could be parsed along with a
+// count of the resources that were skipped because they were malformed
+func GetAppsMetadataFromUrl(cliConnection plugin.CliConnection, url string) ([]*AppMetadata, int, error) {
+	return getAppsMetadataFromUrl(cliConnection, url, nil)
+}
+
+func getAppsMetadataFromUrl(cliConnection plugin.CliConnection, url string, relations *inlineRelations) ([]*AppMetadata, int, error) {
 
 	appsMetadataArray := []*AppMetadata{}
+	skipped := 0
 
-	handleRequest := func(outputBytes []byte) (data interface{}, nextUrl string, err error) {
-		var appResp AppResponse
-		err = json.Unmarshal(outputBytes, &appResp)
-		if err != nil {
-			toplog.Warn("*** %v unmarshal parsing output: %v", url, string(outputBytes[:]))
-			return appsMetadataArray, "", err
+	handleResource := func(rawResource json.RawMessage) error {
+		var app AppResource
+		if err := json.Unmarshal(rawResource, &app); err != nil {
+			skipped++
+			toplog.Debug("%v skipping malformed resource: %v resource: %v", url, err, string(rawResource))
+			return nil
 		}
-		for _, app := range appResp.Resources {
-			app.Entity.Guid = app.Meta.Guid
-			appMetadata := NewAppMetadata(app.Entity)
-			appsMetadataArray = append(appsMetadataArray, appMetadata)
-		}
-		return appResp, appResp.NextUrl, nil
+		app.Entity.applyMeta(app.Meta)
+		app.Entity.applyInlineRelations(relations)
+		appMetadata := NewAppMetadata(app.Entity)
+		appsMetadataArray = append(appsMetadataArray, appMetadata)
+		return nil
 	}
 
-	err := common.CallPagableAPI(cliConnection, url, handleRequest)
+	err := common.CallPagableResourceAPI(cliConnection, url, common.V2PageParser, handleResource)
 
-	return appsMetadataArray, err
+	return appsMetadataArray, skipped, err
 
 }
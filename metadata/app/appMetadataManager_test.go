@@ -0,0 +1,89 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func testApp(guid, name, spaceGuid string) *AppMetadata {
+	return NewAppMetadata(App{Guid: guid, Name: name, SpaceGuid: spaceGuid,
+		State: "STARTED", Instances: 2, MemoryMB: 256, DiskQuotaMB: 1024})
+}
+
+var _ = Describe("AppMetadataManager", func() {
+	var mdMgr *AppMetadataManager
+
+	BeforeEach(func() {
+		mdMgr = NewAppMetadataManager()
+		mdMgr.SetAppMetadata(testApp("app-1", "web", "space-1"))
+		mdMgr.SetAppMetadata(testApp("app-2", "worker", "space-1"))
+	})
+
+	It("finds app names used in more than one space when an app is reloaded", func() {
+		Expect(mdMgr.IsDuplicateName("web")).To(BeFalse())
+
+		mdMgr.SetAppMetadata(testApp("app-3", "web", "space-2"))
+		Expect(mdMgr.IsDuplicateName("web")).To(BeTrue())
+		Expect(mdMgr.IsDuplicateName("worker")).To(BeFalse())
+	})
+
+	It("does not count apps of the same space as duplicates", func() {
+		mdMgr.SetAppMetadata(testApp("app-3", "web", "space-1"))
+		Expect(mdMgr.IsDuplicateName("web")).To(BeFalse())
+	})
+
+	It("drops a duplicate name when an app is renamed or deleted", func() {
+		mdMgr.SetAppMetadata(testApp("app-3", "web", "space-2"))
+		mdMgr.SetAppMetadata(testApp("app-3", "web-2", "space-2"))
+		Expect(mdMgr.IsDuplicateName("web")).To(BeFalse())
+
+		mdMgr.SetAppMetadata(testApp("app-4", "worker", "space-3"))
+		Expect(mdMgr.IsDuplicateName("worker")).To(BeTrue())
+		mdMgr.DeleteAppMetadata("app-4")
+		Expect(mdMgr.IsDuplicateName("worker")).To(BeFalse())
+	})
+
+	It("recomputes the started app totals when an app is reloaded", func() {
+		Expect(mdMgr.GetTotalMemoryAllStartedApps()).To(Equal(float64(2 * 2 * 256 * MEGABYTE)))
+		stopped := testApp("app-2", "worker", "space-1")
+		stopped.State = "STOPPED"
+		mdMgr.SetAppMetadata(stopped)
+		Expect(mdMgr.GetTotalMemoryAllStartedApps()).To(Equal(float64(2 * 256 * MEGABYTE)))
+		Expect(mdMgr.GetTotalDiskAllStartedApps()).To(Equal(float64(2 * 1024 * MEGABYTE)))
+	})
+
+	It("can be read while apps are reloaded", func() {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				mdMgr.SetAppMetadata(testApp("app-3", "web", "space-2"))
+				mdMgr.DeleteAppMetadata("app-3")
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			mdMgr.IsDuplicateName("web")
+			mdMgr.AllApps()
+		}
+		wg.Wait()
+		Expect(mdMgr.AppMetadataSize()).To(Equal(2))
+	})
+})
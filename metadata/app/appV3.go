@@ -0,0 +1,194 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// The v3 API splits what v2 reports as an app into an app and its
+// processes.  Only the "web" process is mapped back onto the App struct.
+
+type AppV3 struct {
+	Guid      string `json:"guid"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Lifecycle struct {
+		Type string `json:"type"`
+		Data struct {
+			Buildpacks []string `json:"buildpacks"`
+			Stack      string   `json:"stack"`
+		} `json:"data"`
+	} `json:"lifecycle"`
+	Relationships struct {
+		Space struct {
+			Data struct {
+				Guid string `json:"guid"`
+			} `json:"data"`
+		} `json:"space"`
+	} `json:"relationships"`
+}
+
+type ProcessV3 struct {
+	Guid        string  `json:"guid"`
+	Type        string  `json:"type"`
+	Command     string  `json:"command"`
+	Instances   float64 `json:"instances"`
+	MemoryMB    float64 `json:"memory_in_mb"`
+	DiskMB      float64 `json:"disk_in_mb"`
+	HealthCheck struct {
+		Type string `json:"type"`
+		Data struct {
+			Timeout  float64 `json:"timeout"`
+			Endpoint string  `json:"endpoint"`
+		} `json:"data"`
+	} `json:"health_check"`
+	Relationships struct {
+		App struct {
+			Data struct {
+				Guid string `json:"guid"`
+			} `json:"data"`
+		} `json:"app"`
+	} `json:"relationships"`
+}
+
+// toApp maps the v3 app and its web process (which may be nil) onto
+// the v2 shaped App struct used everywhere else
+func (appV3 *AppV3) toApp(process *ProcessV3) App {
+	app := App{
+		Guid:      appV3.Guid,
+		Name:      appV3.Name,
+		SpaceGuid: appV3.Relationships.Space.Data.Guid,
+		State:     appV3.State,
+		StackGuid: stackGuidByName(appV3.Lifecycle.Data.Stack),
+		// v3 has no package_updated_at, updated_at is the closest equivalent
+		PackageUpdatedAt: appV3.UpdatedAt,
+		CreatedAt:        common.ParseTimestamp(appV3.CreatedAt),
+		UpdatedAt:        common.ParseTimestamp(appV3.UpdatedAt),
+		SshUnknown:       true,
+	}
+	if len(appV3.Lifecycle.Data.Buildpacks) > 0 {
+		app.Buildpack = appV3.Lifecycle.Data.Buildpacks[0]
+	}
+	if process != nil {
+		app.Instances = process.Instances
+		app.MemoryMB = process.MemoryMB
+		app.DiskQuotaMB = process.DiskMB
+		app.DetectedStartCmd = process.Command
+		app.HealthcheckType = process.HealthCheck.Type
+		app.HealthcheckTimeout = process.HealthCheck.Data.Timeout
+		app.HealthcheckHttpEndpoint = process.HealthCheck.Data.Endpoint
+	}
+	return app
+}
+
+// v3 reports the stack by name, v2 by guid
+func stackGuidByName(stackName string) string {
+	for _, s := range stack.AllStacks() {
+		if s.Name == stackName {
+			return s.Guid
+		}
+	}
+	// FindStackMetadata falls back to displaying the guid, which is the name here
+	return stackName
+}
+
+func getAppsMetadataV3(cliConnection plugin.CliConnection) ([]*AppMetadata, int, error) {
+
+	appsMetadataArray := []*AppMetadata{}
+	skipped := 0
+
+	processUrl := common.GetEndpointConfig().V3ListUrl("/v3/processes?types=web&per_page=5000")
+	processMap := make(map[string]*ProcessV3)
+	handleProcess := func(rawResource json.RawMessage) error {
+		process := &ProcessV3{}
+		if err := json.Unmarshal(rawResource, process); err != nil {
+			skipped++
+			toplog.Debug("%v skipping malformed resource: %v resource: %v", processUrl, err, string(rawResource))
+			return nil
+		}
+		processMap[process.Relationships.App.Data.Guid] = process
+		return nil
+	}
+	err := common.CallPagableResourceAPI(cliConnection, processUrl, common.V3PageParser, handleProcess)
+	if err != nil {
+		return appsMetadataArray, skipped, err
+	}
+
+	appUrl := common.GetEndpointConfig().V3ListUrl("/v3/apps?per_page=5000")
+	handleApp := func(rawResource json.RawMessage) error {
+		var appV3 AppV3
+		if err := json.Unmarshal(rawResource, &appV3); err != nil {
+			skipped++
+			toplog.Debug("%v skipping malformed resource: %v resource: %v", appUrl, err, string(rawResource))
+			return nil
+		}
+		appsMetadataArray = append(appsMetadataArray, NewAppMetadata(appV3.toApp(processMap[appV3.Guid])))
+		return nil
+	}
+	err = common.CallPagableResourceAPI(cliConnection, appUrl, common.V3PageParser, handleApp)
+
+	return appsMetadataArray, skipped, err
+}
+
+func getAppMetadataV3(cliConnection plugin.CliConnection, appId string) (*AppMetadata, error) {
+	emptyApp := NewAppMetadataById(appId)
+
+	outputStr, err := common.CallAPI(cliConnection, "/v3/apps/"+appId)
+	if err != nil {
+		return emptyApp, err
+	}
+	var appV3 AppV3
+	err = json.Unmarshal([]byte(outputStr), &appV3)
+	if err != nil {
+		return emptyApp, err
+	}
+
+	var process *ProcessV3
+	outputStr, err = common.CallAPI(cliConnection, "/v3/apps/"+appId+"/processes/web")
+	if err == nil {
+		process = &ProcessV3{}
+		if err := json.Unmarshal([]byte(outputStr), process); err != nil {
+			toplog.Debug("Unable to parse web process for app %v: %v", appId, err)
+			process = nil
+		}
+	}
+	return NewAppMetadata(appV3.toApp(process)), nil
+}
+
+func getTotalAppCountV3(cliConnection plugin.CliConnection) (int, error) {
+	url := "/v3/apps?per_page=1"
+	output, err := common.CallAPI(cliConnection, url)
+	if err != nil {
+		return 0, err
+	}
+	var response struct {
+		Pagination common.V3Pagination `json:"pagination"`
+	}
+	err = json.Unmarshal([]byte(output), &response)
+	if err != nil {
+		toplog.Warn("*** %v unmarshal parsing output: %v", url, output)
+		return 0, err
+	}
+	return response.Pagination.TotalResults, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// CC API versions that can be used to load metadata
+const (
+	API_AUTO = "auto"
+	API_V2   = "v2"
+	API_V3   = "v3"
+)
+
+var useV3Api bool
+
+func SetUseV3Api(useV3 bool) {
+	useV3Api = useV3
+}
+
+// IsV3Api returns true if the loaders should use the v3 CC API
+func IsV3Api() bool {
+	return useV3Api
+}
+
+type rootResponse struct {
+	Links map[string]*V3Link `json:"links"`
+}
+
+// ConfigureApiVersion selects the CC API version to use.  With API_AUTO the
+// root endpoint is checked and v3 is used only when the foundation no
+// longer advertises the v2 API.
+func ConfigureApiVersion(cliConnection plugin.CliConnection, apiVersion string) {
+	switch strings.ToLower(apiVersion) {
+	case API_V2:
+		SetUseV3Api(false)
+	case API_V3:
+		SetUseV3Api(true)
+	default:
+		SetUseV3Api(detectV3Api(cliConnection))
+	}
+	if IsV3Api() {
+		toplog.Info("Using CC API v3 to load app metadata")
+	}
+}
+
+func detectV3Api(cliConnection plugin.CliConnection) bool {
+	output, err := CallAPI(cliConnection, "/")
+	if err != nil {
+		toplog.Warn("Unable to detect CC API version, using v2: %v", err)
+		return false
+	}
+	var response rootResponse
+	err = json.Unmarshal([]byte(output), &response)
+	if err != nil {
+		toplog.Debug("Unable to parse CC root endpoint, using v2: %v", err)
+		return false
+	}
+	return response.Links["cloud_controller_v3"] != nil && response.Links["cloud_controller_v2"] == nil
+}
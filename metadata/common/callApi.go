@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
 
@@ -32,8 +33,20 @@ const AUTH_ERROR = "Authentication has expired"
 
 var (
 	curlMutex sync.Mutex
+
+	// Log every CC request (see SetApiTrace)
+	apiTrace        bool
+	apiTraceVerbose bool
 )
 
+// SetApiTrace enables logging the method, url, status, duration and size of
+// every CC request to toplog.ApiCategory (debug level).  Verbose also logs
+// the response bodies.
+func SetApiTrace(trace bool, verbose bool) {
+	apiTrace = trace
+	apiTraceVerbose = trace && verbose
+}
+
 type handleResponseFunc func(outputBytes []byte) (data interface{}, nextUrl string, err error)
 
 func CallAPI(cliConnection plugin.CliConnection, url string) (string, error) {
@@ -45,6 +58,15 @@ func CallAPI(cliConnection plugin.CliConnection, url string) (string, error) {
 	return outputStr, nil
 }
 
+// CallDeleteAPI issues a DELETE (not retried, DELETE calls change state)
+func CallDeleteAPI(cliConnection plugin.CliConnection, url string) (string, error) {
+	output, err := curl(cliConnection, "DELETE", url, "-X", "DELETE")
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(output, ""), nil
+}
+
 func CallPagableAPI(cliConnection plugin.CliConnection, url string, handleResponse handleResponseFunc) error {
 	nextUrl := url
 	for nextUrl != "" {
@@ -90,9 +112,55 @@ func callCurlRetryable(cliConnection plugin.CliConnection, url string) ([]string
 // Having issues calling cli CURL from multiple threads -- response text seems to get merged
 // so lets just single thread the curl calls for now
 func callCurl(cliConnection plugin.CliConnection, url string) ([]string, error) {
+	return curl(cliConnection, "GET", url)
+}
+
+func curl(cliConnection plugin.CliConnection, method string, url string, args ...string) ([]string, error) {
 	curlMutex.Lock()
 	defer curlMutex.Unlock()
-	return cliConnection.CliCommandWithoutTerminalOutput("curl", url)
+	curlArgs := append([]string{"curl", url}, args...)
+	if !apiTrace {
+		return cliConnection.CliCommandWithoutTerminalOutput(curlArgs...)
+	}
+
+	// Include the response headers to get the status, they are removed
+	// again before the output is returned
+	start := clock.Now()
+	output, err := cliConnection.CliCommandWithoutTerminalOutput(append(curlArgs, "-i")...)
+	duration := clock.Since(start)
+	if err != nil {
+		toplog.DebugC(toplog.ApiCategory, "%v %v error: %v duration: %v", method, url, err, duration)
+		return output, err
+	}
+	status, body := splitResponseHeaders(output)
+	byteCount := 0
+	for _, line := range body {
+		byteCount += len(line)
+	}
+	toplog.DebugC(toplog.ApiCategory, "%v %v status: %v duration: %v bytes: %v", method, url, status, duration, byteCount)
+	if apiTraceVerbose {
+		toplog.DebugC(toplog.ApiCategory, "%v %v response: %v", method, url, strings.Join(body, ""))
+	}
+	return body, nil
+}
+
+// Split the output of "cf curl -i" into the HTTP status (e.g., "200 OK") and
+// the body lines.  If there is no status line the output is returned as is.
+func splitResponseHeaders(output []string) (string, []string) {
+	if len(output) == 0 || !strings.HasPrefix(output[0], "HTTP/") {
+		return "unknown", output
+	}
+	status := output[0]
+	if index := strings.Index(status, " "); index > 0 {
+		status = status[index+1:]
+	}
+	status = strings.TrimSpace(status)
+	for i := 1; i < len(output); i++ {
+		if strings.TrimSpace(output[i]) == "" {
+			return status, output[i+1:]
+		}
+	}
+	return status, []string{}
 }
 
 func GetStringValueByFieldName(n interface{}, field_name string) (string, bool) {
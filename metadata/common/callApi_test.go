@@ -0,0 +1,49 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("splitResponseHeaders", func() {
+	// The cf CLI returns its output split on "\n", so CRLF header lines
+	// keep the "\r"
+	table.DescribeTable("cf curl -i output",
+		func(output []string, expectedStatus string, expectedBody []string) {
+			status, body := splitResponseHeaders(output)
+			Expect(status).To(Equal(expectedStatus))
+			Expect(body).To(Equal(expectedBody))
+		},
+		table.Entry("CRLF headers",
+			[]string{"HTTP/1.1 200 OK\r", "Content-Type: application/json\r", "\r", "", "{", `  "total_results": 0`, "}"},
+			"200 OK", []string{"", "{", `  "total_results": 0`, "}"}),
+		table.Entry("LF headers",
+			[]string{"HTTP/1.1 404 Not Found", "Content-Type: application/json", "", `{"code": 10000}`},
+			"404 Not Found", []string{`{"code": 10000}`}),
+		table.Entry("no headers",
+			[]string{`{"resources": []}`},
+			"unknown", []string{`{"resources": []}`}),
+		table.Entry("headers without a body",
+			[]string{"HTTP/1.1 204 No Content\r", "X-Vcap-Request-Id: abc\r"},
+			"204 No Content", []string{}),
+		table.Entry("no output",
+			[]string{},
+			"unknown", []string{}),
+	)
+})
@@ -0,0 +1,186 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default CC API v2 base paths used by the loaders
+const (
+	DefaultAppsPath   = "/v2/apps"
+	DefaultRoutesPath = "/v2/routes"
+	RouteMappingsPath = "/v2/route_mappings"
+)
+
+// Largest results-per-page accepted by the CC v2 API
+const MaxResultsPerPage = 100
+
+// Largest useful inline-relations-depth for app requests (space and org)
+const MaxInlineRelationsDepth = 2
+
+// Base paths and query parameters used by the app and route loaders.  Allows
+// foundations with unusual configurations (or a mock CC for testing) to
+// override the endpoints.  Zero values for query parameters leave them off
+// the request (CC defaults apply).
+type EndpointConfig struct {
+	AppsPath       string
+	RoutesPath     string
+	ResultsPerPage int
+	// When > 0 the app requests include inline-relations-depth so space
+	// (1) and org (2) names are returned with each app
+	InlineRelationsDepth int
+	// Additional raw query parameters, e.g., "inline-relations-depth=1"
+	ExtraQuery string
+	// Org (and space of the org) the app, space, route and crash event
+	// lists are limited to, see SetLoadScope.  Empty loads all.
+	ScopeOrgGuid   string
+	ScopeSpaceGuid string
+}
+
+func DefaultEndpointConfig() *EndpointConfig {
+	return &EndpointConfig{
+		AppsPath:   DefaultAppsPath,
+		RoutesPath: DefaultRoutesPath,
+	}
+}
+
+var endpointConfig = DefaultEndpointConfig()
+
+// Set the endpoint config used by the loaders.  Empty paths keep the defaults.
+func SetEndpointConfig(ec *EndpointConfig) {
+	c := *ec
+	if c.AppsPath == "" {
+		c.AppsPath = DefaultAppsPath
+	}
+	if c.RoutesPath == "" {
+		c.RoutesPath = DefaultRoutesPath
+	}
+	c.AppsPath = strings.TrimRight(c.AppsPath, "/")
+	c.RoutesPath = strings.TrimRight(c.RoutesPath, "/")
+	c.ExtraQuery = strings.TrimLeft(c.ExtraQuery, "?&")
+	endpointConfig = &c
+}
+
+func GetEndpointConfig() *EndpointConfig {
+	return endpointConfig
+}
+
+// Limit the metadata loads to the org / space scope selected at startup so
+// less is loaded on large foundations.  Set before the first load, empty
+// guids load all.
+func SetLoadScope(orgGuid, spaceGuid string) {
+	c := *endpointConfig
+	c.ScopeOrgGuid = orgGuid
+	c.ScopeSpaceGuid = spaceGuid
+	endpointConfig = &c
+}
+
+// Url of the (first page of the) app list
+func (c *EndpointConfig) AppsUrl() string {
+	return c.withInlineRelations(c.withScope(c.ListUrl(c.AppsPath), true))
+}
+
+// Url of a single app
+func (c *EndpointConfig) AppUrl(appId string) string {
+	return c.withInlineRelations(c.AppsPath + "/" + appId)
+}
+
+// Url of the (first page of the) route list
+func (c *EndpointConfig) RoutesUrl() string {
+	return c.withScope(c.ListUrl(c.RoutesPath), false)
+}
+
+// Url of the (first page of the) space list
+func (c *EndpointConfig) SpacesUrl() string {
+	return c.withScope("/v2/spaces", false)
+}
+
+// Add the load scope filter to a v2 list url.  Lists that can not be
+// filtered by space (spaceFilter false) are limited to the scope's org.
+func (c *EndpointConfig) withScope(url string, spaceFilter bool) string {
+	if spaceFilter && c.ScopeSpaceGuid != "" {
+		return addQuery(url, "q=space_guid:"+c.ScopeSpaceGuid)
+	}
+	if c.ScopeOrgGuid != "" {
+		return addQuery(url, "q=organization_guid:"+c.ScopeOrgGuid)
+	}
+	return url
+}
+
+// Add the load scope filter to a v2 events url
+func (c *EndpointConfig) EventsUrl(url string) string {
+	return c.withScope(url, true)
+}
+
+// Add the load scope filter to a v3 app or process list url
+func (c *EndpointConfig) V3ListUrl(url string) string {
+	if c.ScopeSpaceGuid != "" {
+		return addQuery(url, "space_guids="+c.ScopeSpaceGuid)
+	}
+	if c.ScopeOrgGuid != "" {
+		return addQuery(url, "organization_guids="+c.ScopeOrgGuid)
+	}
+	return url
+}
+
+// Url of the (first page of the) app to route mappings
+func (c *EndpointConfig) RouteMappingsUrl() string {
+	return c.ListUrl(RouteMappingsPath)
+}
+
+// Url of the (first page of the) apps bound to a route
+func (c *EndpointConfig) RouteAppsUrl(routeId string) string {
+	return c.ListUrl(fmt.Sprintf("%v/%v/apps", c.RoutesPath, routeId))
+}
+
+// Url of the (first page of the) service bindings of an app.  The service
+// instance and its plan are returned inline with each binding.
+func (c *EndpointConfig) AppServiceBindingsUrl(appId string) string {
+	return addQuery(c.ListUrl(fmt.Sprintf("%v/%v/service_bindings", c.AppsPath, appId)), "inline-relations-depth=2")
+}
+
+func (c *EndpointConfig) withInlineRelations(path string) string {
+	if c.InlineRelationsDepth <= 0 {
+		return path
+	}
+	return addQuery(path, fmt.Sprintf("inline-relations-depth=%v", c.InlineRelationsDepth))
+}
+
+// Add the configured query parameters to a list path.  Subsequent pages
+// use the next_url returned by CC which keeps these parameters.
+func (c *EndpointConfig) ListUrl(path string) string {
+	params := make([]string, 0, 2)
+	if c.ResultsPerPage > 0 {
+		params = append(params, fmt.Sprintf("results-per-page=%v", c.ResultsPerPage))
+	}
+	if c.ExtraQuery != "" {
+		params = append(params, c.ExtraQuery)
+	}
+	if len(params) == 0 {
+		return path
+	}
+	return addQuery(path, strings.Join(params, "&"))
+}
+
+func addQuery(path, query string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + query
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EndpointConfig", func() {
+	AfterEach(func() {
+		SetEndpointConfig(DefaultEndpointConfig())
+	})
+
+	table.DescribeTable("load scope filters",
+		func(orgGuid, spaceGuid string, url func(c *EndpointConfig) string, expected string) {
+			SetEndpointConfig(&EndpointConfig{ResultsPerPage: 100})
+			SetLoadScope(orgGuid, spaceGuid)
+			Expect(url(GetEndpointConfig())).To(Equal(expected))
+		},
+		table.Entry("apps, no scope", "", "", (*EndpointConfig).AppsUrl,
+			"/v2/apps?results-per-page=100"),
+		table.Entry("apps of an org", "org-1", "", (*EndpointConfig).AppsUrl,
+			"/v2/apps?results-per-page=100&q=organization_guid:org-1"),
+		table.Entry("apps of a space", "org-1", "space-1", (*EndpointConfig).AppsUrl,
+			"/v2/apps?results-per-page=100&q=space_guid:space-1"),
+		table.Entry("routes of a space are limited to the org", "org-1", "space-1", (*EndpointConfig).RoutesUrl,
+			"/v2/routes?results-per-page=100&q=organization_guid:org-1"),
+		table.Entry("spaces of the org", "org-1", "space-1", (*EndpointConfig).SpacesUrl,
+			"/v2/spaces?q=organization_guid:org-1"),
+		table.Entry("events of a space", "org-1", "space-1",
+			func(c *EndpointConfig) string { return c.EventsUrl("/v2/events?q=type:app.crash") },
+			"/v2/events?q=type:app.crash&q=space_guid:space-1"),
+		table.Entry("v3 apps of an org", "org-1", "",
+			func(c *EndpointConfig) string { return c.V3ListUrl("/v3/apps?per_page=5000") },
+			"/v3/apps?per_page=5000&organization_guids=org-1"),
+		table.Entry("v3 processes of a space", "org-1", "space-1",
+			func(c *EndpointConfig) string { return c.V3ListUrl("/v3/processes?types=web") },
+			"/v3/processes?types=web&space_guids=space-1"),
+	)
+
+	It("does not scope a single app", func() {
+		SetLoadScope("org-1", "space-1")
+		Expect(GetEndpointConfig().AppUrl("app-1")).To(Equal("/v2/apps/app-1"))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/mdGlobalManagerInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
@@ -84,7 +85,7 @@ func (mdMgr *MdCommonManager) FlushCache() {
 }
 
 func (mdMgr *MdCommonManager) RequestLoadCacheIfOld() {
-	if time.Now().Sub(mdMgr.fullLoadCacheTime) > time.Hour*24 {
+	if clock.Now().Sub(mdMgr.fullLoadCacheTime) > time.Hour*24 {
 		mdMgr.LoadCacheAysnc()
 	}
 }
@@ -113,16 +114,29 @@ func (mdMgr *MdCommonManager) LoadCache(cliConnection plugin.CliConnection) {
 		return
 	}
 
+	now := clock.Now()
 	metadataMap := make(map[string]IMetadata)
 	for _, metadata := range metadataArray {
 		//toplog.Info("From Map - %+v", metadata)
+		metadata.SetCacheTime(now)
 		metadataMap[metadata.GetGuid()] = metadata
 	}
 
 	mdMgr.mu.Lock()
 	defer mdMgr.mu.Unlock()
 	mdMgr.metadataMap = metadataMap
-	mdMgr.fullLoadCacheTime = time.Now()
+	mdMgr.fullLoadCacheTime = now
+}
+
+// Time of the last full load of the cache, nil if not loaded yet
+func (mdMgr *MdCommonManager) GetCacheTime() *time.Time {
+	mdMgr.mu.Lock()
+	defer mdMgr.mu.Unlock()
+	if mdMgr.fullLoadCacheTime.IsZero() {
+		return nil
+	}
+	cacheTime := mdMgr.fullLoadCacheTime
+	return &cacheTime
 }
 
 func (mdMgr *MdCommonManager) getMetadata(cliConnection plugin.CliConnection) ([]IMetadata, error) {
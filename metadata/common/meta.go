@@ -15,8 +15,31 @@
 
 package common
 
+import "time"
+
 type Meta struct {
 	Guid      string `json:"guid"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
+
+func (meta *Meta) CreatedTime() time.Time {
+	return ParseTimestamp(meta.CreatedAt)
+}
+
+func (meta *Meta) UpdatedTime() time.Time {
+	return ParseTimestamp(meta.UpdatedAt)
+}
+
+// ParseTimestamp parses a CC API timestamp (RFC3339, e.g.,
+// 2016-11-15T19:56:52Z).  Empty or malformed values are the zero time.
+func ParseTimestamp(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return timestamp
+}
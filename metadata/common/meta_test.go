@@ -0,0 +1,48 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseTimestamp", func() {
+	table.DescribeTable("CC API timestamp formats",
+		func(value string, expected time.Time) {
+			Expect(ParseTimestamp(value).Equal(expected)).To(BeTrue())
+		},
+		table.Entry("v2 UTC", "2016-11-15T19:56:52Z",
+			time.Date(2016, 11, 15, 19, 56, 52, 0, time.UTC)),
+		table.Entry("v3 fractional seconds", "2016-11-15T19:56:52.123Z",
+			time.Date(2016, 11, 15, 19, 56, 52, 123000000, time.UTC)),
+		table.Entry("zone offset", "2016-11-15T13:56:52-06:00",
+			time.Date(2016, 11, 15, 19, 56, 52, 0, time.UTC)),
+		table.Entry("empty", "", time.Time{}),
+		table.Entry("date only", "2016-11-15", time.Time{}),
+		table.Entry("no zone", "2016-11-15T19:56:52", time.Time{}),
+		table.Entry("not a timestamp", "yesterday", time.Time{}),
+	)
+
+	It("is used for the created and updated times", func() {
+		meta := &Meta{CreatedAt: "2016-11-15T19:56:52Z", UpdatedAt: ""}
+		Expect(meta.CreatedTime()).To(Equal(time.Date(2016, 11, 15, 19, 56, 52, 0, time.UTC)))
+		Expect(meta.UpdatedTime().IsZero()).To(BeTrue())
+	})
+})
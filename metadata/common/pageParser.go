@@ -0,0 +1,104 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// PageParser extracts the raw resources and the url of the next page from
+// one page of a pageable CC API response.  The v2 and v3 APIs use a
+// different envelope so each has its own parser.
+type PageParser func(outputBytes []byte) (resources []json.RawMessage, nextUrl string, err error)
+
+type handleResourceFunc func(rawResource json.RawMessage) error
+
+type v2PageResponse struct {
+	NextUrl   string            `json:"next_url"`
+	Resources []json.RawMessage `json:"resources"`
+}
+
+type V3Link struct {
+	Href string `json:"href"`
+}
+
+type V3Pagination struct {
+	TotalResults int     `json:"total_results"`
+	TotalPages   int     `json:"total_pages"`
+	Next         *V3Link `json:"next"`
+}
+
+type v3PageResponse struct {
+	Pagination V3Pagination      `json:"pagination"`
+	Resources  []json.RawMessage `json:"resources"`
+}
+
+// V2PageParser parses the v2 envelope which uses "next_url"
+func V2PageParser(outputBytes []byte) ([]json.RawMessage, string, error) {
+	var response v2PageResponse
+	err := json.Unmarshal(outputBytes, &response)
+	if err != nil {
+		return nil, "", err
+	}
+	return response.Resources, response.NextUrl, nil
+}
+
+// V3PageParser parses the v3 envelope which uses "pagination.next.href"
+func V3PageParser(outputBytes []byte) ([]json.RawMessage, string, error) {
+	var response v3PageResponse
+	err := json.Unmarshal(outputBytes, &response)
+	if err != nil {
+		return nil, "", err
+	}
+	nextUrl := ""
+	if response.Pagination.Next != nil {
+		nextUrl = V3RelativeUrl(response.Pagination.Next.Href)
+	}
+	return response.Resources, nextUrl, nil
+}
+
+// V3RelativeUrl converts the absolute href returned by the v3 API into
+// the path and query that "cf curl" expects
+func V3RelativeUrl(href string) string {
+	parsedUrl, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	return parsedUrl.RequestURI()
+}
+
+// CallPagableResourceAPI calls each page of url using parser to read the
+// envelope and handleResource for each resource found
+func CallPagableResourceAPI(cliConnection plugin.CliConnection, url string, parser PageParser, handleResource handleResourceFunc) error {
+	handleResponse := func(outputBytes []byte) (data interface{}, nextUrl string, err error) {
+		resources, nextUrl, err := parser(outputBytes)
+		if err != nil {
+			toplog.Warn("*** %v unmarshal parsing output: %v", url, string(outputBytes[:]))
+			return nil, "", err
+		}
+		for _, rawResource := range resources {
+			if err := handleResource(rawResource); err != nil {
+				return nil, "", err
+			}
+		}
+		return resources, nextUrl, nil
+	}
+	return CallPagableAPI(cliConnection, url, handleResponse)
+}
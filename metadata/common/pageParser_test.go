@@ -0,0 +1,231 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry/cli/plugin/pluginfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const v2FirstPageFixture = `{
+  "total_results": 3,
+  "total_pages": 2,
+  "prev_url": null,
+  "next_url": "/v2/apps?order-direction=asc&page=2&results-per-page=2",
+  "resources": [
+    {"metadata": {"guid": "app-1"}, "entity": {"name": "web"}},
+    {"metadata": {"guid": "app-2"}, "entity": {"name": "api"}}
+  ]
+}`
+
+const v2LastPageFixture = `{
+  "total_results": 3,
+  "total_pages": 2,
+  "prev_url": "/v2/apps?order-direction=asc&page=1&results-per-page=2",
+  "next_url": null,
+  "resources": [
+    {"metadata": {"guid": "app-3"}, "entity": {"name": "worker"}}
+  ]
+}`
+
+const v2EmptyPageFixture = `{
+  "total_results": 0,
+  "total_pages": 1,
+  "prev_url": null,
+  "next_url": null,
+  "resources": []
+}`
+
+const v3FirstPageFixture = `{
+  "pagination": {
+    "total_results": 3,
+    "total_pages": 2,
+    "first": {"href": "https://api.example.com/v3/apps?page=1&per_page=2"},
+    "last": {"href": "https://api.example.com/v3/apps?page=2&per_page=2"},
+    "next": {"href": "https://api.example.com/v3/apps?page=2&per_page=2"},
+    "previous": null
+  },
+  "resources": [
+    {"guid": "app-1", "name": "web"},
+    {"guid": "app-2", "name": "api"}
+  ]
+}`
+
+const v3LastPageFixture = `{
+  "pagination": {
+    "total_results": 3,
+    "total_pages": 2,
+    "first": {"href": "https://api.example.com/v3/apps?page=1&per_page=2"},
+    "last": {"href": "https://api.example.com/v3/apps?page=2&per_page=2"},
+    "next": null,
+    "previous": {"href": "https://api.example.com/v3/apps?page=1&per_page=2"}
+  },
+  "resources": [
+    {"guid": "app-3", "name": "worker"}
+  ]
+}`
+
+const v3EmptyPageFixture = `{
+  "pagination": {
+    "total_results": 0,
+    "total_pages": 1,
+    "first": {"href": "https://api.example.com/v3/apps?page=1&per_page=2"},
+    "last": {"href": "https://api.example.com/v3/apps?page=1&per_page=2"},
+    "next": null,
+    "previous": null
+  },
+  "resources": []
+}`
+
+type fixtureResource struct {
+	Guid     string `json:"guid"`
+	Metadata struct {
+		Guid string `json:"guid"`
+	} `json:"metadata"`
+}
+
+func resourceGuids(resources []json.RawMessage) []string {
+	guids := make([]string, 0, len(resources))
+	for _, rawResource := range resources {
+		var resource fixtureResource
+		Expect(json.Unmarshal(rawResource, &resource)).To(Succeed())
+		guid := resource.Guid
+		if guid == "" {
+			guid = resource.Metadata.Guid
+		}
+		guids = append(guids, guid)
+	}
+	return guids
+}
+
+var _ = Describe("Page parsers", func() {
+
+	Describe("V2PageParser", func() {
+		It("returns the resources and next_url", func() {
+			resources, nextUrl, err := V2PageParser([]byte(v2FirstPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceGuids(resources)).To(Equal([]string{"app-1", "app-2"}))
+			Expect(nextUrl).To(Equal("/v2/apps?order-direction=asc&page=2&results-per-page=2"))
+		})
+
+		It("has no next url on the last page", func() {
+			resources, nextUrl, err := V2PageParser([]byte(v2LastPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceGuids(resources)).To(Equal([]string{"app-3"}))
+			Expect(nextUrl).To(Equal(""))
+		})
+
+		It("ignores the v3 pagination", func() {
+			_, nextUrl, err := V2PageParser([]byte(v3FirstPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextUrl).To(Equal(""))
+		})
+
+		It("returns no resources for an empty page", func() {
+			resources, nextUrl, err := V2PageParser([]byte(v2EmptyPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+			Expect(nextUrl).To(Equal(""))
+		})
+
+		It("fails on output that is not JSON", func() {
+			_, _, err := V2PageParser([]byte("FAILED"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("V3PageParser", func() {
+		It("returns the resources and the relative pagination.next url", func() {
+			resources, nextUrl, err := V3PageParser([]byte(v3FirstPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceGuids(resources)).To(Equal([]string{"app-1", "app-2"}))
+			Expect(nextUrl).To(Equal("/v3/apps?page=2&per_page=2"))
+		})
+
+		It("has no next url on the last page", func() {
+			resources, nextUrl, err := V3PageParser([]byte(v3LastPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resourceGuids(resources)).To(Equal([]string{"app-3"}))
+			Expect(nextUrl).To(Equal(""))
+		})
+
+		It("ignores the v2 next_url", func() {
+			_, nextUrl, err := V3PageParser([]byte(v2FirstPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nextUrl).To(Equal(""))
+		})
+
+		It("returns no resources for an empty page", func() {
+			resources, nextUrl, err := V3PageParser([]byte(v3EmptyPageFixture))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+			Expect(nextUrl).To(Equal(""))
+		})
+	})
+
+	Describe("CallPagableResourceAPI", func() {
+		var cliConnection *pluginfakes.FakeCliConnection
+
+		// Fixture pages by the url they are returned for
+		servePages := func(pages map[string]string) {
+			cliConnection.CliCommandWithoutTerminalOutputStub = func(args ...string) ([]string, error) {
+				return []string{pages[args[1]]}, nil
+			}
+		}
+
+		collectGuids := func(url string, parser PageParser) []string {
+			guids := make([]string, 0)
+			err := CallPagableResourceAPI(cliConnection, url, parser, func(rawResource json.RawMessage) error {
+				guids = append(guids, resourceGuids([]json.RawMessage{rawResource})...)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return guids
+		}
+
+		BeforeEach(func() {
+			cliConnection = new(pluginfakes.FakeCliConnection)
+		})
+
+		It("follows the v2 next_url", func() {
+			servePages(map[string]string{
+				"/v2/apps": v2FirstPageFixture,
+				"/v2/apps?order-direction=asc&page=2&results-per-page=2": v2LastPageFixture,
+			})
+			Expect(collectGuids("/v2/apps", V2PageParser)).To(Equal([]string{"app-1", "app-2", "app-3"}))
+			Expect(cliConnection.CliCommandWithoutTerminalOutputCallCount()).To(Equal(2))
+		})
+
+		It("follows the v3 pagination.next", func() {
+			servePages(map[string]string{
+				"/v3/apps":                   v3FirstPageFixture,
+				"/v3/apps?page=2&per_page=2": v3LastPageFixture,
+			})
+			Expect(collectGuids("/v3/apps", V3PageParser)).To(Equal([]string{"app-1", "app-2", "app-3"}))
+			Expect(cliConnection.CliCommandWithoutTerminalOutputCallCount()).To(Equal(2))
+		})
+
+		It("calls the API once for an empty result", func() {
+			servePages(map[string]string{"/v3/apps": v3EmptyPageFixture})
+			Expect(collectGuids("/v3/apps", V3PageParser)).To(BeEmpty())
+			Expect(cliConnection.CliCommandWithoutTerminalOutputCallCount()).To(Equal(1))
+		})
+	})
+
+})
@@ -0,0 +1,217 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crashData
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+type AppEventResponse struct {
+	Count     int                `json:"total_results"`
+	Pages     int                `json:"total_pages"`
+	NextUrl   string             `json:"next_url"`
+	Resources []AppEventResource `json:"resources"`
+}
+
+type AppEventResource struct {
+	Meta   common.Meta    `json:"metadata"`
+	Entity AppEventEntity `json:"entity"`
+}
+
+// Same fields as EventData except the metadata which differs by event type
+type AppEventEntity struct {
+	Type           string          `json:"type"`
+	Actor          string          `json:"actor"`
+	Actor_type     string          `json:"actor_type"`
+	Actor_name     string          `json:"actor_name"`
+	Actor_username string          `json:"actor_username"`
+	Actee          string          `json:"actee"`
+	Timestamp      string          `json:"timestamp"`
+	Metadata       json.RawMessage `json:"metadata"`
+}
+
+// An event recorded by the cloud controller for an app: staging, updates,
+// scaling, restarts, crashes, etc.
+type AppEvent struct {
+	Guid      string
+	Type      string
+	Timestamp time.Time
+	// User name (or actor name) that caused the event
+	Actor string
+	// Summary of the event metadata, e.g., "instances=3 memory=1024"
+	Detail string
+}
+
+type appEventsCacheEntry struct {
+	events   []*AppEvent
+	loadTime *time.Time
+	// Error of the last load, kept with its loadTime so the load is not
+	// retried before config.AppEventsCacheSeconds
+	loadErr error
+}
+
+var (
+	appEventsMu sync.Mutex
+	// Key: appId.  An entry with a nil loadTime is being loaded.
+	appEventsCache = make(map[string]*appEventsCacheEntry)
+)
+
+// FindAppEvents returns the recent events of an app, oldest first.  If they
+// are not cached, or the cache is older than config.AppEventsCacheSeconds,
+// they are loaded in the background.  The second return value is false
+// until the first load completes.  A failed load is not retried until
+// config.AppEventsCacheSeconds have passed, its error is returned until then.
+func FindAppEvents(cliConnection plugin.CliConnection, appId string) ([]*AppEvent, bool, error) {
+	appEventsMu.Lock()
+	defer appEventsMu.Unlock()
+	entry := appEventsCache[appId]
+	if entry == nil {
+		entry = &appEventsCacheEntry{}
+		appEventsCache[appId] = entry
+		go loadAppEvents(cliConnection, appId)
+	} else if entry.loadTime != nil && clock.Since(*entry.loadTime) > config.AppEventsCacheSeconds*time.Second {
+		// Keep showing the old events while the reload is in progress
+		entry.loadTime = nil
+		go loadAppEvents(cliConnection, appId)
+	}
+	return entry.events, entry.events != nil || entry.loadErr != nil, entry.loadErr
+}
+
+// Time the events of the app were last loaded, nil if not loaded yet
+func GetAppEventsCacheTime(appId string) *time.Time {
+	appEventsMu.Lock()
+	defer appEventsMu.Unlock()
+	if entry := appEventsCache[appId]; entry != nil {
+		return entry.loadTime
+	}
+	return nil
+}
+
+// FlushAppEventsCache removes all cached app events so they are reloaded
+// the next time they are requested
+func FlushAppEventsCache() {
+	appEventsMu.Lock()
+	defer appEventsMu.Unlock()
+	appEventsCache = make(map[string]*appEventsCacheEntry)
+}
+
+func loadAppEvents(cliConnection plugin.CliConnection, appId string) {
+	events, err := getAppEvents(cliConnection, appId)
+	appEventsMu.Lock()
+	defer appEventsMu.Unlock()
+	entry := appEventsCache[appId]
+	if entry == nil {
+		// Cache was flushed while loading
+		return
+	}
+	now := clock.Now()
+	entry.loadTime = &now
+	entry.loadErr = err
+	if err != nil {
+		toplog.Warn("*** app events metadata error (retry in %v seconds): %v", config.AppEventsCacheSeconds, err.Error())
+		return
+	}
+	entry.events = events
+}
+
+// Only the first page is requested -- with a descending order it holds the
+// config.AppEventsMaxCount most recent events.  They are returned in
+// chronological order.
+func getAppEvents(cliConnection plugin.CliConnection, appId string) ([]*AppEvent, error) {
+	url := fmt.Sprintf("/v2/events?q=actee:%v&order-direction=desc&results-per-page=%v", appId, config.AppEventsMaxCount)
+	toplog.Debug("getAppEvents url: %v", url)
+
+	output, err := common.CallAPI(cliConnection, url)
+	if err != nil {
+		return nil, err
+	}
+	var response AppEventResponse
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		toplog.Warn("*** %v unmarshal parsing output: %v", url, output)
+		return nil, err
+	}
+
+	layout := "2006-01-02T15:04:05Z"
+	events := make([]*AppEvent, 0, len(response.Resources))
+	for _, item := range response.Resources {
+		entity := item.Entity
+		timestamp, err := time.Parse(layout, entity.Timestamp)
+		if err != nil {
+			toplog.Debug("app event %v timestamp parse error: %v", item.Meta.Guid, err)
+		}
+		actor := entity.Actor_username
+		if actor == "" {
+			actor = entity.Actor_name
+		}
+		events = append(events, &AppEvent{
+			Guid:      item.Meta.Guid,
+			Type:      entity.Type,
+			Timestamp: timestamp,
+			Actor:     actor,
+			Detail:    summarizeEventMetadata(entity.Metadata),
+		})
+	}
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	toplog.Debug("Total app events loaded for %v: %v", appId, len(events))
+	return events, nil
+}
+
+// summarizeEventMetadata formats the event metadata as sorted key=value
+// pairs.  For update events only the request fields are shown.
+func summarizeEventMetadata(rawMetadata json.RawMessage) string {
+	if len(rawMetadata) == 0 {
+		return ""
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(rawMetadata, &metadata); err != nil {
+		return string(rawMetadata)
+	}
+	if request, ok := metadata["request"].(map[string]interface{}); ok {
+		metadata = request
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := metadata[key]
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			jsonValue, _ := json.Marshal(value)
+			pairs = append(pairs, fmt.Sprintf("%v=%v", key, string(jsonValue)))
+		case nil:
+			// Skip empty fields
+		default:
+			pairs = append(pairs, fmt.Sprintf("%v=%v", key, value))
+		}
+	}
+	return strings.Join(pairs, " ")
+}
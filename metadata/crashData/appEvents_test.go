@@ -0,0 +1,105 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crashData
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/cli/plugin/pluginfakes"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// First page of /v2/events in descending order
+const appEventsFixture = `{
+  "total_results": 3,
+  "total_pages": 1,
+  "next_url": null,
+  "resources": [
+    {
+      "metadata": {"guid": "event-3"},
+      "entity": {"type": "app.crash", "actor": "app-1", "actor_name": "web",
+        "timestamp": "2017-06-01T12:10:00Z", "metadata": {"exit_status": 255, "index": 0}}
+    },
+    {
+      "metadata": {"guid": "event-2"},
+      "entity": {"type": "audit.app.update", "actor": "user-1", "actor_name": "admin", "actor_username": "admin@example.com",
+        "timestamp": "2017-06-01T12:05:00Z", "metadata": {"request": {"instances": 3}}}
+    },
+    {
+      "metadata": {"guid": "event-1"},
+      "entity": {"type": "audit.app.create", "actor": "user-1", "actor_name": "admin",
+        "timestamp": "2017-06-01T12:00:00Z", "metadata": null}
+    }
+  ]
+}`
+
+var _ = Describe("App events", func() {
+
+	table.DescribeTable("summarizeEventMetadata",
+		func(metadata string, expected string) {
+			Expect(summarizeEventMetadata(json.RawMessage(metadata))).To(Equal(expected))
+		},
+		table.Entry("no metadata", "", ""),
+		table.Entry("null metadata", "null", ""),
+		table.Entry("sorted key=value pairs", `{"reason": "CRASHED", "index": 1, "exit_status": 255}`,
+			"exit_status=255 index=1 reason=CRASHED"),
+		table.Entry("only the request of update events", `{"request": {"memory": 1024, "instances": 3}, "name": "web"}`,
+			"instances=3 memory=1024"),
+		table.Entry("nested values as JSON", `{"environment_json": {"A": "1"}, "ports": [8080]}`,
+			`environment_json={"A":"1"} ports=[8080]`),
+		table.Entry("empty fields skipped", `{"buildpack": null, "state": "STARTED"}`,
+			"state=STARTED"),
+		table.Entry("not a JSON object", `"PRIVATE DATA HIDDEN"`, `"PRIVATE DATA HIDDEN"`),
+	)
+
+	Describe("getAppEvents", func() {
+		var cliConnection *pluginfakes.FakeCliConnection
+
+		BeforeEach(func() {
+			cliConnection = new(pluginfakes.FakeCliConnection)
+			cliConnection.CliCommandWithoutTerminalOutputReturns([]string{appEventsFixture}, nil)
+		})
+
+		It("requests the most recent events", func() {
+			_, err := getAppEvents(cliConnection, "app-1")
+			Expect(err).NotTo(HaveOccurred())
+			args := cliConnection.CliCommandWithoutTerminalOutputArgsForCall(0)
+			Expect(args[1]).To(Equal("/v2/events?q=actee:app-1&order-direction=desc&results-per-page=100"))
+		})
+
+		It("returns the events in chronological order", func() {
+			events, err := getAppEvents(cliConnection, "app-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Guid).To(Equal("event-1"))
+			Expect(events[1].Guid).To(Equal("event-2"))
+			Expect(events[2].Guid).To(Equal("event-3"))
+			Expect(events[0].Timestamp).To(Equal(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)))
+		})
+
+		It("prefers the actor user name", func() {
+			events, err := getAppEvents(cliConnection, "app-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events[0].Actor).To(Equal("admin"))
+			Expect(events[1].Actor).To(Equal("admin@example.com"))
+			Expect(events[1].Detail).To(Equal("instances=3"))
+		})
+	})
+
+})
@@ -17,6 +17,7 @@ package crashData
 
 import (
 	"regexp"
+	"sort"
 	"time"
 )
 
@@ -31,13 +32,26 @@ type ContainerCrashInfo struct {
 	ContainerIndex  int
 	CrashTime       *time.Time
 	ExitDescription string
+	// Exit status parsed from the exit description (empty if not reported)
+	ExitStatus string
+
+	// Optional detail -- not all crash sources report these
+	ExitReason   string
+	InstanceGuid string
+	CellId       string
+	CrashCount   int
+	// IP of the cell the container was last seen on (live captured crashes only)
+	CellIp string
 }
 
 func NewContainerCrashInfo(containerIndex int, crashTime *time.Time, exitDescription string) *ContainerCrashInfo {
 	exitDescriptionClean := CleanupExitDescription(exitDescription)
-	//exitStatus := ExtractExitStatusFromExitDescription(exitDescription)
+	exitStatus := ""
+	if regexExtractExitStatus.MatchString(exitDescription) {
+		exitStatus = ExtractExitStatusFromExitDescription(exitDescription)
+	}
 	info := &ContainerCrashInfo{ContainerIndex: containerIndex, CrashTime: crashTime,
-		ExitDescription: exitDescriptionClean}
+		ExitDescription: exitDescriptionClean, ExitStatus: exitStatus}
 	return info
 }
 
@@ -55,6 +69,73 @@ func (p ContainerCrashInfoSlice) Swap(i, j int) {
 	p[i], p[j] = p[j], p[i]
 }
 
+// Crash records from the CC events API have second granularity while live
+// captured crashes have nanosecond, records within this window are the same crash
+const duplicateCrashWindow = 2 * time.Second
+
+// MergeCrashInfo combines crash records from two sources (e.g., CC event
+// history and live captured) dropping duplicates of the same crash.  When
+// a duplicate is found the primary record is kept and any detail it is
+// missing is copied from the secondary record.  Result is sorted by crash time.
+func MergeCrashInfo(primary, secondary []*ContainerCrashInfo) []*ContainerCrashInfo {
+	merged := make([]*ContainerCrashInfo, 0, len(primary)+len(secondary))
+	for _, crashInfo := range primary {
+		if crashInfo != nil {
+			merged = append(merged, crashInfo)
+		}
+	}
+	primaryCount := len(merged)
+	for _, crashInfo := range secondary {
+		if crashInfo == nil {
+			continue
+		}
+		if duplicate := findDuplicateCrash(merged[:primaryCount], crashInfo); duplicate != nil {
+			duplicate.fillMissing(crashInfo)
+			continue
+		}
+		merged = append(merged, crashInfo)
+	}
+	sort.Sort(ContainerCrashInfoSlice(merged))
+	return merged
+}
+
+func findDuplicateCrash(crashInfoList []*ContainerCrashInfo, crashInfo *ContainerCrashInfo) *ContainerCrashInfo {
+	for _, candidate := range crashInfoList {
+		if candidate.ContainerIndex != crashInfo.ContainerIndex {
+			continue
+		}
+		delta := candidate.CrashTime.Sub(*crashInfo.CrashTime)
+		if delta < duplicateCrashWindow && delta > -duplicateCrashWindow {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func (info *ContainerCrashInfo) fillMissing(other *ContainerCrashInfo) {
+	if info.ExitDescription == "" {
+		info.ExitDescription = other.ExitDescription
+	}
+	if info.ExitStatus == "" {
+		info.ExitStatus = other.ExitStatus
+	}
+	if info.ExitReason == "" {
+		info.ExitReason = other.ExitReason
+	}
+	if info.InstanceGuid == "" {
+		info.InstanceGuid = other.InstanceGuid
+	}
+	if info.CellId == "" {
+		info.CellId = other.CellId
+	}
+	if info.CrashCount == 0 {
+		info.CrashCount = other.CrashCount
+	}
+	if info.CellIp == "" {
+		info.CellIp = other.CellIp
+	}
+}
+
 func ExtractExitStatusFromExitDescription(exitDescription string) string {
 	parsedData := regexExtractExitStatus.FindAllStringSubmatch(exitDescription, -1)
 	if parsedData != nil && len(parsedData) > 0 {
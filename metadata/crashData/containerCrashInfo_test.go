@@ -0,0 +1,73 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crashData_test
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MergeCrashInfo", func() {
+	crashAt := func(containerIndex int, offset time.Duration) *crashData.ContainerCrashInfo {
+		crashTime := crashLoopStart.Add(offset)
+		return &crashData.ContainerCrashInfo{ContainerIndex: containerIndex, CrashTime: &crashTime}
+	}
+
+	It("keeps the primary record of a crash reported by both sources and fills in its missing detail", func() {
+		primary := crashAt(0, 10*time.Second)
+		primary.ExitDescription = "APP/PROC/WEB: Exited with status 137"
+		primary.CrashCount = 3
+		secondary := crashAt(0, 10*time.Second+500*time.Millisecond)
+		secondary.ExitDescription = "out of memory"
+		secondary.CellIp = "10.0.0.7"
+		secondary.InstanceGuid = "instance-guid"
+
+		merged := crashData.MergeCrashInfo([]*crashData.ContainerCrashInfo{primary}, []*crashData.ContainerCrashInfo{secondary})
+		Expect(merged).To(HaveLen(1))
+		Expect(merged[0]).To(BeIdenticalTo(primary))
+		Expect(merged[0].ExitDescription).To(Equal("APP/PROC/WEB: Exited with status 137"))
+		Expect(merged[0].CrashCount).To(Equal(3))
+		Expect(merged[0].CellIp).To(Equal("10.0.0.7"))
+		Expect(merged[0].InstanceGuid).To(Equal("instance-guid"))
+	})
+
+	It("keeps crashes of different instances at the same time", func() {
+		merged := crashData.MergeCrashInfo(
+			[]*crashData.ContainerCrashInfo{crashAt(0, 10*time.Second)},
+			[]*crashData.ContainerCrashInfo{crashAt(1, 10*time.Second)})
+		Expect(merged).To(HaveLen(2))
+	})
+
+	It("keeps crashes of the same instance outside the duplicate window", func() {
+		merged := crashData.MergeCrashInfo(
+			[]*crashData.ContainerCrashInfo{crashAt(0, 10*time.Second)},
+			[]*crashData.ContainerCrashInfo{crashAt(0, 12*time.Second)})
+		Expect(merged).To(HaveLen(2))
+	})
+
+	It("skips nil records and sorts the result by crash time", func() {
+		first := crashAt(0, 1*time.Minute)
+		second := crashAt(1, 2*time.Minute)
+		third := crashAt(0, 3*time.Minute)
+		merged := crashData.MergeCrashInfo(
+			[]*crashData.ContainerCrashInfo{third, nil, first},
+			[]*crashData.ContainerCrashInfo{nil, second})
+		Expect(merged).To(Equal([]*crashData.ContainerCrashInfo{first, second, third}))
+	})
+})
@@ -21,9 +21,11 @@ import (
 	"net/url"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 )
@@ -31,8 +33,9 @@ import (
 var (
 	LoadEventsUntilTime *time.Time
 
-	// Time data was last loaded
-	cacheTime *time.Time
+	// Time data was last loaded, read by the UI while loaders set it
+	cacheTimeMu sync.Mutex
+	cacheTime   *time.Time
 
 	crashDataMetadataCache []EventData
 	// Map: [AppGuid] = array of crash timestamps
@@ -40,11 +43,20 @@ var (
 )
 
 func GetCacheTime() *time.Time {
+	cacheTimeMu.Lock()
+	defer cacheTimeMu.Unlock()
 	return cacheTime
 }
 
+func setCacheTime() {
+	now := clock.Now()
+	cacheTimeMu.Lock()
+	cacheTime = &now
+	cacheTimeMu.Unlock()
+}
+
 func IsCacheLoaded() bool {
-	return cacheTime != nil
+	return GetCacheTime() != nil
 }
 
 func All() []EventData {
@@ -66,6 +78,15 @@ func FindByApp(appGuid string) []*ContainerCrashInfo {
 	return crashInfo
 }
 
+// Guids of all apps that have crash history loaded
+func AppIds() []string {
+	appIds := make([]string, 0, len(crashDataByAppId))
+	for appId := range crashDataByAppId {
+		appIds = append(appIds, appId)
+	}
+	return appIds
+}
+
 func FindSinceByApp(appGuid string, since time.Duration) []*ContainerCrashInfo {
 	crashTimestamps := FindByApp(appGuid)
 	return filterSince(crashTimestamps, since)
@@ -90,7 +111,7 @@ func FindLastCrashByApp(appGuid string) *ContainerCrashInfo {
 func filterSince(crashInfoList []*ContainerCrashInfo, since time.Duration) []*ContainerCrashInfo {
 
 	if crashInfoList != nil {
-		sinceTime := time.Now().Add(since)
+		sinceTime := clock.Now().Add(since)
 		crashInfoListSize := len(crashInfoList)
 		crashInfoListSince := make([]*ContainerCrashInfo, 0, crashInfoListSize)
 		for i, _ := range crashInfoList {
@@ -131,10 +152,12 @@ func LoadCrashDataCache(cliConnection plugin.CliConnection) {
 		instanceIndex := crashData.Metadata.Index
 		exitDescription := crashData.Metadata.Exit_description
 		crashInfo := NewContainerCrashInfo(instanceIndex, &crashTimestamp, exitDescription)
+		crashInfo.ExitReason = crashData.Metadata.Reason
+		crashInfo.InstanceGuid = crashData.Metadata.Instance
+		crashInfo.CellId = crashData.Metadata.Cell_id
 		crashDataByAppId[crashData.Actor] = append(crashDataByAppId[crashData.Actor], crashInfo)
 	}
-	now := time.Now()
-	cacheTime = &now
+	setCacheTime()
 }
 
 func getCrashDataMetadata(cliConnection plugin.CliConnection) ([]EventData, error) {
@@ -147,10 +170,10 @@ func getCrashDataMetadata(cliConnection plugin.CliConnection) ([]EventData, erro
 	eventsUtilTimeStr := eventsUtilTime.Format(timestampFormat)
 	eventsUtilTimeStrEncoded := url.PathEscape(eventsUtilTimeStr)
 
-	oneDayAgo := time.Now().Add(-24 * time.Hour)
+	oneDayAgo := clock.Now().Add(-24 * time.Hour)
 	oneDayAgoStr := oneDayAgo.Format(timestampFormat)
 	oneDayAgoStrEncoded := url.PathEscape(oneDayAgoStr)
-	urlPath = fmt.Sprintf(urlPath, oneDayAgoStrEncoded, eventsUtilTimeStrEncoded)
+	urlPath = common.GetEndpointConfig().EventsUrl(fmt.Sprintf(urlPath, oneDayAgoStrEncoded, eventsUtilTimeStrEncoded))
 
 	metadata := []EventData{}
 
@@ -0,0 +1,75 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crashData
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+)
+
+// IsCrashLooping reports if any instance in the crash list is crash looping
+// as of now (see config.CrashLoopMinCrashes).  Crashes are grouped by
+// container index and the time between consecutive crashes of an instance
+// is used as its uptime.  Diego delays restarts with an exponential backoff
+// so after the first short interval each interval may be up to twice the
+// prior one.  The loop has ended when the instance has not crashed again
+// within the next allowed interval.
+func IsCrashLooping(crashInfoList []*ContainerCrashInfo, now time.Time) bool {
+	byContainer := make(map[int][]*ContainerCrashInfo)
+	for _, crashInfo := range crashInfoList {
+		if crashInfo != nil && crashInfo.CrashTime != nil {
+			byContainer[crashInfo.ContainerIndex] = append(byContainer[crashInfo.ContainerIndex], crashInfo)
+		}
+	}
+	for _, containerCrashes := range byContainer {
+		if len(containerCrashes) >= config.CrashLoopMinCrashes && isContainerCrashLooping(containerCrashes, now) {
+			return true
+		}
+	}
+	return false
+}
+
+func isContainerCrashLooping(crashInfoList []*ContainerCrashInfo, now time.Time) bool {
+	sort.Sort(ContainerCrashInfoSlice(crashInfoList))
+	maxInterval := time.Duration(config.CrashLoopMaxIntervalSeconds) * time.Second
+	allowedInterval := func(priorInterval time.Duration) time.Duration {
+		if 2*priorInterval > maxInterval {
+			return 2 * priorInterval
+		}
+		return maxInterval
+	}
+
+	// Length of the run of short intervals ending at the latest crash
+	runCount := 1
+	priorInterval := time.Duration(0)
+	for i := 1; i < len(crashInfoList); i++ {
+		interval := crashInfoList[i].CrashTime.Sub(*crashInfoList[i-1].CrashTime)
+		if interval <= allowedInterval(priorInterval) {
+			runCount++
+			priorInterval = interval
+		} else {
+			runCount = 1
+			priorInterval = 0
+		}
+	}
+	if runCount < config.CrashLoopMinCrashes {
+		return false
+	}
+	lastCrashTime := *crashInfoList[len(crashInfoList)-1].CrashTime
+	return now.Sub(lastCrashTime) <= allowedInterval(priorInterval)
+}
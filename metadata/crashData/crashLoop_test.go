@@ -0,0 +1,74 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crashData_test
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var crashLoopStart = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// Crashes of container 0 after the given seconds from crashLoopStart
+func crashesAt(seconds ...int) []*crashData.ContainerCrashInfo {
+	crashes := make([]*crashData.ContainerCrashInfo, 0, len(seconds))
+	for _, second := range seconds {
+		crashTime := crashLoopStart.Add(time.Duration(second) * time.Second)
+		crashes = append(crashes, &crashData.ContainerCrashInfo{ContainerIndex: 0, CrashTime: &crashTime})
+	}
+	return crashes
+}
+
+var _ = Describe("IsCrashLooping", func() {
+	table.DescribeTable("crash history of one instance",
+		func(crashes []*crashData.ContainerCrashInfo, nowSeconds int, expected bool) {
+			now := crashLoopStart.Add(time.Duration(nowSeconds) * time.Second)
+			Expect(crashData.IsCrashLooping(crashes, now)).To(Equal(expected))
+		},
+		table.Entry("no crashes", nil, 0, false),
+		table.Entry("fewer than the minimum crashes", crashesAt(0, 30), 40, false),
+		table.Entry("minimum crashes in a row", crashesAt(0, 30, 60), 70, true),
+		table.Entry("input not sorted by crash time", crashesAt(60, 0, 30), 70, true),
+		table.Entry("first interval over the maximum", crashesAt(0, 121, 242), 250, false),
+		table.Entry("first interval at the maximum", crashesAt(0, 120, 240), 250, true),
+		table.Entry("intervals doubling with the restart back-off", crashesAt(0, 60, 180, 420), 430, true),
+		table.Entry("interval more than twice the prior one", crashesAt(0, 100, 200, 401), 410, false),
+		table.Entry("short intervals after a long gap", crashesAt(0, 1000, 1030, 1060), 1070, true),
+		table.Entry("loop still active at the end of the allowed interval", crashesAt(0, 30, 60), 180, true),
+		table.Entry("loop cleared after the allowed interval without a crash", crashesAt(0, 30, 60), 181, false),
+		table.Entry("loop cleared after a backed off interval", crashesAt(0, 60, 180, 420), 901, false),
+		table.Entry("loop still active within a backed off interval", crashesAt(0, 60, 180, 420), 900, true),
+	)
+
+	It("does not combine crashes of different instances", func() {
+		crashes := crashesAt(0, 30, 60)
+		for i, crash := range crashes {
+			crash.ContainerIndex = i
+		}
+		now := crashLoopStart.Add(70 * time.Second)
+		Expect(crashData.IsCrashLooping(crashes, now)).To(BeFalse())
+	})
+
+	It("ignores crashes without a crash time", func() {
+		crashes := append(crashesAt(0, 30), &crashData.ContainerCrashInfo{ContainerIndex: 0})
+		now := crashLoopStart.Add(40 * time.Second)
+		Expect(crashData.IsCrashLooping(crashes, now)).To(BeFalse())
+	})
+})
@@ -52,4 +52,5 @@ type EventDataMetadataField struct {
 	Index            int    `json:"index"`
 	Exit_description string `json:"exit_description"`
 	Reason           string `json:"reason"`
+	Cell_id          string `json:"cell_id"`
 }
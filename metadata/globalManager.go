@@ -19,6 +19,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/domain"
@@ -26,6 +28,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/orgQuota"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/route"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/serviceBinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/spaceQuota"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
@@ -34,12 +37,19 @@ import (
 	"code.cloudfoundry.org/cli/plugin"
 )
 
+// Time a metadata cache was last loaded, see GlobalManager.CacheTimes
+type CacheTime struct {
+	Name string
+	Time time.Time
+}
+
 type GlobalManager struct {
 	appMdMgr *app.AppMetadataManager
 	//orgMdMgr *OrgMetadataManager
 	//spaceMdMgr *SpaceMetadataManager
-	orgQuotaMdMgr   *orgQuota.OrgQuotaMetadataManager
-	spaceQuotaMdMgr *spaceQuota.SpaceQuotaMetadataManager
+	orgQuotaMdMgr     *orgQuota.OrgQuotaMetadataManager
+	spaceQuotaMdMgr   *spaceQuota.SpaceQuotaMetadataManager
+	routeMappingMdMgr *route.RouteMappingManager
 
 	mu sync.Mutex
 
@@ -49,7 +59,15 @@ type GlobalManager struct {
 	refreshQueue  map[string]string
 	cliConnection plugin.CliConnection
 
+	// Closed by Stop to end the metadata thread and skip further loads
+	stopped chan bool
+
 	loadMetadataInProgress bool
+
+	// False until route and domain metadata has been loaded (see
+	// config.IsDeferRouteMetadata).  Guarded by mu.
+	routeMetadataLoaded      bool
+	routeMetadataLoadStarted bool
 }
 
 func NewGlobalManager(conn plugin.CliConnection) *GlobalManager {
@@ -59,16 +77,18 @@ func NewGlobalManager(conn plugin.CliConnection) *GlobalManager {
 	mgr.appMdMgr = app.NewAppMetadataManager()
 	mgr.orgQuotaMdMgr = orgQuota.NewOrgQuotaMetadataManager(mgr)
 	mgr.spaceQuotaMdMgr = spaceQuota.NewSpaceQuotaMetadataManager(mgr)
+	mgr.routeMappingMdMgr = route.NewRouteMappingManager()
 
 	mgr.appDeleteQueue = make(map[string]string)
 
 	mgr.refreshQueue = make(map[string]string)
 	mgr.refreshNow = make(chan bool)
+	mgr.stopped = make(chan bool)
 	mgr.cliConnection = conn
 
 	// Set set the time of event data end date/time here so we don't end up loading
 	// events after we've already started counting them from the firehose.
-	now := time.Now()
+	now := clock.Now()
 	crashData.LoadEventsUntilTime = &now
 
 	go mgr.loadMetadataThread()
@@ -88,15 +108,30 @@ func (mgr *GlobalManager) GetSpaceQuotaMdManager() *spaceQuota.SpaceQuotaMetadat
 	return mgr.spaceQuotaMdMgr
 }
 
+func (mgr *GlobalManager) GetRouteMappingMdManager() *route.RouteMappingManager {
+	return mgr.routeMappingMdMgr
+}
+
 func (mgr *GlobalManager) GetCliConnection() plugin.CliConnection {
 	return mgr.cliConnection
 }
 
-// Load all the metadata.  This is a blocking call.
-func (mgr *GlobalManager) LoadMetadata() {
-	toplog.Info("GlobalManager>loadMetadata")
+// Load all the metadata.  This is a blocking call.  Returns false without
+// loading anything if another load is already in progress.
+func (mgr *GlobalManager) LoadMetadata() bool {
+	toplog.InfoC(toplog.MetadataCategory, "GlobalManager>loadMetadata")
 
+	if mgr.isStopped() {
+		return false
+	}
+	mgr.mu.Lock()
+	if mgr.loadMetadataInProgress {
+		mgr.mu.Unlock()
+		toplog.InfoC(toplog.MetadataCategory, "Metadata load already in progress, skipping")
+		return false
+	}
 	mgr.loadMetadataInProgress = true
+	mgr.mu.Unlock()
 
 	isolationSegment.LoadCache(mgr.cliConnection)
 	stack.LoadStackCache(mgr.cliConnection)
@@ -105,21 +140,117 @@ func (mgr *GlobalManager) LoadMetadata() {
 
 	//time.Sleep(time.Second * 60)
 
-	space.LoadSpaceCache(mgr.cliConnection)
-	org.LoadOrgCache(mgr.cliConnection)
+	// Spaces and orgs returned inline with the apps already filled the caches
+	inlineDepth := mgr.appMdMgr.InlineRelationsDepth()
+	if inlineDepth < 1 {
+		space.LoadSpaceCache(mgr.cliConnection)
+	}
+	if inlineDepth < 2 {
+		org.LoadOrgCache(mgr.cliConnection)
+	}
 
-	route.LoadRouteCache(mgr.cliConnection)
-	domain.LoadDomainCache(mgr.cliConnection)
+	if config.IsDeferRouteMetadata() && !mgr.IsRouteMetadataLoaded() {
+		toplog.InfoC(toplog.MetadataCategory, "Quiet start: route and domain metadata will be loaded when route view is opened")
+	} else {
+		mgr.loadRouteMetadata()
+	}
 	crashData.LoadCrashDataCache(mgr.cliConnection)
 
+	mgr.mu.Lock()
 	mgr.loadMetadataInProgress = false
+	mgr.mu.Unlock()
+	return true
+}
 
+// Stop the metadata thread and skip any further loads.  A CC API call
+// already in flight can not be cancelled, it is left to complete.
+func (mgr *GlobalManager) Stop() error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	select {
+	case <-mgr.stopped:
+	default:
+		close(mgr.stopped)
+	}
+	return nil
 }
 
-func (mgr *GlobalManager) FlushCache() {
-	mgr.LoadMetadata()
+func (mgr *GlobalManager) isStopped() bool {
+	select {
+	case <-mgr.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+func (mgr *GlobalManager) IsLoadMetadataInProgress() bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return mgr.loadMetadataInProgress
+}
+
+func (mgr *GlobalManager) loadRouteMetadata() {
+	route.LoadRouteCache(mgr.cliConnection)
+	mgr.routeMappingMdMgr.LoadRouteMappingCache(mgr.cliConnection)
+	domain.LoadDomainCache(mgr.cliConnection)
+	mgr.mu.Lock()
+	mgr.routeMetadataLoaded = true
+	mgr.mu.Unlock()
+}
+
+func (mgr *GlobalManager) IsRouteMetadataLoaded() bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return mgr.routeMetadataLoaded
+}
+
+// LoadRouteMetadataIfDeferred loads route and domain metadata if it was skipped
+// at startup.  Returns true if the caller should wait for the load (i.e., this
+// call performed the load).  This is a blocking call.
+func (mgr *GlobalManager) LoadRouteMetadataIfDeferred() bool {
+	mgr.mu.Lock()
+	if mgr.routeMetadataLoaded || mgr.routeMetadataLoadStarted {
+		mgr.mu.Unlock()
+		return false
+	}
+	mgr.routeMetadataLoadStarted = true
+	mgr.mu.Unlock()
+
+	toplog.InfoC(toplog.MetadataCategory, "Loading deferred route and domain metadata")
+	mgr.loadRouteMetadata()
+	return true
+}
+
+// Reload all metadata.  Returns false if a load was already in progress.
+func (mgr *GlobalManager) FlushCache() bool {
+	if !mgr.LoadMetadata() {
+		return false
+	}
 	mgr.orgQuotaMdMgr.FlushCache()
 	mgr.spaceQuotaMdMgr.FlushCache()
+	serviceBinding.FlushCache()
+	crashData.FlushAppEventsCache()
+	return true
+}
+
+// Load times of the metadata caches that have been loaded.  Quota caches
+// are only loaded when first needed so may not be included.
+func (mgr *GlobalManager) CacheTimes() []CacheTime {
+	cacheTimes := make([]CacheTime, 0)
+	add := func(name string, cacheTime *time.Time) {
+		if cacheTime != nil {
+			cacheTimes = append(cacheTimes, CacheTime{Name: name, Time: *cacheTime})
+		}
+	}
+	add("app", mgr.appMdMgr.GetCacheTime())
+	add("space", space.GetCacheTime())
+	add("org", org.GetCacheTime())
+	add("route", route.GetCacheTime())
+	add("crash", crashData.GetCacheTime())
+	add("org-quota", mgr.orgQuotaMdMgr.GetCacheTime())
+	add("space-quota", mgr.spaceQuotaMdMgr.GetCacheTime())
+	return cacheTimes
 }
 
 func (mgr *GlobalManager) IsAppDeleted(appId string) bool {
@@ -137,7 +268,10 @@ func (mgr *GlobalManager) RequestRefreshAppMetadata(appId string) {
 }
 
 func (mgr *GlobalManager) wakeRefreshThread() {
-	mgr.refreshNow <- true
+	select {
+	case mgr.refreshNow <- true:
+	case <-mgr.stopped:
+	}
 }
 
 func (mgr *GlobalManager) loadMetadataThread() {
@@ -148,48 +282,54 @@ func (mgr *GlobalManager) loadMetadataThread() {
 
 	for {
 
-		toplog.Debug("Metadata - sleep time: %v", minNextLoadTime)
+		toplog.DebugC(toplog.MetadataCategory, "Metadata - sleep time: %v", minNextLoadTime)
 
 		select {
 		case <-mgr.refreshNow:
 			//mui.updateDisplay(g)
 		case <-time.After(minNextLoadTime):
 			//mui.updateDisplay(g)
+		case <-mgr.stopped:
+			toplog.DebugC(toplog.MetadataCategory, "Metadata cache thread stopped")
+			return
 		}
 
 		minNextLoadTime = veryLongtime
-		toplog.Debug("Metadata cache thread is awake")
+		toplog.DebugC(toplog.MetadataCategory, "Metadata cache thread is awake")
 		for _, appId := range mgr.refreshQueue {
+			if mgr.isStopped() {
+				break
+			}
 			appMetadata := mgr.appMdMgr.FindAppMetadataInternal(appId, false)
-			timeSinceLastLoad := time.Now().Sub(appMetadata.CacheTime)
+			timeSinceLastLoad := clock.Now().Sub(appMetadata.CacheTime)
 			appName := appMetadata.Name
-			toplog.Debug("Metadata - appId: %v name: [%v] - inqueue check time since last load: %v", appId, appName, timeSinceLastLoad)
+			toplog.DebugC(toplog.MetadataCategory, "Metadata - appId: %v name: [%v] - inqueue check time since last load: %v", appId, appName, timeSinceLastLoad)
 			if timeSinceLastLoad > minimumLoadTimeMS {
-				toplog.Debug("Metadata - appId: %v name: [%v] - Needs to be loaded now", appId, appName)
+				toplog.DebugC(toplog.MetadataCategory, "Metadata - appId: %v name: [%v] - Needs to be loaded now", appId, appName)
 				newAppMetadata, err := mgr.appMdMgr.GetAppMetadataInternal(mgr.cliConnection, appId)
 				if err != nil {
-					toplog.Warn("Metadata - appId: %v name: [%v] - Error: %v", appId, appName, err)
+					toplog.WarnC(toplog.MetadataCategory, "Metadata - appId: %v name: [%v] - Error: %v", appId, appName, err)
 				} else {
-					toplog.Info("Metadata - appId: %v name: [%v] - Load start", appId, appName)
+					toplog.InfoC(toplog.MetadataCategory, "Metadata - appId: %v name: [%v] - Load start", appId, appName)
 					if newAppMetadata.Name != "" {
 						// Only save if it really loaded
-						mgr.appMdMgr.GetAppMetadataMap()[appId] = newAppMetadata
+						mgr.appMdMgr.SetAppMetadata(newAppMetadata)
 					} else {
 						// If we can't reload this appId the it must have been deleted
 						// Remove from metadata cache AND remove from appstats in "current" processor
-						delete(mgr.appMdMgr.GetAppMetadataMap(), appId)
+						mgr.appMdMgr.DeleteAppMetadata(appId)
 						mgr.appDeleteQueue[appId] = appId
-						toplog.Info("Metadata - appId: %v name: [%v] - Removed from cache as it doesn't seem to exist", appId, appName)
+						toplog.InfoC(toplog.MetadataCategory, "Metadata - appId: %v name: [%v] - Removed from cache as it doesn't seem to exist", appId, appName)
 					}
-					toplog.Info("Metadata - appId: %v name: [%v] - Load complete", appId, newAppMetadata.Name)
+					toplog.InfoC(toplog.MetadataCategory, "Metadata - appId: %v name: [%v] - Load complete", appId, newAppMetadata.Name)
 					delete(mgr.refreshQueue, appId)
 				}
 			} else {
-				toplog.Debug("Metadata - appId %v name: [%v] - Too soon to reload", appId, appName)
+				toplog.DebugC(toplog.MetadataCategory, "Metadata - appId %v name: [%v] - Too soon to reload", appId, appName)
 				nextLoadTime := minimumLoadTimeMS - timeSinceLastLoad
-				toplog.Debug("Metadata - appId %v name: [%v] - Try to load in: %v", appId, appName, nextLoadTime)
+				toplog.DebugC(toplog.MetadataCategory, "Metadata - appId %v name: [%v] - Try to load in: %v", appId, appName, nextLoadTime)
 				if minNextLoadTime > nextLoadTime {
-					toplog.Debug("Metadata - appId %v name: [%v] - value was min: %v", appId, appName, nextLoadTime)
+					toplog.DebugC(toplog.MetadataCategory, "Metadata - appId %v name: [%v] - value was min: %v", appId, appName, nextLoadTime)
 					minNextLoadTime = nextLoadTime
 				}
 			}
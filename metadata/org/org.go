@@ -17,8 +17,11 @@ package org
 
 import (
 	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
@@ -52,9 +55,26 @@ type Org struct {
 }
 
 var (
+	// Time data was last loaded, read by the UI while loaders set it
+	cacheTimeMu sync.Mutex
+	cacheTime   *time.Time
+
 	orgsMetadataCache []Org
 )
 
+func GetCacheTime() *time.Time {
+	cacheTimeMu.Lock()
+	defer cacheTimeMu.Unlock()
+	return cacheTime
+}
+
+func setCacheTime() {
+	now := clock.Now()
+	cacheTimeMu.Lock()
+	cacheTime = &now
+	cacheTimeMu.Unlock()
+}
+
 func All() []Org {
 	return orgsMetadataCache
 }
@@ -92,6 +112,14 @@ func LoadOrgCache(cliConnection plugin.CliConnection) {
 		return
 	}
 	orgsMetadataCache = data
+	setCacheTime()
+}
+
+// Replace the cache with orgs loaded elsewhere, e.g., returned inline
+// with the apps
+func SetCache(orgs []Org) {
+	orgsMetadataCache = orgs
+	setCacheTime()
 }
 
 func getOrgMetadata(cliConnection plugin.CliConnection) ([]Org, error) {
@@ -17,20 +17,23 @@ package route
 
 import (
 	"encoding/json"
-	"fmt"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
 
+// Resources are left raw so they can be decoded individually (see AppResponse)
 type RouteResponse struct {
-	Count     int             `json:"total_results"`
-	Pages     int             `json:"total_pages"`
-	NextUrl   string          `json:"next_url"`
-	Resources []RouteResource `json:"resources"`
+	Count     int               `json:"total_results"`
+	Pages     int               `json:"total_pages"`
+	NextUrl   string            `json:"next_url"`
+	Resources []json.RawMessage `json:"resources"`
 }
 
 type RouteResource struct {
@@ -63,14 +66,25 @@ func CreateInternalGeneratedRoute(hostName string, pathName string, domainGuid s
 }
 
 var (
+	// Time data was last loaded, read by the UI while loaders set it
+	cacheTimeMu sync.Mutex
+	cacheTime   *time.Time
+
 	routesMetadataCache         []*Route
 	internalRoutesMetadataCache []*Route
-	// Key: routeId, value: list of AppId
-	appsForRouteCache map[string][]string
 )
 
-func init() {
-	appsForRouteCache = make(map[string][]string)
+func GetCacheTime() *time.Time {
+	cacheTimeMu.Lock()
+	defer cacheTimeMu.Unlock()
+	return cacheTime
+}
+
+func setCacheTime() {
+	now := clock.Now()
+	cacheTimeMu.Lock()
+	cacheTime = &now
+	cacheTimeMu.Unlock()
 }
 
 func AllRoutes() []*Route {
@@ -91,41 +105,28 @@ func FindRouteMetadata(routeGuid string) *Route {
 	return &Route{Guid: routeGuid}
 }
 
-func FindAppIdsForRouteMetadata(cliConnection plugin.CliConnection, routeGuid string) []string {
-	appIds := appsForRouteCache[routeGuid]
-	if appIds == nil {
-		// We stick an empty array in to prevent triggering go routine multiple times
-		// TODO: Find a better way to do this
-		// TODO: Need a way to do a callback / tickle when metadata is loaded so
-		// caller who wanted the data can refresh screen (if still relevant)
-		appsForRouteCache[routeGuid] = make([]string, 0)
-		go LoadAppsForRouteCache(cliConnection, routeGuid)
-	}
-	return appIds
-}
-
 func LoadRouteCache(cliConnection plugin.CliConnection) {
-	data, err := getRouteMetadata(cliConnection)
+	data, skipped, err := getRouteMetadata(cliConnection)
 	if err != nil {
 		toplog.Warn("*** route metadata error: %v", err.Error())
 		return
 	}
-	routesMetadataCache = data
-}
-
-func LoadAppsForRouteCache(cliConnection plugin.CliConnection, routeId string) {
-	appIds := getAppIdsForRoute(cliConnection, routeId)
-	if appIds != nil {
-		appsForRouteCache[routeId] = appIds
+	if skipped > 0 {
+		toplog.Info("Route metadata loaded with %v malformed record(s) skipped", skipped)
 	}
+	routesMetadataCache = data
+	setCacheTime()
 }
 
 func getAppIdsForRoute(cliConnection plugin.CliConnection, routeId string) []string {
-	appList, err := getAppsForRoute(cliConnection, routeId)
+	appList, skipped, err := getAppsForRoute(cliConnection, routeId)
 	if err != nil {
 		toplog.Warn("*** getAppsForRoute metadata error: %v", err.Error())
 		return nil
 	}
+	if skipped > 0 {
+		toplog.Info("Apps for route %v loaded with %v malformed record(s) skipped", routeId, skipped)
+	}
 	appIdList := make([]string, len(appList))
 	for i, app := range appList {
 		appIdList[i] = app.Guid
@@ -133,16 +134,19 @@ func getAppIdsForRoute(cliConnection plugin.CliConnection, routeId string) []str
 	return appIdList
 }
 
-func getAppsForRoute(cliConnection plugin.CliConnection, routeId string) ([]*app.AppMetadata, error) {
-	url := fmt.Sprintf("/v2/routes/%v/apps", routeId)
+func getAppsForRoute(cliConnection plugin.CliConnection, routeId string) ([]*app.AppMetadata, int, error) {
+	url := common.GetEndpointConfig().RouteAppsUrl(routeId)
 	toplog.Debug("getAppsForRoute url: %v", url)
 	return app.GetAppsMetadataFromUrl(cliConnection, url)
 }
 
-func getRouteMetadata(cliConnection plugin.CliConnection) ([]*Route, error) {
+// getRouteMetadata returns the routes that could be parsed along with a
+// count of the resources that were skipped because they were malformed
+func getRouteMetadata(cliConnection plugin.CliConnection) ([]*Route, int, error) {
 
-	url := "/v2/routes"
+	url := common.GetEndpointConfig().RoutesUrl()
 	metadata := []*Route{}
+	skipped := 0
 
 	toplog.Debug("Route>>getRouteMetadata start")
 
@@ -153,7 +157,13 @@ func getRouteMetadata(cliConnection plugin.CliConnection) ([]*Route, error) {
 			toplog.Warn("*** %v unmarshal parsing output: %v", url, string(outputBytes[:]))
 			return metadata, "", err
 		}
-		for _, item := range response.Resources {
+		for _, rawResource := range response.Resources {
+			var item RouteResource
+			if err := json.Unmarshal(rawResource, &item); err != nil {
+				skipped++
+				toplog.Debug("%v skipping malformed resource: %v resource: %v", url, err, string(rawResource))
+				continue
+			}
 			item.Entity.Guid = item.Meta.Guid
 			entity := item.Entity
 			metadata = append(metadata, &entity)
@@ -163,8 +173,8 @@ func getRouteMetadata(cliConnection plugin.CliConnection) ([]*Route, error) {
 
 	err := common.CallPagableAPI(cliConnection, url, handleRequest)
 
-	toplog.Debug("Route>>getRouteMetadata complete - loaded: %v items", len(metadata))
+	toplog.Debug("Route>>getRouteMetadata complete - loaded: %v items skipped: %v", len(metadata), skipped)
 
-	return metadata, err
+	return metadata, skipped, err
 
 }
@@ -0,0 +1,172 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+type RouteMappingResponse struct {
+	Count     int               `json:"total_results"`
+	Pages     int               `json:"total_pages"`
+	NextUrl   string            `json:"next_url"`
+	Resources []json.RawMessage `json:"resources"`
+}
+
+type RouteMappingResource struct {
+	Meta   common.Meta  `json:"metadata"`
+	Entity RouteMapping `json:"entity"`
+}
+
+type RouteMapping struct {
+	AppGuid   string `json:"app_guid"`
+	RouteGuid string `json:"route_guid"`
+}
+
+// Apps mapped to each route and the number of routes mapped to each app.
+// Filled by the route mapping load and updated each time the apps of a
+// single route are loaded.
+type RouteMappingManager struct {
+	mu sync.Mutex
+	// Key: routeId, value: list of AppId
+	appsForRoute map[string][]string
+	// Key: appId, value: number of routes mapped to the app.  Nil until the
+	// route mappings have been loaded.
+	routeCountForApp map[string]int
+}
+
+func NewRouteMappingManager() *RouteMappingManager {
+	return &RouteMappingManager{appsForRoute: make(map[string][]string)}
+}
+
+// RouteCountForApp returns the number of routes mapped to the app.  The
+// second return value is false if the route mappings have not been loaded.
+func (mgr *RouteMappingManager) RouteCountForApp(appId string) (int, bool) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.routeCountForApp == nil {
+		return 0, false
+	}
+	return mgr.routeCountForApp[appId], true
+}
+
+// The apps mapped to the route.  If the route is not known yet the apps
+// are loaded in the background and nil is returned.
+func (mgr *RouteMappingManager) FindAppIdsForRoute(cliConnection plugin.CliConnection, routeGuid string) []string {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	appIds, found := mgr.appsForRoute[routeGuid]
+	if !found {
+		// We stick an empty array in to prevent triggering go routine multiple times
+		// TODO: Need a way to do a callback / tickle when metadata is loaded so
+		// caller who wanted the data can refresh screen (if still relevant)
+		mgr.appsForRoute[routeGuid] = make([]string, 0)
+		go mgr.loadAppsForRoute(cliConnection, routeGuid)
+	}
+	return appIds
+}
+
+func (mgr *RouteMappingManager) loadAppsForRoute(cliConnection plugin.CliConnection, routeId string) {
+	appIds := getAppIdsForRoute(cliConnection, routeId)
+	if appIds != nil {
+		mgr.SetAppsForRoute(routeId, appIds)
+	}
+}
+
+// Replace the apps mapped to a route, an empty list removes the route's
+// mappings.  The route counts of the apps added / removed are updated.
+func (mgr *RouteMappingManager) SetAppsForRoute(routeId string, appIds []string) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if mgr.routeCountForApp != nil {
+		for _, appId := range mgr.appsForRoute[routeId] {
+			mgr.routeCountForApp[appId]--
+			if mgr.routeCountForApp[appId] <= 0 {
+				delete(mgr.routeCountForApp, appId)
+			}
+		}
+		for _, appId := range appIds {
+			mgr.routeCountForApp[appId]++
+		}
+	}
+	mgr.appsForRoute[routeId] = appIds
+}
+
+func (mgr *RouteMappingManager) LoadRouteMappingCache(cliConnection plugin.CliConnection) {
+	data, skipped, err := getRouteMappingMetadata(cliConnection)
+	if err != nil {
+		toplog.Warn("*** route mapping metadata error: %v", err.Error())
+		return
+	}
+	if skipped > 0 {
+		toplog.Info("Route mapping metadata loaded with %v malformed record(s) skipped", skipped)
+	}
+	mgr.setRouteMappings(data)
+}
+
+func (mgr *RouteMappingManager) setRouteMappings(mappings []*RouteMapping) {
+	appsForRoute := make(map[string][]string)
+	routeCountForApp := make(map[string]int)
+	for _, mapping := range mappings {
+		appsForRoute[mapping.RouteGuid] = append(appsForRoute[mapping.RouteGuid], mapping.AppGuid)
+		routeCountForApp[mapping.AppGuid]++
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.appsForRoute = appsForRoute
+	mgr.routeCountForApp = routeCountForApp
+}
+
+func getRouteMappingMetadata(cliConnection plugin.CliConnection) ([]*RouteMapping, int, error) {
+
+	url := common.GetEndpointConfig().RouteMappingsUrl()
+	metadata := []*RouteMapping{}
+	skipped := 0
+
+	toplog.Debug("Route>>getRouteMappingMetadata start")
+
+	handleRequest := func(outputBytes []byte) (data interface{}, nextUrl string, err error) {
+		var response RouteMappingResponse
+		err = json.Unmarshal(outputBytes, &response)
+		if err != nil {
+			toplog.Warn("*** %v unmarshal parsing output: %v", url, string(outputBytes[:]))
+			return metadata, "", err
+		}
+		for _, rawResource := range response.Resources {
+			var item RouteMappingResource
+			if err := json.Unmarshal(rawResource, &item); err != nil || item.Entity.AppGuid == "" {
+				skipped++
+				toplog.Debug("%v skipping malformed resource: %v resource: %v", url, err, string(rawResource))
+				continue
+			}
+			entity := item.Entity
+			metadata = append(metadata, &entity)
+		}
+		return response, response.NextUrl, nil
+	}
+
+	err := common.CallPagableAPI(cliConnection, url, handleRequest)
+
+	toplog.Debug("Route>>getRouteMappingMetadata complete - loaded: %v items skipped: %v", len(metadata), skipped)
+
+	return metadata, skipped, err
+
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouteMappingManager", func() {
+	var mgr *RouteMappingManager
+
+	BeforeEach(func() {
+		mgr = NewRouteMappingManager()
+	})
+
+	routeCount := func(appId string) int {
+		count, loaded := mgr.RouteCountForApp(appId)
+		Expect(loaded).To(BeTrue())
+		return count
+	}
+
+	It("reports the route counts as not loaded until the mappings are loaded", func() {
+		mgr.SetAppsForRoute("route-1", []string{"app-1"})
+		_, loaded := mgr.RouteCountForApp("app-1")
+		Expect(loaded).To(BeFalse())
+	})
+
+	It("counts the routes mapped to each app", func() {
+		mgr.setRouteMappings([]*RouteMapping{
+			{AppGuid: "app-1", RouteGuid: "route-1"},
+			{AppGuid: "app-1", RouteGuid: "route-2"},
+			{AppGuid: "app-2", RouteGuid: "route-2"},
+		})
+		Expect(routeCount("app-1")).To(Equal(2))
+		Expect(routeCount("app-2")).To(Equal(1))
+		Expect(routeCount("app-3")).To(Equal(0))
+	})
+
+	It("updates the counts when the apps of a route change", func() {
+		mgr.setRouteMappings([]*RouteMapping{
+			{AppGuid: "app-1", RouteGuid: "route-1"},
+			{AppGuid: "app-2", RouteGuid: "route-1"},
+		})
+
+		mgr.SetAppsForRoute("route-1", []string{"app-2", "app-3"})
+		Expect(routeCount("app-1")).To(Equal(0))
+		Expect(routeCount("app-2")).To(Equal(1))
+		Expect(routeCount("app-3")).To(Equal(1))
+
+		mgr.SetAppsForRoute("route-2", []string{"app-3"})
+		Expect(routeCount("app-3")).To(Equal(2))
+
+		mgr.SetAppsForRoute("route-1", []string{})
+		Expect(routeCount("app-2")).To(Equal(0))
+		Expect(routeCount("app-3")).To(Equal(1))
+	})
+
+	It("replaces routes loaded individually with the next full load", func() {
+		mgr.setRouteMappings(nil)
+		mgr.SetAppsForRoute("route-1", []string{"app-1"})
+		mgr.setRouteMappings([]*RouteMapping{{AppGuid: "app-2", RouteGuid: "route-2"}})
+		Expect(routeCount("app-1")).To(Equal(0))
+		Expect(routeCount("app-2")).To(Equal(1))
+	})
+})
@@ -0,0 +1,229 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceBinding
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+const (
+	ManagedServiceInstanceType      = "managed_service_instance"
+	UserProvidedServiceInstanceType = "user_provided_service_instance"
+)
+
+type ServiceBindingResponse struct {
+	Count     int                      `json:"total_results"`
+	Pages     int                      `json:"total_pages"`
+	NextUrl   string                   `json:"next_url"`
+	Resources []ServiceBindingResource `json:"resources"`
+}
+
+type ServiceBindingResource struct {
+	Meta   common.Meta          `json:"metadata"`
+	Entity ServiceBindingEntity `json:"entity"`
+}
+
+type ServiceBindingEntity struct {
+	AppGuid             string                  `json:"app_guid"`
+	ServiceInstanceGuid string                  `json:"service_instance_guid"`
+	ServiceInstance     ServiceInstanceResource `json:"service_instance"`
+}
+
+type ServiceInstanceResource struct {
+	Meta   common.Meta           `json:"metadata"`
+	Entity ServiceInstanceEntity `json:"entity"`
+}
+
+type ServiceInstanceEntity struct {
+	Name        string              `json:"name"`
+	Type        string              `json:"type"`
+	ServicePlan ServicePlanResource `json:"service_plan"`
+}
+
+type ServicePlanResource struct {
+	Meta   common.Meta       `json:"metadata"`
+	Entity ServicePlanEntity `json:"entity"`
+}
+
+type ServicePlanEntity struct {
+	Name        string `json:"name"`
+	ServiceGuid string `json:"service_guid"`
+}
+
+type ServiceResponse struct {
+	Meta   common.Meta   `json:"metadata"`
+	Entity ServiceEntity `json:"entity"`
+}
+
+type ServiceEntity struct {
+	Label string `json:"label"`
+}
+
+// A service instance bound to an app
+type ServiceBinding struct {
+	Guid                string
+	AppGuid             string
+	ServiceInstanceGuid string
+	ServiceInstanceName string
+	// ManagedServiceInstanceType or UserProvidedServiceInstanceType
+	ServiceInstanceType string
+	// Service offering label and plan name, empty for user provided instances
+	ServiceLabel string
+	PlanName     string
+}
+
+func (sb *ServiceBinding) IsUserProvided() bool {
+	return sb.ServiceInstanceType == UserProvidedServiceInstanceType
+}
+
+type serviceBindingsCacheEntry struct {
+	bindings []*ServiceBinding
+	// Zero while the first load is in progress
+	loadTime time.Time
+	// Error of the last load, kept with its loadTime so the load is not
+	// retried before config.ServiceBindingRetrySeconds
+	loadErr error
+	loading bool
+}
+
+var (
+	mu sync.Mutex
+	// Key: appId
+	serviceBindingsForAppCache = make(map[string]*serviceBindingsCacheEntry)
+	// Key: service guid, value: service label
+	serviceLabelCache = make(map[string]string)
+)
+
+// FindServiceBindingsForApp returns the cached service bindings of an app.
+// If they are not cached yet they are loaded in the background and the
+// second return value is false until the load completes.  A failed load is
+// retried after config.ServiceBindingRetrySeconds, its error is returned
+// until then.
+func FindServiceBindingsForApp(cliConnection plugin.CliConnection, appId string) ([]*ServiceBinding, bool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	entry := serviceBindingsForAppCache[appId]
+	if entry == nil {
+		entry = &serviceBindingsCacheEntry{loading: true}
+		serviceBindingsForAppCache[appId] = entry
+		go LoadServiceBindingsForAppCache(cliConnection, appId)
+	} else if entry.loadErr != nil && !entry.loading && clock.Since(entry.loadTime) > config.ServiceBindingRetrySeconds*time.Second {
+		entry.loading = true
+		go LoadServiceBindingsForAppCache(cliConnection, appId)
+	}
+	return entry.bindings, !entry.loadTime.IsZero(), entry.loadErr
+}
+
+func LoadServiceBindingsForAppCache(cliConnection plugin.CliConnection, appId string) {
+	bindings, err := getServiceBindingsForApp(cliConnection, appId)
+	mu.Lock()
+	defer mu.Unlock()
+	entry := serviceBindingsForAppCache[appId]
+	if entry == nil {
+		// Cache was flushed while loading
+		return
+	}
+	entry.loading = false
+	entry.loadTime = clock.Now()
+	entry.loadErr = err
+	if err != nil {
+		toplog.Warn("*** service binding metadata error (retry in %v seconds): %v", config.ServiceBindingRetrySeconds, err.Error())
+		return
+	}
+	entry.bindings = bindings
+}
+
+// FlushCache removes all cached service bindings so they are reloaded the
+// next time they are requested
+func FlushCache() {
+	mu.Lock()
+	defer mu.Unlock()
+	serviceBindingsForAppCache = make(map[string]*serviceBindingsCacheEntry)
+	serviceLabelCache = make(map[string]string)
+}
+
+func getServiceBindingsForApp(cliConnection plugin.CliConnection, appId string) ([]*ServiceBinding, error) {
+
+	url := common.GetEndpointConfig().AppServiceBindingsUrl(appId)
+	toplog.Debug("getServiceBindingsForApp url: %v", url)
+	bindings := []*ServiceBinding{}
+
+	handleRequest := func(outputBytes []byte) (data interface{}, nextUrl string, err error) {
+		var response ServiceBindingResponse
+		err = json.Unmarshal(outputBytes, &response)
+		if err != nil {
+			toplog.Warn("*** %v unmarshal parsing output: %v", url, string(outputBytes[:]))
+			return bindings, "", err
+		}
+		for _, item := range response.Resources {
+			instance := item.Entity.ServiceInstance.Entity
+			binding := &ServiceBinding{
+				Guid:                item.Meta.Guid,
+				AppGuid:             item.Entity.AppGuid,
+				ServiceInstanceGuid: item.Entity.ServiceInstanceGuid,
+				ServiceInstanceName: instance.Name,
+				ServiceInstanceType: instance.Type,
+				PlanName:            instance.ServicePlan.Entity.Name,
+			}
+			if serviceGuid := instance.ServicePlan.Entity.ServiceGuid; serviceGuid != "" {
+				binding.ServiceLabel = getServiceLabel(cliConnection, serviceGuid)
+			}
+			bindings = append(bindings, binding)
+		}
+		return response, response.NextUrl, nil
+	}
+
+	err := common.CallPagableAPI(cliConnection, url, handleRequest)
+	return bindings, err
+}
+
+// Service offering labels are shared by many instances so they are cached
+// separately.  The guid is returned if the label can not be loaded.
+func getServiceLabel(cliConnection plugin.CliConnection, serviceGuid string) string {
+	mu.Lock()
+	label, found := serviceLabelCache[serviceGuid]
+	mu.Unlock()
+	if found {
+		return label
+	}
+
+	url := fmt.Sprintf("/v2/services/%v", serviceGuid)
+	output, err := common.CallAPI(cliConnection, url)
+	if err != nil {
+		toplog.Warn("*** %v error: %v", url, err.Error())
+		return serviceGuid
+	}
+	var response ServiceResponse
+	if err := json.Unmarshal([]byte(output), &response); err != nil {
+		toplog.Warn("*** %v unmarshal parsing output: %v", url, output)
+		return serviceGuid
+	}
+	label = response.Entity.Label
+
+	mu.Lock()
+	serviceLabelCache[serviceGuid] = label
+	mu.Unlock()
+	return label
+}
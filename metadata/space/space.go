@@ -17,8 +17,11 @@ package space
 
 import (
 	"encoding/json"
+	"sync"
+	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
@@ -50,9 +53,26 @@ type Space struct {
 }
 
 var (
+	// Time data was last loaded, read by the UI while loaders set it
+	cacheTimeMu sync.Mutex
+	cacheTime   *time.Time
+
 	spacesMetadataCache []Space
 )
 
+func GetCacheTime() *time.Time {
+	cacheTimeMu.Lock()
+	defer cacheTimeMu.Unlock()
+	return cacheTime
+}
+
+func setCacheTime() {
+	now := clock.Now()
+	cacheTimeMu.Lock()
+	cacheTime = &now
+	cacheTimeMu.Unlock()
+}
+
 func All() []Space {
 	return spacesMetadataCache
 }
@@ -82,11 +102,24 @@ func LoadSpaceCache(cliConnection plugin.CliConnection) {
 		return
 	}
 	spacesMetadataCache = data
+	setCacheTime()
+}
+
+// Replace the cache with spaces loaded elsewhere, e.g., returned inline
+// with the apps
+func SetCache(spaces []Space) {
+	for i := range spaces {
+		if spaces[i].IsolationSegmentGuid == "" {
+			spaces[i].IsolationSegmentGuid = isolationSegment.DefaultIsolationSegmentGuid
+		}
+	}
+	spacesMetadataCache = spaces
+	setCacheTime()
 }
 
 func getSpaceMetadata(cliConnection plugin.CliConnection) ([]Space, error) {
 
-	url := "/v2/spaces"
+	url := common.GetEndpointConfig().SpacesUrl()
 	metadata := []Space{}
 
 	handleRequest := func(outputBytes []byte) (data interface{}, nextUrl string, err error) {
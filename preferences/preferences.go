@@ -0,0 +1,156 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preferences
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Written in the cf CLI config directory ($CF_HOME/.cf or ~/.cf)
+const FileName = "top_preferences.json"
+
+// Settings kept between sessions
+type Preferences struct {
+	Presets []*Preset `json:"presets,omitempty"`
+	// View name -> column id -> display width
+	ColumnWidths map[string]map[string]int `json:"column_widths,omitempty"`
+	// Log view timestamps without the date and zone
+	LogShortTimestamps bool `json:"log_short_timestamps,omitempty"`
+	// Log view wraps long lines instead of scrolling horizontally
+	LogWrap bool `json:"log_wrap,omitempty"`
+}
+
+// Named bundle of the org / space scope and the sort, filters and visible
+// columns of one list view.  Presets are applied to the view they were
+// saved from.
+type Preset struct {
+	Name           string            `json:"name"`
+	ViewName       string            `json:"view_name"`
+	OrgGuid        string            `json:"org_guid,omitempty"`
+	SpaceGuid      string            `json:"space_guid,omitempty"`
+	SortColumns    []*SortColumn     `json:"sort_columns,omitempty"`
+	Filters        map[string]string `json:"filters,omitempty"`
+	VisibleColumns []string          `json:"visible_columns,omitempty"`
+	// Filter toggles of the view itself, see ui presetStateView
+	ViewState map[string]string `json:"view_state,omitempty"`
+}
+
+type SortColumn struct {
+	Id          string `json:"id"`
+	ReverseSort bool   `json:"reverse_sort,omitempty"`
+}
+
+func Path() string {
+	dir := os.Getenv("CF_HOME")
+	if dir == "" {
+		dir = os.Getenv("HOME")
+	}
+	if dir == "" {
+		dir = os.Getenv("USERPROFILE")
+	}
+	return filepath.Join(dir, ".cf", FileName)
+}
+
+// Load the saved preferences, empty preferences if none have been saved yet
+func Load() (*Preferences, error) {
+	path := Path()
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Preferences{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p Preferences
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return &p, nil
+}
+
+func (p *Preferences) Save() error {
+	path := Path()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// Presets saved from the given view sorted by name
+func (p *Preferences) PresetsForView(viewName string) []*Preset {
+	presets := make([]*Preset, 0)
+	for _, preset := range p.Presets {
+		if preset.ViewName == viewName {
+			presets = append(presets, preset)
+		}
+	}
+	sort.Sort(presetsByName(presets))
+	return presets
+}
+
+type presetsByName []*Preset
+
+func (p presetsByName) Len() int           { return len(p) }
+func (p presetsByName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p presetsByName) Less(i, j int) bool { return p[i].Name < p[j].Name }
+
+// Add the preset, replacing a preset of the same name saved from the same view
+func (p *Preferences) SetPreset(preset *Preset) {
+	for i, existing := range p.Presets {
+		if existing.ViewName == preset.ViewName && existing.Name == preset.Name {
+			p.Presets[i] = preset
+			return
+		}
+	}
+	p.Presets = append(p.Presets, preset)
+}
+
+func (p *Preferences) RemovePreset(viewName, name string) {
+	presets := make([]*Preset, 0, len(p.Presets))
+	for _, preset := range p.Presets {
+		if preset.ViewName != viewName || preset.Name != name {
+			presets = append(presets, preset)
+		}
+	}
+	p.Presets = presets
+}
+
+// Column width overrides saved for the view, nil if none
+func (p *Preferences) ColumnWidthsForView(viewName string) map[string]int {
+	return p.ColumnWidths[viewName]
+}
+
+// Replace the column width overrides of the view.  An empty map removes
+// the view's entry.
+func (p *Preferences) SetColumnWidths(viewName string, widths map[string]int) {
+	if len(widths) == 0 {
+		delete(p.ColumnWidths, viewName)
+		return
+	}
+	if p.ColumnWidths == nil {
+		p.ColumnWidths = make(map[string]map[string]int)
+	}
+	p.ColumnWidths[viewName] = widths
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preferences_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/preferences"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Preferences", func() {
+	var (
+		cfHome      string
+		savedCfHome string
+	)
+
+	BeforeEach(func() {
+		var err error
+		cfHome, err = ioutil.TempDir("", "top-preferences")
+		Expect(err).NotTo(HaveOccurred())
+		savedCfHome = os.Getenv("CF_HOME")
+		os.Setenv("CF_HOME", cfHome)
+	})
+
+	AfterEach(func() {
+		os.Setenv("CF_HOME", savedCfHome)
+		os.RemoveAll(cfHome)
+	})
+
+	It("is in the cf config directory", func() {
+		Expect(preferences.Path()).To(Equal(filepath.Join(cfHome, ".cf", preferences.FileName)))
+	})
+
+	It("loads empty preferences when none have been saved", func() {
+		prefs, err := preferences.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prefs).To(Equal(&preferences.Preferences{}))
+	})
+
+	It("loads the saved preferences", func() {
+		prefs := &preferences.Preferences{LogShortTimestamps: true, LogWrap: true}
+		prefs.SetPreset(&preferences.Preset{
+			Name:           "busy",
+			ViewName:       "appListView",
+			OrgGuid:        "org-1",
+			SortColumns:    []*preferences.SortColumn{{Id: "CPU", ReverseSort: true}},
+			Filters:        map[string]string{"APPLICATION": "web"},
+			VisibleColumns: []string{"APPLICATION", "CPU"},
+			ViewState:      map[string]string{"crash_filter": "true"},
+		})
+		prefs.SetColumnWidths("appListView", map[string]int{"APPLICATION": 40})
+		Expect(prefs.Save()).To(Succeed())
+
+		loaded, err := preferences.Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(prefs))
+	})
+
+	It("reports a file that is not valid JSON", func() {
+		Expect(os.MkdirAll(filepath.Dir(preferences.Path()), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(preferences.Path(), []byte("{"), 0600)).To(Succeed())
+		_, err := preferences.Load()
+		Expect(err).To(MatchError(ContainSubstring(preferences.Path())))
+	})
+
+	Describe("presets", func() {
+		var prefs *preferences.Preferences
+
+		BeforeEach(func() {
+			prefs = &preferences.Preferences{}
+			prefs.SetPreset(&preferences.Preset{Name: "web", ViewName: "appListView"})
+			prefs.SetPreset(&preferences.Preset{Name: "api", ViewName: "appListView"})
+			prefs.SetPreset(&preferences.Preset{Name: "web", ViewName: "routeListView"})
+		})
+
+		It("lists the presets of the view sorted by name", func() {
+			presets := prefs.PresetsForView("appListView")
+			Expect(presets).To(HaveLen(2))
+			Expect(presets[0].Name).To(Equal("api"))
+			Expect(presets[1].Name).To(Equal("web"))
+		})
+
+		It("replaces a preset of the same name and view", func() {
+			prefs.SetPreset(&preferences.Preset{Name: "web", ViewName: "appListView", SpaceGuid: "space-1"})
+			presets := prefs.PresetsForView("appListView")
+			Expect(presets).To(HaveLen(2))
+			Expect(presets[1].SpaceGuid).To(Equal("space-1"))
+		})
+
+		It("removes only the preset of the view", func() {
+			prefs.RemovePreset("appListView", "web")
+			Expect(prefs.PresetsForView("appListView")).To(HaveLen(1))
+			Expect(prefs.PresetsForView("routeListView")).To(HaveLen(1))
+		})
+	})
+
+	It("removes the column widths of a view when set to empty", func() {
+		prefs := &preferences.Preferences{}
+		prefs.SetColumnWidths("appListView", map[string]int{"APPLICATION": 40})
+		Expect(prefs.ColumnWidthsForView("appListView")).To(Equal(map[string]int{"APPLICATION": 40}))
+		prefs.SetColumnWidths("appListView", nil)
+		Expect(prefs.ColumnWidthsForView("appListView")).To(BeNil())
+	})
+
+})
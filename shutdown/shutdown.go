@@ -0,0 +1,91 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shutdown
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// Cleanup hooks run when top exits.  Hooks run one at a time in the order
+// they were registered.  Each hook is given config.ShutdownHookTimeoutSeconds
+// to complete; a hook that does not complete in time is abandoned so that a
+// hung step (e.g., closing a firehose connection) does not prevent the
+// remaining steps (e.g., restoring the terminal) from running.
+
+type hook struct {
+	name string
+	f    func() error
+}
+
+var (
+	mu      sync.Mutex
+	hooks   []*hook
+	runOnce sync.Once
+)
+
+// Register a cleanup hook to be run on shutdown
+func Register(name string, f func() error) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, &hook{name: name, f: f})
+}
+
+// Run all registered hooks.  Only the first call runs the hooks, subsequent
+// calls return immediately.
+func Run() {
+	runOnce.Do(func() {
+		mu.Lock()
+		toRun := make([]*hook, len(hooks))
+		copy(toRun, hooks)
+		mu.Unlock()
+
+		timeout := time.Duration(config.ShutdownHookTimeoutSeconds) * time.Second
+		startTime := time.Now()
+		toplog.Debug("Shutdown start - %v step(s)", len(toRun))
+		for _, h := range toRun {
+			runHook(h, timeout)
+		}
+		toplog.Debug("Shutdown complete (%v)", time.Since(startTime).Truncate(time.Millisecond))
+	})
+}
+
+func runHook(h *hook, timeout time.Duration) {
+	toplog.Debug("Shutdown step %v: start", h.name)
+	startTime := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- h.f()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			toplog.Debug("Shutdown step %v: error: %v", h.name, err)
+		}
+		toplog.Debug("Shutdown step %v: complete (%v)", h.name, time.Since(startTime).Truncate(time.Millisecond))
+	case <-time.After(timeout):
+		toplog.Debug("Shutdown step %v: timed out after %v, continuing", h.name, timeout)
+	}
+}
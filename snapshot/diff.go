@@ -0,0 +1,192 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+const (
+	DIFF_CHANGED = ""
+	DIFF_NEW     = "NEW"
+	DIFF_REMOVED = "REMOVED"
+)
+
+// Per-app difference between two snapshots.  Before or After is nil
+// when the app is only present in one of the snapshots.
+type AppDiff struct {
+	AppId     string
+	AppName   string
+	SpaceName string
+	OrgName   string
+	Status    string
+
+	Before *dataCommon.AppSummary
+	After  *dataCommon.AppSummary
+
+	CpuDelta      float64
+	MemoryDelta   int64
+	Crash24hDelta int
+	Http5xxDelta  int64
+}
+
+func (d *AppDiff) Id() string {
+	return d.AppId
+}
+
+func Diff(before, after *Snapshot) []*AppDiff {
+	beforeMap := make(map[string]*dataCommon.AppSummary)
+	for _, app := range before.Apps {
+		beforeMap[app.AppId] = app
+	}
+	afterMap := make(map[string]*dataCommon.AppSummary)
+	for _, app := range after.Apps {
+		afterMap[app.AppId] = app
+	}
+
+	diffs := make([]*AppDiff, 0, len(afterMap))
+	for appId, afterApp := range afterMap {
+		diffs = append(diffs, newAppDiff(beforeMap[appId], afterApp))
+	}
+	for appId, beforeApp := range beforeMap {
+		if afterMap[appId] == nil {
+			diffs = append(diffs, newAppDiff(beforeApp, nil))
+		}
+	}
+	return diffs
+}
+
+func newAppDiff(before, after *dataCommon.AppSummary) *AppDiff {
+	// Missing side is treated as all zero stats
+	empty := &dataCommon.AppSummary{}
+	d := &AppDiff{Before: before, After: after, Status: DIFF_CHANGED}
+	named := after
+	switch {
+	case before == nil:
+		d.Status = DIFF_NEW
+		before = empty
+	case after == nil:
+		d.Status = DIFF_REMOVED
+		after = empty
+		named = before
+	}
+	d.AppId = named.AppId
+	d.AppName = named.AppName
+	d.SpaceName = named.SpaceName
+	d.OrgName = named.OrgName
+
+	d.CpuDelta = after.TotalCpuPercentage - before.TotalCpuPercentage
+	d.MemoryDelta = after.TotalMemoryUsed - before.TotalMemoryUsed
+	d.Crash24hDelta = after.Crash24hCount - before.Crash24hCount
+	d.Http5xxDelta = after.Http5xxCount - before.Http5xxCount
+	return d
+}
+
+// Render the differences as a plain text table using the same list
+// widget table rendering as the 'T' (copy as table) action
+func DiffTable(diffs []*AppDiff) string {
+	listWidget := uiCommon.NewListWidget(nil, "snapshotDiff", 0, nil, diffColumns(), nil)
+	listWidget.SetSortColumns([]*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("CPU_DELTA", true),
+		uiCommon.NewSortColumn("APPLICATION", false),
+	})
+	listData := make([]uiCommon.IData, 0, len(diffs))
+	for _, d := range diffs {
+		listData = append(listData, d)
+	}
+	listWidget.SetListData(listData)
+	return listWidget.TableText()
+}
+
+func diffColumns() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, diffTextColumn("APPLICATION", "APPLICATION", 30,
+		func(d *AppDiff) string { return d.AppName }))
+	columns = append(columns, diffTextColumn("SPACE", "SPACE", 10,
+		func(d *AppDiff) string { return d.SpaceName }))
+	columns = append(columns, diffTextColumn("ORG", "ORG", 10,
+		func(d *AppDiff) string { return d.OrgName }))
+	columns = append(columns, diffTextColumn("STATUS", "STATUS", 7,
+		func(d *AppDiff) string { return d.Status }))
+	columns = append(columns, diffNumberColumn("CPU", "CPU%", 6,
+		func(d *AppDiff) float64 {
+			if d.After == nil {
+				return 0
+			}
+			return d.After.TotalCpuPercentage
+		},
+		func(d *AppDiff) string {
+			if d.After == nil {
+				return "--"
+			}
+			return fmt.Sprintf("%.2f", d.After.TotalCpuPercentage)
+		}))
+	columns = append(columns, diffNumberColumn("CPU_DELTA", "CPU%+/-", 7,
+		func(d *AppDiff) float64 { return d.CpuDelta },
+		func(d *AppDiff) string { return fmt.Sprintf("%+.2f", d.CpuDelta) }))
+	columns = append(columns, diffNumberColumn("MEM_DELTA", "MEM+/-", 10,
+		func(d *AppDiff) float64 { return float64(d.MemoryDelta) },
+		func(d *AppDiff) string { return formatBytesDelta(d.MemoryDelta) }))
+	columns = append(columns, diffNumberColumn("CRH_DELTA", "CRH+/-", 6,
+		func(d *AppDiff) float64 { return float64(d.Crash24hDelta) },
+		func(d *AppDiff) string { return fmt.Sprintf("%+d", d.Crash24hDelta) }))
+	columns = append(columns, diffNumberColumn("5XX_DELTA", "5XX+/-", 8,
+		func(d *AppDiff) float64 { return float64(d.Http5xxDelta) },
+		func(d *AppDiff) string { return fmt.Sprintf("%+d", d.Http5xxDelta) }))
+	return columns
+}
+
+func diffTextColumn(id, label string, size int, valueFunc func(d *AppDiff) string) *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(valueFunc(c1.(*AppDiff)), valueFunc(c2.(*AppDiff)))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return util.FormatDisplayDataLeft(valueFunc(data.(*AppDiff)), size)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return valueFunc(data.(*AppDiff))
+	}
+	return uiCommon.NewListColumn(id, label, size,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+}
+
+func diffNumberColumn(id, label string, size int,
+	sortValueFunc func(d *AppDiff) float64,
+	displayValueFunc func(d *AppDiff) string) *uiCommon.ListColumn {
+
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return sortValueFunc(c1.(*AppDiff)) < sortValueFunc(c2.(*AppDiff))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return util.FormatDisplayDataRight(displayValueFunc(data.(*AppDiff)), size)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", sortValueFunc(data.(*AppDiff)))
+	}
+	return uiCommon.NewListColumn(id, label, size,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+}
+
+func formatBytesDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + util.FormatBytes(uint64(-delta))
+	}
+	return "+" + util.FormatBytes(uint64(delta))
+}
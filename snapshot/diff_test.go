@@ -0,0 +1,98 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot_test
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/snapshot"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	var (
+		before *snapshot.Snapshot
+		after  *snapshot.Snapshot
+	)
+
+	diffsById := func(diffs []*snapshot.AppDiff) map[string]*snapshot.AppDiff {
+		byId := make(map[string]*snapshot.AppDiff)
+		for _, d := range diffs {
+			byId[d.AppId] = d
+		}
+		return byId
+	}
+
+	BeforeEach(func() {
+		before = &snapshot.Snapshot{Apps: []*dataCommon.AppSummary{
+			{AppId: "app-1", AppName: "web", SpaceName: "dev", OrgName: "acme",
+				TotalCpuPercentage: 10, TotalMemoryUsed: 1000, Crash24hCount: 1, Http5xxCount: 5},
+			{AppId: "app-2", AppName: "worker", SpaceName: "dev", OrgName: "acme",
+				TotalCpuPercentage: 4, TotalMemoryUsed: 500, Crash24hCount: 2, Http5xxCount: 1},
+		}}
+		after = &snapshot.Snapshot{Apps: []*dataCommon.AppSummary{
+			{AppId: "app-1", AppName: "web-renamed", SpaceName: "dev", OrgName: "acme",
+				TotalCpuPercentage: 25.5, TotalMemoryUsed: 400, Crash24hCount: 3, Http5xxCount: 5},
+			{AppId: "app-3", AppName: "api", SpaceName: "prod", OrgName: "acme",
+				TotalCpuPercentage: 7, TotalMemoryUsed: 2000, Crash24hCount: 0, Http5xxCount: 9},
+		}}
+	})
+
+	It("returns one entry per app in either snapshot", func() {
+		diffs := snapshot.Diff(before, after)
+		Expect(diffs).To(HaveLen(3))
+		Expect(diffsById(diffs)).To(HaveKey("app-1"))
+		Expect(diffsById(diffs)).To(HaveKey("app-2"))
+		Expect(diffsById(diffs)).To(HaveKey("app-3"))
+	})
+
+	It("computes the change of an app in both snapshots using the latest names", func() {
+		d := diffsById(snapshot.Diff(before, after))["app-1"]
+		Expect(d.Status).To(Equal(snapshot.DIFF_CHANGED))
+		Expect(d.AppName).To(Equal("web-renamed"))
+		Expect(d.CpuDelta).To(BeNumerically("~", 15.5, 0.001))
+		Expect(d.MemoryDelta).To(Equal(int64(-600)))
+		Expect(d.Crash24hDelta).To(Equal(2))
+		Expect(d.Http5xxDelta).To(BeZero())
+		Expect(d.Before).NotTo(BeNil())
+		Expect(d.After).NotTo(BeNil())
+	})
+
+	It("treats an app only in the later snapshot as new", func() {
+		d := diffsById(snapshot.Diff(before, after))["app-3"]
+		Expect(d.Status).To(Equal(snapshot.DIFF_NEW))
+		Expect(d.Before).To(BeNil())
+		Expect(d.AppName).To(Equal("api"))
+		Expect(d.SpaceName).To(Equal("prod"))
+		Expect(d.CpuDelta).To(BeNumerically("~", 7, 0.001))
+		Expect(d.MemoryDelta).To(Equal(int64(2000)))
+		Expect(d.Http5xxDelta).To(Equal(int64(9)))
+	})
+
+	It("treats an app only in the earlier snapshot as removed", func() {
+		d := diffsById(snapshot.Diff(before, after))["app-2"]
+		Expect(d.Status).To(Equal(snapshot.DIFF_REMOVED))
+		Expect(d.After).To(BeNil())
+		Expect(d.AppName).To(Equal("worker"))
+		Expect(d.CpuDelta).To(BeNumerically("~", -4, 0.001))
+		Expect(d.MemoryDelta).To(Equal(int64(-500)))
+		Expect(d.Crash24hDelta).To(Equal(-2))
+	})
+
+	It("returns no entries for two empty snapshots", func() {
+		Expect(snapshot.Diff(&snapshot.Snapshot{}, &snapshot.Snapshot{})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,66 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+)
+
+// Exported point in time per-app stats.  The app entries use the same
+// schema as the headless stats API (dataCommon.AppSummary).
+type Snapshot struct {
+	TakenAt time.Time                `json:"taken_at"`
+	Target  string                   `json:"target,omitempty"`
+	Apps    []*dataCommon.AppSummary `json:"apps"`
+}
+
+func NewSnapshot(commonData *dataCommon.CommonData, target string) *Snapshot {
+	return &Snapshot{
+		TakenAt: time.Now(),
+		Target:  target,
+		Apps:    commonData.LastAppSummaries(),
+	}
+}
+
+// File name used when exporting from the UI, e.g., top-snapshot-20170301-154500.json
+func DefaultFileName(takenAt time.Time) string {
+	return fmt.Sprintf("top-snapshot-%v.json", takenAt.Format("20060102-150405"))
+}
+
+func (s *Snapshot) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func Load(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return &s, nil
+}
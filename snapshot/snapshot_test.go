@@ -0,0 +1,75 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/snapshot"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshot file", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cftop-snapshot")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("loads the snapshot that was written", func() {
+		written := &snapshot.Snapshot{
+			TakenAt: time.Date(2017, 3, 1, 15, 45, 0, 0, time.UTC),
+			Target:  "api.example.com",
+			Apps: []*dataCommon.AppSummary{
+				{AppId: "app-1", AppName: "web", SpaceName: "dev", OrgName: "acme", StackName: "cflinuxfs2",
+					Monitored: true, DesiredContainers: 2, TotalReportingContainers: 2,
+					TotalCpuPercentage: 12.75, CpuTrend: 1, TotalMemoryUsed: 3 * 1024 * 1024 * 1024,
+					TotalDiskUsed: 512 * 1024 * 1024, Crash1hCount: 1, Crash24hCount: 4,
+					EventL1Rate: 3, EventL10Rate: 25, EventL60Rate: 140,
+					HttpAllCount: 1000, Http2xxCount: 950, Http3xxCount: 10, Http4xxCount: 30, Http5xxCount: 10,
+					HealthScore: 80},
+				{AppId: "app-2", AppName: "worker \"batch\""},
+			},
+		}
+		path := filepath.Join(dir, "snapshot.json")
+		Expect(written.Write(path)).To(Succeed())
+
+		loaded, err := snapshot.Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.TakenAt.Equal(written.TakenAt)).To(BeTrue())
+		Expect(loaded.Target).To(Equal(written.Target))
+		Expect(loaded.Apps).To(Equal(written.Apps))
+	})
+
+	It("writes an empty app list", func() {
+		path := filepath.Join(dir, "snapshot.json")
+		Expect((&snapshot.Snapshot{TakenAt: time.Now()}).Write(path)).To(Succeed())
+		loaded, err := snapshot.Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Apps).To(BeEmpty())
+	})
+})
@@ -19,9 +19,11 @@ import (
 	"bufio"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jeffail/gabs"
@@ -31,9 +33,16 @@ import (
 	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/gorilla/websocket"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/capture"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
+	"github.com/ecsteam/cloudfoundry-top-plugin/shutdown"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	gops "github.com/mitchellh/go-ps"
 )
@@ -49,6 +58,18 @@ type Client struct {
 	pluginMetadata *plugin.PluginMetadata
 	eventrouting   *eventrouting.EventRouter
 	router         *eventrouting.EventRouter
+
+	// Open doppler connections, closed on shutdown
+	mu        sync.Mutex
+	consumers map[*consumer.Consumer]bool
+	stopping  bool
+
+	// Writes received envelopes to ClientOptions.CaptureFile
+	captureWriter    *capture.Writer
+	captureErrorOnce sync.Once
+
+	// Shared by the doppler connections (see firehoseTLSConfig)
+	tlsConfig *tls.Config
 }
 
 // ClientOptions needed to start the Client
@@ -57,6 +78,105 @@ type ClientOptions struct {
 	NoTopCheck bool
 	Cygwin     bool
 	Nozzles    int
+	// Enable keybindings that inject test log messages
+	TestMessages bool
+	// Firehose subscription id.  When empty a random per-process id is used so
+	// that concurrent instances of top each receive the full firehose stream
+	SubscriptionID string
+	// Disable all keybindings that change state (see config.IsKioskMode)
+	Kiosk bool
+	// Two snapshot files (before, after) to compare instead of running top
+	DiffSnapshotFiles []string
+	// Capacity of the queue between the nozzles and event processing
+	EventQueueSize int
+	// Number of goroutines processing firehose events
+	EventWorkers int
+	// Warn at startup when the foundation has more apps than this (0 disables)
+	LargeFoundationAppCount int
+	// Do not prompt for an org/space scope when the foundation is large
+	NoScopePrompt bool
+	// CC API version used to load app metadata: auto, v2 or v3
+	ApiVersion string
+	// Skip route/domain metadata at startup and load when route view is opened
+	QuietStart bool
+	// Window used by the app list crashing apps only filter
+	CrashFilterMinutes int
+	// Overrides of the CC API paths / query parameters used by the app and
+	// route loaders (see common.EndpointConfig).  Zero values keep defaults.
+	AppsPath       string
+	RoutesPath     string
+	ResultsPerPage int
+	ApiQuery       string
+	// Request space / org names inline with app metadata (0 disables)
+	InlineRelationsDepth int
+	// Minutes without a key press before the display is paused (0 disables)
+	IdleTimeoutMinutes int
+	// Also discard firehose events while idle paused
+	IdlePauseIngest bool
+	// Screen recording file (empty uses config.DefaultRecordFileName) and
+	// whether to leave colors out of the recording
+	RecordFileName string
+	RecordNoAnsi   bool
+	// Flag apps with a container using this percent of its reserved memory
+	MemoryRiskPercent int
+	// CPU percent decimal places (config.CpuPrecisionAuto for auto)
+	CpuPrecision int
+	// Show CPU percent divided by the number of cell CPUs
+	CpuPerCore bool
+	// Write all received firehose envelopes to this file (see capture.Writer)
+	CaptureFile string
+	// Read envelopes from a capture file instead of connecting to the
+	// firehose.  The original timing is kept unless ReplayFast is set.
+	ReplayFile string
+	ReplayFast bool
+	// Start with line wrap on in the log view
+	LogWrap bool
+	// Start with short time-only timestamps in the log view
+	LogShortTime bool
+	// Seconds without scrolling before the log view resumes auto scroll (0 disables)
+	LogScrollResumeSeconds int
+	// Seconds without any firehose envelope before the nozzles are reconnected (0 disables)
+	FirehoseIdleSeconds int
+	// Firehose event types processed, nil processes all (see config.EventTypes)
+	EventTypes []string
+	// Highlight metadata age in the header when older than this
+	MetadataWarnMinutes int
+	// Mark apps in the app list updated within this many minutes, 0 disables
+	RecentDeployMinutes int
+	// Warn when a display refresh step takes longer than this, 0 disables
+	RefreshBudgetMS int
+	// Length of the request chart in the app HTTP view
+	RequestHistoryMinutes int
+	// View opened at startup (one of config.StartViewNames)
+	StartView string
+	// How the highlighted list row is kept in view on refresh (see config.HighlightTrackModes)
+	HighlightTrack string
+	// Org or org/space names the display is scoped to at startup
+	Scope string
+	// Log every CC API request (category "api") and optionally the responses
+	ApiTrace        bool
+	ApiTraceVerbose bool
+	// Run without the UI for this many seconds, then print a one line JSON
+	// report (see Report) to stdout and exit.  0 starts the UI.
+	ReportSeconds int
+	// Proxy URL and no-proxy list for the firehose connections, empty uses
+	// the proxy environment variables (see config.SetProxy)
+	Proxy   string
+	NoProxy string
+	// Skip firehose TLS certificate verification even if the cf CLI target
+	// verifies, and PEM file of CA certs trusted in addition to the system roots
+	SkipSSLValidation bool
+	CaBundleFile      string
+	// Number of apps ranked in the top talkers view
+	TopTalkersCount int
+	// Health score below which app list rows are tinted yellow / red
+	HealthScoreWarn int
+	HealthScoreBad  int
+	// Health score penalties: per crash in the last hour, for all desired
+	// instances not reporting and for all HTTP responses being 5xx
+	HealthCrashPenalty   int
+	HealthMissingPenalty int
+	Health5xxPenalty     int
 }
 
 // NewClient instantiating the top client
@@ -85,31 +205,267 @@ func (c *Client) Ask(prompt string) string {
 // Start starting the client
 func (c *Client) Start() {
 
-	if !c.options.NoTopCheck && c.shouldExitTop() {
+	// A report run is unattended (e.g., cron) so it never prompts
+	report := c.options.ReportSeconds > 0
+
+	if !c.options.NoTopCheck && !report && c.shouldExitTop() {
 		// There are other instances of top running and user requested to exit
 		return
 	}
 
-	toplog.SetDebugEnabled(c.options.Debug)
+	// API trace lines are logged at debug level
+	toplog.SetDebugEnabled(c.options.Debug || c.options.ApiTrace)
+	common.SetApiTrace(c.options.ApiTrace, c.options.ApiTraceVerbose)
+	config.SetKioskMode(c.options.Kiosk)
+	config.SetEventQueueCapacity(c.options.EventQueueSize)
+	config.SetEventWorkers(c.options.EventWorkers)
+	config.SetDeferRouteMetadata(c.options.QuietStart)
+	config.SetCrashFilterMinutes(c.options.CrashFilterMinutes)
+	config.SetIdleTimeoutMinutes(c.options.IdleTimeoutMinutes)
+	config.SetIdlePauseIngest(c.options.IdlePauseIngest)
+	config.SetMemoryRiskPercent(c.options.MemoryRiskPercent)
+	config.SetRecordFileName(c.options.RecordFileName)
+	config.SetRecordAnsi(!c.options.RecordNoAnsi)
+	config.SetCpuPrecision(c.options.CpuPrecision)
+	config.SetCpuPerCore(c.options.CpuPerCore)
+	toplog.SetTestMessagesEnabled(c.options.TestMessages && !c.options.Kiosk)
+	toplog.LoadLogPreferences()
+	if c.options.LogWrap {
+		toplog.SetWrapEnabled(true)
+	}
+	if c.options.LogShortTime {
+		toplog.SetShortTimestampsEnabled(true)
+	}
+	toplog.SetAutoScrollResumeSeconds(c.options.LogScrollResumeSeconds)
+	config.SetEventTypes(c.options.EventTypes)
+	config.SetMetadataWarnMinutes(c.options.MetadataWarnMinutes)
+	config.SetRecentDeployMinutes(c.options.RecentDeployMinutes)
+	config.SetRefreshBudgetMS(c.options.RefreshBudgetMS)
+	config.SetRequestHistoryMinutes(c.options.RequestHistoryMinutes)
+	config.SetFirehoseIdleTimeoutSeconds(c.options.FirehoseIdleSeconds)
+	config.SetHighlightTrackMode(c.options.HighlightTrack)
+	config.SetTopTalkersCount(c.options.TopTalkersCount)
+	config.SetHealthScoreThresholds(c.options.HealthScoreWarn, c.options.HealthScoreBad)
+	config.SetHealthPenalties(c.options.HealthCrashPenalty, c.options.HealthMissingPenalty, c.options.Health5xxPenalty)
+	if err := config.SetProxy(c.options.Proxy, c.options.NoProxy); err != nil {
+		c.ui.Failed("proxy: " + err.Error())
+		return
+	}
+	if c.options.Proxy != "" {
+		// cf curl runs in the cf CLI process so the proxy option can't be applied to it
+		toplog.Info("Firehose connections use proxy %v.  CC API requests are made by the cf CLI which uses the HTTPS_PROXY / NO_PROXY environment variables.",
+			config.ProxyDescription())
+	}
 
 	conn := c.cliConnection
 
+	// A replay does not connect to the firehose.  Metadata (app, space and
+	// org names) is still loaded from the targeted foundation if logged in.
+	replay := c.options.ReplayFile != ""
+
+	if conn == nil {
+		c.ui.Failed("No cf CLI connection available - run top as a cf CLI plugin: cf top")
+		return
+	}
+
+	if replay {
+		if _, err := capture.Validate(c.options.ReplayFile); err != nil {
+			c.ui.Failed("Unable to replay capture file: %v", err)
+			return
+		}
+	}
+
 	isLoggedIn, err := conn.IsLoggedIn()
 	if err != nil {
 		c.ui.Failed(err.Error())
 		return
 	}
 	if !isLoggedIn {
-		c.ui.Failed("Must login first")
+		if !replay {
+			c.ui.Failed("Must login first")
+			return
+		}
+		c.ui.Warn("Not logged in - replay will show GUIDs instead of app, space and org names")
+	}
+
+	// Selected before the access check so the check probes an endpoint of
+	// the version that will be used
+	common.ConfigureApiVersion(conn, c.options.ApiVersion)
+	if isLoggedIn {
+		if err := c.checkApiAccess(); err != nil {
+			if !replay {
+				c.ui.Failed("%v", err)
+				return
+			}
+			c.ui.Warn("%v - replay will show GUIDs instead of app, space and org names", err)
+		}
+	}
+
+	if !report {
+		fmt.Printf("Loading...")
+	}
+
+	privileged := true
+	if !replay {
+		var ok bool
+		if privileged, ok = c.checkPrivileges(); !ok {
+			return
+		}
+	}
+
+	common.SetEndpointConfig(&common.EndpointConfig{
+		AppsPath:             c.options.AppsPath,
+		RoutesPath:           c.options.RoutesPath,
+		ResultsPerPage:       c.options.ResultsPerPage,
+		ExtraQuery:           c.options.ApiQuery,
+		InlineRelationsDepth: c.options.InlineRelationsDepth,
+	})
+
+	scopeOrgGuid, scopeSpaceGuid := "", ""
+	if c.options.Scope != "" {
+		scopeOrgGuid, scopeSpaceGuid, err = c.findScope(c.options.Scope)
+		if err != nil {
+			c.ui.Failed(err.Error())
+			return
+		}
+	} else if privileged && !replay && !report {
+		scopeOrgGuid, scopeSpaceGuid = c.checkFoundationSize()
+	}
+	// Only the metadata of the scope is loaded (the metadata thread starts
+	// loading with the UI)
+	common.SetLoadScope(scopeOrgGuid, scopeSpaceGuid)
+
+	ui := ui.NewMasterUI(conn, c.pluginMetadata, privileged)
+	c.router = ui.GetRouter()
+
+	// Shutdown steps run in this order, the UI registers restoring the terminal last
+	shutdown.Register("metadata", c.router.GetProcessor().GetMetadataManager().Stop)
+	shutdown.Register("firehose", c.closeNozzles)
+	ui.SetInitialScope(scopeOrgGuid, scopeSpaceGuid)
+	ui.SetStartView(c.options.StartView)
+
+	toplog.Info("Top started at " + time.Now().Format("01-02-2006 15:04:05"))
+
+	if replay {
+		go c.replayEvents()
+		if report {
+			c.runReport(nil, scopeOrgGuid, scopeSpaceGuid)
+			return
+		}
+		fmt.Printf("\r           \r")
+		ui.Start(nil)
 		return
 	}
 
-	fmt.Printf("Loading...")
+	c.tlsConfig, err = c.firehoseTLSConfig()
+	if err != nil {
+		c.ui.Failed("Firehose TLS setup failed: %v", err)
+		return
+	}
+
+	if c.options.CaptureFile != "" {
+		captureWriter, err := capture.NewWriter(c.options.CaptureFile)
+		if err != nil {
+			c.ui.Failed("Unable to create capture file: %v", err)
+			return
+		}
+		c.captureWriter = captureWriter
+		// Registered after "firehose" so all received envelopes are flushed
+		shutdown.Register("capture", c.closeCapture)
+		toplog.InfoC(toplog.FirehoseCategory, "Capturing firehose envelopes to file: %v", captureWriter.FileName())
+	}
+
+	monitoredAppGuids, err := c.setupFirehoseConnections(privileged)
+	if err != nil {
+		return
+	}
+	if report {
+		c.runReport(monitoredAppGuids, scopeOrgGuid, scopeSpaceGuid)
+		return
+	}
+
+	// Clear the 'Loading...' message from screen
+	fmt.Printf("\r           \r")
+
+	ui.Start(monitoredAppGuids)
+}
 
+// runReport collects stats for ClientOptions.ReportSeconds without opening
+// the UI, runs the shutdown steps and prints the Report as a single JSON
+// line to stdout.  Metadata loads in the background so on a large
+// foundation a short run may report GUIDs instead of app names.
+func (c *Client) runReport(monitoredAppGuids map[string]bool, scopeOrgGuid, scopeSpaceGuid string) {
+	processor := c.router.GetProcessor()
+	processor.Start()
+	time.Sleep(time.Duration(c.options.ReportSeconds) * time.Second)
+
+	processor.UpdateData()
+	summaries := dataCommon.GetAppSummaries(processor, c.router.GetStartTime(), monitoredAppGuids, scopeOrgGuid, scopeSpaceGuid)
+	report := NewReport(c.router, c.options.ReportSeconds, summaries)
+	shutdown.Run()
+
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		c.ui.Failed("Unable to create report: %v", err)
+		return
+	}
+	fmt.Println(string(reportJSON))
+}
+
+// checkApiAccess verifies the CC API can be reached and accepts the current
+// token before anything is loaded.  Without this check the metadata loaders
+// only log their errors and top starts with empty caches.
+func (c *Client) checkApiAccess() error {
+	conn := c.cliConnection
+	apiEndpoint, err := conn.ApiEndpoint()
+	if err != nil || apiEndpoint == "" {
+		return fmt.Errorf("No API endpoint targeted - use 'cf api' and 'cf login' first")
+	}
+	if _, err := conn.AccessToken(); err != nil {
+		return fmt.Errorf("Unable to get an access token for %v - use 'cf login' to log in again\n%v", apiEndpoint, err)
+	}
+
+	// Any authenticated request of the selected API version will do, ask
+	// for as little as possible
+	url := "/v2/organizations?results-per-page=1"
+	if common.IsV3Api() {
+		url = "/v3/organizations?per_page=1"
+	}
+	output, err := common.CallAPI(conn, url)
+	if err != nil {
+		return fmt.Errorf("Unable to reach the Cloud Controller API at %v\n%v", apiEndpoint, err)
+	}
+	jsonParsed, err := gabs.ParseJSON([]byte(output))
+	if err != nil {
+		return fmt.Errorf("Unexpected response from the Cloud Controller API at %v: %v", apiEndpoint, output)
+	}
+	if errorCode, ok := jsonParsed.Path("error_code").Data().(string); ok {
+		description, _ := jsonParsed.Path("description").Data().(string)
+		if errorCode == "CF-InvalidAuthToken" {
+			return fmt.Errorf("Access token for %v is not valid - use 'cf login' to log in again (%v)", apiEndpoint, description)
+		}
+		return fmt.Errorf("Cloud Controller API at %v returned %v: %v", apiEndpoint, errorCode, description)
+	}
+	// v3 reports errors as a list of code, title (CF-...) and detail
+	if errorList, ok := jsonParsed.Path("errors").Data().([]interface{}); ok && len(errorList) > 0 {
+		v3Error, _ := errorList[0].(map[string]interface{})
+		title, _ := v3Error["title"].(string)
+		detail, _ := v3Error["detail"].(string)
+		if title == "CF-InvalidAuthToken" {
+			return fmt.Errorf("Access token for %v is not valid - use 'cf login' to log in again (%v)", apiEndpoint, detail)
+		}
+		return fmt.Errorf("Cloud Controller API at %v returned %v: %v", apiEndpoint, title, detail)
+	}
+	return nil
+}
+
+// checkPrivileges returns if the user has both the scopes needed to run
+// privileged and false for ok if the scopes could not be determined
+func (c *Client) checkPrivileges() (privileged bool, ok bool) {
 	scopes, err := c.getUserScopes()
 	if err != nil {
 		c.ui.Failed("Could not determine privileges. Are you logged in?\n%v", err)
-		return
+		return false, false
 	}
 
 	hasCCAdminScope := c.hasCloudControllerAdminScope(scopes)
@@ -126,22 +482,27 @@ func (c *Client) Start() {
 		c.ui.Warn("See: https://github.com/ECSTeam/cloudfoundry-top-plugin#assign-scope-if-privileged-mode-is-needed\n")
 	}
 
-	privileged := hasCCAdminScope && hasFirehoseScope
-
-	ui := ui.NewMasterUI(conn, c.pluginMetadata, privileged)
-	c.router = ui.GetRouter()
-
-	toplog.Info("Top started at " + time.Now().Format("01-02-2006 15:04:05"))
+	return hasCCAdminScope && hasFirehoseScope, true
+}
 
-	monitoredAppGuids, err := c.setupFirehoseConnections(privileged)
+// replayEvents routes the envelopes of the replay file as if they were
+// received from a single nozzle
+func (c *Client) replayEvents() {
+	fileName := c.options.ReplayFile
+	toplog.InfoC(toplog.FirehoseCategory, "Replaying firehose envelopes from file: %v", fileName)
+	count, err := capture.Replay(fileName, !c.options.ReplayFast, func(envelope *events.Envelope) {
+		c.router.Route(0, envelope)
+	})
 	if err != nil {
+		toplog.ErrorC(toplog.FirehoseCategory, "Replay stopped after %v envelopes: %v", count, err)
 		return
 	}
+	toplog.InfoC(toplog.FirehoseCategory, "Replay complete - %v envelopes", count)
+}
 
-	// Clear the 'Loading...' message from screen
-	fmt.Printf("\r           \r")
-
-	ui.Start(monitoredAppGuids)
+func (c *Client) closeCapture() error {
+	toplog.DebugC(toplog.FirehoseCategory, "Closing capture file, %v envelopes written", c.captureWriter.Count())
+	return c.captureWriter.Close()
 }
 
 // setupFirehoseConnections starts nozzle(s) aysnc and return if user is privileged
@@ -149,12 +510,19 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 
 	// If user has correct privileges, use the "firehose" API
 	if privileged {
-		toplog.Info("Running with doppler.firehose privileges - opening %v nozzles", c.options.Nozzles)
-		subscriptionID := "TopPlugin_" + util.Pseudo_uuid()
+		toplog.InfoC(toplog.FirehoseCategory, "Running with doppler.firehose privileges - opening %v nozzles", c.options.Nozzles)
+		subscriptionID := c.options.SubscriptionID
+		if subscriptionID == "" {
+			subscriptionID = "TopPlugin_" + util.Pseudo_uuid()
+		}
+		toplog.InfoC(toplog.FirehoseCategory, "Using firehose subscription id: %v", subscriptionID)
 		for i := 0; i < c.options.Nozzles; i++ {
 			go c.createAndKeepAliveNozzle(subscriptionID, "", i)
 		}
-		toplog.Info("Starting %v firehose nozzle instances", c.options.Nozzles)
+		toplog.InfoC(toplog.FirehoseCategory, "Starting %v firehose nozzle instances", c.options.Nozzles)
+		if config.FirehoseIdleTimeoutSeconds() > 0 {
+			go c.firehoseIdleWatchdog(time.Duration(config.FirehoseIdleTimeoutSeconds()) * time.Second)
+		}
 		return nil, nil
 	}
 
@@ -183,7 +551,7 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 	if nozzlesToOpen > MAX_APPS_TO_MONITOR {
 		nozzlesToOpen = MAX_APPS_TO_MONITOR
 	}
-	toplog.Info("Running without doppler.firehose scope - opening %v nozzles", nozzlesToOpen)
+	toplog.InfoC(toplog.FirehoseCategory, "Running without doppler.firehose scope - opening %v nozzles", nozzlesToOpen)
 	monitoredAppGuids := make(map[string]bool)
 	for i, application := range apps {
 		if i >= MAX_APPS_TO_MONITOR {
@@ -191,7 +559,7 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 			fmt.Printf("\rMax of %v apps to monitor was reached.  Some apps will not be monitored.\n", MAX_APPS_TO_MONITOR)
 			break
 		}
-		toplog.Info("Starting app nozzle #%v instance for App %s", i, application.Name)
+		toplog.InfoC(toplog.FirehoseCategory, "Starting app nozzle #%v instance for App %s", i, application.Name)
 		go c.createAndKeepAliveNozzle("", application.Guid, i)
 		monitoredAppGuids[application.Guid] = true
 		// TODO: Need to come up with a way for user to specify (or select on UI) which apps will be monitored
@@ -204,7 +572,7 @@ func (c *Client) setupFirehoseConnections(privileged bool) (map[string]bool, err
 func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instanceID int) error {
 	minRetrySeconds := (2 * time.Second)
 
-	for {
+	for !c.isStopping() {
 		// This is a blocking call if no error
 		startTime := time.Now()
 		var err error
@@ -217,26 +585,29 @@ func (c *Client) createAndKeepAliveNozzle(subscriptionID, appGUID string, instan
 			errMsg := err.Error()
 			notAuthorized := strings.Contains(errMsg, "authorized")
 			if websocket.IsCloseError(err, websocket.CloseNormalClosure) || notAuthorized {
-				toplog.Error("Nozzle #%v - Stopped with error: %v", instanceID, err)
+				toplog.ErrorC(toplog.FirehoseCategory, "Nozzle #%v - Stopped with error: %v", instanceID, err)
 				if notAuthorized {
-					toplog.Error("Are you sure you have 'admin' privileges on foundation?")
-					toplog.Error("See needed permissions for this plugin here:")
-					toplog.Error("https://github.com/ECSTeam/cloudfoundry-top-plugin")
+					toplog.ErrorC(toplog.FirehoseCategory, "Are you sure you have 'admin' privileges on foundation?")
+					toplog.ErrorC(toplog.FirehoseCategory, "See needed permissions for this plugin here:")
+					toplog.ErrorC(toplog.FirehoseCategory, "https://github.com/ECSTeam/cloudfoundry-top-plugin")
 				}
 				break
 			}
-			toplog.Warn("Nozzle #%v - error: %v", instanceID, err)
+			if c.isStopping() {
+				break
+			}
+			toplog.WarnC(toplog.FirehoseCategory, "Nozzle #%v - error: %v", instanceID, err)
 			/*
 				authorizationExpired := strings.Contains(errMsg, "auth request failed")
 				if authorizationExpired {
-					toplog.Error("Login authorization no longer valid.  Exit top and re-login")
+					toplog.ErrorC(toplog.FirehoseCategory, "Login authorization no longer valid.  Exit top and re-login")
 				}
 			*/
 		}
-		toplog.Warn("Nozzle #%v - Shutdown. Nozzle instance will be restarted", instanceID)
+		toplog.WarnC(toplog.FirehoseCategory, "Nozzle #%v - Shutdown. Nozzle instance will be restarted", instanceID)
 		lastRetry := time.Now().Sub(startTime)
 		if lastRetry < minRetrySeconds {
-			toplog.Info("Nozzle #%v - Nozzle instance restart too fast, delaying for %v", instanceID, minRetrySeconds)
+			toplog.InfoC(toplog.FirehoseCategory, "Nozzle #%v - Nozzle instance restart too fast, delaying for %v", instanceID, minRetrySeconds)
 			time.Sleep(minRetrySeconds)
 		}
 	}
@@ -255,15 +626,12 @@ func (c *Client) createNozzle(subscriptionID string, instanceID int) error {
 		return err
 	}
 
-	skipVerifySSL, err := conn.IsSSLDisabled()
-	if err != nil {
-		return err
-	}
-
-	dopplerConnection := consumer.New(dopplerEndpoint, &tls.Config{InsecureSkipVerify: skipVerifySSL}, nil)
+	dopplerConnection := consumer.New(dopplerEndpoint, c.tlsConfig, config.ProxyFunc())
 
 	tokenRefresher := NewTokenRefresher(conn, instanceID)
 	dopplerConnection.RefreshTokenFrom(tokenRefresher)
+	c.trackConsumer(dopplerConnection)
+	defer c.untrackConsumer(dopplerConnection)
 	dopplerConnection.SetIdleTimeout(15 * time.Second)
 
 	authToken, err := conn.AccessToken()
@@ -274,7 +642,7 @@ func (c *Client) createNozzle(subscriptionID string, instanceID int) error {
 	messages, errors := dopplerConnection.FirehoseWithoutReconnect(subscriptionID, authToken)
 	defer dopplerConnection.Close()
 
-	toplog.Info("Nozzle #%v - Started", instanceID)
+	toplog.InfoC(toplog.FirehoseCategory, "Nozzle #%v - Started", instanceID)
 
 	eventError := c.routeEvents(instanceID, messages, errors)
 	if eventError != nil {
@@ -299,15 +667,12 @@ func (c *Client) createAppNozzle(appGUID string, instanceID int) error {
 		return err
 	}
 
-	skipVerifySSL, err := conn.IsSSLDisabled()
-	if err != nil {
-		return err
-	}
-
-	dopplerConnection := consumer.New(dopplerEndpoint, &tls.Config{InsecureSkipVerify: skipVerifySSL}, nil)
+	dopplerConnection := consumer.New(dopplerEndpoint, c.tlsConfig, config.ProxyFunc())
 
 	tokenRefresher := NewTokenRefresher(conn, instanceID)
 	dopplerConnection.RefreshTokenFrom(tokenRefresher)
+	c.trackConsumer(dopplerConnection)
+	defer c.untrackConsumer(dopplerConnection)
 	// TODO: We need to timeout or do a keepalive to deal with severed connectons
 	// however if we open a stream on a stopped app we never get any events which
 	// causes a timeout error which is not what we want.
@@ -321,7 +686,7 @@ func (c *Client) createAppNozzle(appGUID string, instanceID int) error {
 	messages, errors := dopplerConnection.StreamWithoutReconnect(appGUID, authToken)
 	defer dopplerConnection.Close()
 
-	toplog.Info("Nozzle #%v for %s - Started", instanceID, appGUID)
+	toplog.InfoC(toplog.FirehoseCategory, "Nozzle #%v for %s - Started", instanceID, appGUID)
 
 	eventError := c.routeEvents(instanceID, messages, errors)
 	if eventError != nil {
@@ -333,12 +698,107 @@ func (c *Client) createAppNozzle(appGUID string, instanceID int) error {
 	return nil
 }
 
+func (c *Client) trackConsumer(dopplerConnection *consumer.Consumer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.consumers == nil {
+		c.consumers = make(map[*consumer.Consumer]bool)
+	}
+	c.consumers[dopplerConnection] = true
+}
+
+func (c *Client) untrackConsumer(dopplerConnection *consumer.Consumer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.consumers, dopplerConnection)
+}
+
+func (c *Client) isStopping() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopping
+}
+
+// Shutdown step: stop restarting nozzles and close all open doppler connections
+func (c *Client) closeNozzles() error {
+	c.mu.Lock()
+	c.stopping = true
+	c.mu.Unlock()
+	c.closeConsumers()
+	return nil
+}
+
+// closeConsumers closes all open doppler connections.  Unless top is
+// stopping, createAndKeepAliveNozzle opens a new connection for each
+func (c *Client) closeConsumers() {
+	c.mu.Lock()
+	consumers := make([]*consumer.Consumer, 0, len(c.consumers))
+	for dopplerConnection := range c.consumers {
+		consumers = append(consumers, dopplerConnection)
+	}
+	c.mu.Unlock()
+
+	toplog.DebugC(toplog.FirehoseCategory, "Closing %v nozzle connection(s)", len(consumers))
+	for _, dopplerConnection := range consumers {
+		if err := dopplerConnection.Close(); err != nil {
+			toplog.DebugC(toplog.FirehoseCategory, "Nozzle connection close error: %v", err)
+		}
+	}
+}
+
+// firehoseIdleWatchdog reconnects all firehose nozzles when no envelope at
+// all has been received for the given timeout.  The doppler read deadline
+// does not catch a half-open connection that keeps answering pings but
+// never delivers data.  Not used for app streams as a stopped app
+// legitimately sends nothing.
+func (c *Client) firehoseIdleWatchdog(timeout time.Duration) {
+	checkInterval := timeout / 4
+	if checkInterval < time.Second {
+		checkInterval = time.Second
+	}
+	processor := c.router.GetProcessor()
+	// Start of the current quiet period: startup, last reconnect or ingest resume
+	quietSince := clock.Now()
+	for !c.isStopping() {
+		time.Sleep(checkInterval)
+		if c.router.IsIngestPaused() {
+			// Events are discarded before they are counted while paused
+			quietSince = clock.Now()
+			continue
+		}
+		lastEventTime := processor.GetLastEventTime()
+		if lastEventTime.After(quietSince) {
+			quietSince = lastEventTime
+		}
+		idle := clock.Since(quietSince)
+		if idle < timeout || c.isStopping() {
+			continue
+		}
+		toplog.WarnC(toplog.FirehoseCategory, "No firehose events received for %v - reconnecting nozzles", util.FormatDuration(idle))
+		c.closeConsumers()
+		quietSince = clock.Now()
+	}
+}
+
 func (c *Client) routeEvents(instanceID int, messages <-chan *events.Envelope, errors <-chan error) error {
 	for {
 		select {
-		case envelope := <-messages:
+		case envelope, ok := <-messages:
+			if !ok {
+				return fmt.Errorf("Nozzle #%v - message channel closed", instanceID)
+			}
+			if c.captureWriter != nil {
+				if err := c.captureWriter.Write(envelope); err != nil {
+					c.captureErrorOnce.Do(func() {
+						toplog.ErrorC(toplog.FirehoseCategory, "Capture to file stopped: %v", err)
+					})
+				}
+			}
 			c.router.Route(instanceID, envelope)
-		case err := <-errors:
+		case err, ok := <-errors:
+			if !ok {
+				return fmt.Errorf("Nozzle #%v - error channel closed", instanceID)
+			}
 			c.handleError(instanceID, err)
 			// Nozzle connection does not seem to recover from errors well, so
 			// return here so it can be closed and a new instanced opened
@@ -362,6 +822,77 @@ func (c *Client) handleError(instanceID int, err error) {
 	}
 }
 
+// checkFoundationSize warns if the foundation has a very large number of apps
+// and optionally prompts for an org/space scope to apply before starting.
+// Returns the org and space guids of the scope (empty if none selected)
+func (c *Client) checkFoundationSize() (orgGuid, spaceGuid string) {
+	threshold := c.options.LargeFoundationAppCount
+	if threshold <= 0 {
+		return "", ""
+	}
+	appCount, err := app.GetTotalAppCount(c.cliConnection)
+	if err != nil {
+		toplog.Warn("Unable to determine foundation app count: %v", err)
+		return "", ""
+	}
+	if appCount <= threshold {
+		return "", ""
+	}
+	toplog.Warn("Foundation has %v apps (more then %v).  Metadata load and event volume may be slow", appCount, threshold)
+	if c.options.NoScopePrompt || c.options.Kiosk {
+		return "", ""
+	}
+
+	fmt.Printf("\nThis foundation has %v apps which may take a while to load and display.\n", appCount)
+	for {
+		orgName := c.Ask("Enter an org name to scope the display to (or blank for all orgs):")
+		if orgName == "" {
+			return "", ""
+		}
+		orgModel, err := c.cliConnection.GetOrg(orgName)
+		if err != nil || orgModel.Guid == "" {
+			fmt.Printf("Org '%v' not found\n", orgName)
+			continue
+		}
+		spaceName := c.Ask("Enter a space name within the org (or blank for entire org):")
+		if spaceName == "" {
+			toplog.Info("Scope set to org: %v", orgModel.Name)
+			return orgModel.Guid, ""
+		}
+		for _, s := range orgModel.Spaces {
+			if strings.EqualFold(s.Name, spaceName) {
+				toplog.Info("Scope set to org: %v space: %v", orgModel.Name, s.Name)
+				return orgModel.Guid, s.Guid
+			}
+		}
+		fmt.Printf("Space '%v' not found in org '%v'\n", spaceName, orgModel.Name)
+	}
+}
+
+// findScope returns the org and space guids of a scope given as "org" or
+// "org/space" names (see -scope)
+func (c *Client) findScope(scope string) (orgGuid, spaceGuid string, err error) {
+	orgName, spaceName := scope, ""
+	if i := strings.Index(scope, "/"); i >= 0 {
+		orgName, spaceName = scope[:i], scope[i+1:]
+	}
+	orgModel, err := c.cliConnection.GetOrg(orgName)
+	if err != nil || orgModel.Guid == "" {
+		return "", "", fmt.Errorf("Scope org '%v' not found", orgName)
+	}
+	if spaceName == "" {
+		toplog.Info("Scope set to org: %v", orgModel.Name)
+		return orgModel.Guid, "", nil
+	}
+	for _, s := range orgModel.Spaces {
+		if strings.EqualFold(s.Name, spaceName) {
+			toplog.Info("Scope set to org: %v space: %v", orgModel.Name, s.Name)
+			return orgModel.Guid, s.Guid, nil
+		}
+	}
+	return "", "", fmt.Errorf("Scope space '%v' not found in org '%v'", spaceName, orgModel.Name)
+}
+
 func (c *Client) shouldExitTop() bool {
 	numRunning := c.getNumberOfTopPluginsRunning() - 1
 	if numRunning > 0 {
@@ -0,0 +1,101 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+)
+
+// One line machine readable summary printed on exit when running with
+// ClientOptions.ReportSeconds (no UI)
+type Report struct {
+	Seconds       int     `json:"seconds"`
+	Events        uint64  `json:"events"`
+	EventsPerSec  float64 `json:"events_per_sec"`
+	DroppedEvents uint64  `json:"dropped_events"`
+	// Counts of top's own log messages by level (see toplog.GetMsgDeltas)
+	LogDebug int `json:"log_debug"`
+	LogInfo  int `json:"log_info"`
+	LogWarn  int `json:"log_warn"`
+	LogError int `json:"log_error"`
+
+	Apps         int        `json:"apps"`
+	Crash1hCount int        `json:"crash_1h_count"`
+	Http5xxCount int64      `json:"http_5xx_count"`
+	WorstApp     *ReportApp `json:"worst_app,omitempty"`
+	TopCpuApp    *ReportApp `json:"top_cpu_app,omitempty"`
+}
+
+// The app fields included in the report
+type ReportApp struct {
+	AppName            string  `json:"app_name"`
+	SpaceName          string  `json:"space_name"`
+	OrgName            string  `json:"org_name"`
+	HealthScore        int     `json:"health_score"`
+	TotalCpuPercentage float64 `json:"cpu_percentage"`
+}
+
+func newReportApp(summary *dataCommon.AppSummary) *ReportApp {
+	return &ReportApp{
+		AppName:            summary.AppName,
+		SpaceName:          summary.SpaceName,
+		OrgName:            summary.OrgName,
+		HealthScore:        summary.HealthScore,
+		TotalCpuPercentage: summary.TotalCpuPercentage,
+	}
+}
+
+// Firehose event counts of the report, satisfied by *eventrouting.EventRouter
+type eventCounter interface {
+	GetEventCount() uint64
+	GetDroppedCount() uint64
+}
+
+func NewReport(router eventCounter, seconds int, summaries []*dataCommon.AppSummary) *Report {
+	report := &Report{
+		Seconds:       seconds,
+		Events:        router.GetEventCount(),
+		DroppedEvents: router.GetDroppedCount(),
+		Apps:          len(summaries),
+	}
+	if seconds > 0 {
+		report.EventsPerSec = float64(report.Events) / float64(seconds)
+	}
+	// The log window is never opened so the deltas count all messages
+	report.LogDebug, report.LogInfo, report.LogWarn, report.LogError = toplog.GetMsgDeltas()
+
+	var worst, topCpu *dataCommon.AppSummary
+	for _, summary := range summaries {
+		report.Crash1hCount += summary.Crash1hCount
+		report.Http5xxCount += summary.Http5xxCount
+		// Lowest health score, most crashes when tied
+		if worst == nil || summary.HealthScore < worst.HealthScore ||
+			(summary.HealthScore == worst.HealthScore && summary.Crash1hCount > worst.Crash1hCount) {
+			worst = summary
+		}
+		if topCpu == nil || summary.TotalCpuPercentage > topCpu.TotalCpuPercentage {
+			topCpu = summary
+		}
+	}
+	if worst != nil {
+		report.WorstApp = newReportApp(worst)
+	}
+	if topCpu != nil {
+		report.TopCpuApp = newReportApp(topCpu)
+	}
+	return report
+}
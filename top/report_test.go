@@ -0,0 +1,71 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top_test
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/top"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fakeEventCounter struct {
+	events  uint64
+	dropped uint64
+}
+
+func (f *fakeEventCounter) GetEventCount() uint64 {
+	return f.events
+}
+
+func (f *fakeEventCounter) GetDroppedCount() uint64 {
+	return f.dropped
+}
+
+var _ = Describe("Report", func() {
+
+	counter := &fakeEventCounter{events: 1000, dropped: 3}
+
+	It("totals the apps and picks the worst and top CPU apps", func() {
+		summaries := []*dataCommon.AppSummary{
+			{AppName: "api", SpaceName: "dev", OrgName: "acme", HealthScore: 70, Crash1hCount: 1, Http5xxCount: 4, TotalCpuPercentage: 12.5},
+			{AppName: "web", SpaceName: "dev", OrgName: "acme", HealthScore: 70, Crash1hCount: 2, Http5xxCount: 1, TotalCpuPercentage: 80},
+			{AppName: "worker", SpaceName: "prod", OrgName: "acme", HealthScore: 100, TotalCpuPercentage: 3},
+		}
+		report := top.NewReport(counter, 10, summaries)
+		Expect(report.Seconds).To(Equal(10))
+		Expect(report.Events).To(Equal(uint64(1000)))
+		Expect(report.DroppedEvents).To(Equal(uint64(3)))
+		Expect(report.EventsPerSec).To(Equal(100.0))
+		Expect(report.Apps).To(Equal(3))
+		Expect(report.Crash1hCount).To(Equal(3))
+		Expect(report.Http5xxCount).To(Equal(int64(5)))
+		// Health scores tie, the app with more crashes is worse
+		Expect(report.WorstApp.AppName).To(Equal("web"))
+		Expect(report.WorstApp.HealthScore).To(Equal(70))
+		Expect(report.TopCpuApp.AppName).To(Equal("web"))
+		Expect(report.TopCpuApp.TotalCpuPercentage).To(Equal(80.0))
+	})
+
+	It("has no apps or rate when empty", func() {
+		report := top.NewReport(counter, 0, nil)
+		Expect(report.Apps).To(Equal(0))
+		Expect(report.EventsPerSec).To(Equal(0.0))
+		Expect(report.WorstApp).To(BeNil())
+		Expect(report.TopCpuApp).To(BeNil())
+	})
+
+})
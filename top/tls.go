@@ -0,0 +1,66 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package top
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+// firehoseTLSConfig builds the TLS config shared by the doppler connections.
+// Certificate verification is skipped when the cf CLI target was set with
+// --skip-ssl-validation or with ClientOptions.SkipSSLValidation.  Certs in
+// ClientOptions.CaBundleFile are trusted in addition to the system roots.
+func (c *Client) firehoseTLSConfig() (*tls.Config, error) {
+	sslDisabled, err := c.cliConnection.IsSSLDisabled()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: sslDisabled || c.options.SkipSSLValidation}
+
+	if c.options.CaBundleFile != "" {
+		pemData, err := ioutil.ReadFile(c.options.CaBundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA bundle: %v", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			// System pool is not available on all platforms (e.g., windows)
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("no PEM certificates found in CA bundle %v", c.options.CaBundleFile)
+		}
+		tlsConfig.RootCAs = rootCAs
+		toplog.InfoC(toplog.FirehoseCategory, "Firehose connections trust the certificates in %v.  "+
+			"CC API requests are made by the cf CLI, set SSL_CERT_FILE when running cf for it to trust them too.", c.options.CaBundleFile)
+	}
+
+	if c.options.SkipSSLValidation {
+		toplog.WarnC(toplog.FirehoseCategory, "*** TLS certificate verification is DISABLED for firehose connections (-skip-ssl-validation) ***  "+
+			"Connections are open to man-in-the-middle attacks, only use this on dev / test foundations.")
+		if !sslDisabled {
+			toplog.WarnC(toplog.FirehoseCategory, "CC API requests are made by the cf CLI which still verifies certificates, "+
+				"use 'cf api <url> --skip-ssl-validation' to skip verification for them too.")
+		}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toplog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/atotto/clipboard"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/jroimartin/gocui"
+)
+
+// Number of error / warn lines kept for the errors view.  This is separate
+// from the main log buffer so a flood of debug / info messages does not
+// evict the errors.
+const MAX_ERROR_LINES = 500
+
+const ErrorWindowHeaderText = "Top Errors View"
+const ErrorWindowHeaderHelpText = WHITE + BRIGHT + "ENTER" + WHITE + DIM + ":close  " +
+	WHITE + BRIGHT + "UP" + WHITE + DIM + "/" + WHITE + BRIGHT + "DOWN" + WHITE + DIM + " arrow to scroll  " +
+	WHITE + BRIGHT + "w" + WHITE + DIM + ":include warnings toggle  " +
+	WHITE + BRIGHT + "c" + WHITE + DIM + ":copy"
+
+var (
+	// Error and warn level lines for the session (bounded by MAX_ERROR_LINES)
+	errorLines  []*LogLine
+	errorWidget *ErrorLogWidget
+)
+
+// Caller must hold the mutex
+func addErrorLine(logLine *LogLine) {
+	errorLines = append(errorLines, logLine)
+	if len(errorLines) > MAX_ERROR_LINES {
+		errorLines = errorLines[1:]
+	}
+}
+
+type ErrorLogWidget struct {
+	masterUI MasterUIInterface
+	name     string
+	height   int
+	// -1 means keep the view scrolled to the newest line
+	viewOffset   int
+	includeWarns bool
+}
+
+// Open the errors view.  Shows error (and optionally warn) lines logged
+// during the session.
+func OpenErrorView() {
+	if gui == nil || debugWidget == nil {
+		// InitDebug not called yet
+		return
+	}
+	safeExecute(func(g *gocui.Gui) error {
+		if errorWidget == nil {
+			errorWidget = &ErrorLogWidget{masterUI: debugWidget.masterUI, name: "errorLogView", viewOffset: -1, includeWarns: true}
+		}
+		layoutMgr := errorWidget.masterUI.LayoutManager()
+		if layoutMgr.Top() != errorWidget {
+			layoutMgr.Add(errorWidget)
+		}
+		return errorWidget.Layout(g)
+	})
+}
+
+func (w *ErrorLogWidget) Name() string {
+	return w.name
+}
+
+func (w *ErrorLogWidget) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	left, top, right, bottom := 5, 3, maxX-5, maxY-2
+	if right <= left {
+		right = left + 1
+	}
+	if bottom <= top {
+		bottom = top + 1
+	}
+	w.height = bottom - top - 1
+	v, err := g.SetView(w.name, left, top, right, bottom)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return errors.New(w.name + " layout error:" + err.Error())
+		}
+		v.Frame = true
+		v.Wrap = false
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.closeAction, "close"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeAction, "close"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeAction, "close"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp, "scroll up"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown, "scroll down"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp, "page up"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown, "page down"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, 'w', gocui.ModNone, w.toggleWarnsAction, "include warnings toggle"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, 'c', gocui.ModNone, w.copyClipboardAction, "copy errors to clipboard"); err != nil {
+			log.Panicln(err)
+		}
+		if err := w.masterUI.SetCurrentViewOnTop(g); err != nil {
+			log.Panicln(err)
+		}
+	}
+	w.writeLines(v)
+	return nil
+}
+
+func (w *ErrorLogWidget) writeLines(v *gocui.View) {
+	mu.Lock()
+	defer mu.Unlock()
+	lines := w.visibleLines()
+	h := w.height - WindowHeaderSize
+	offset := w.clampOffset(len(lines))
+
+	levels := "errors"
+	if w.includeWarns {
+		levels = "errors and warnings"
+	}
+	v.Title = fmt.Sprintf("%v (%v: %v)", ErrorWindowHeaderText, levels, len(lines))
+	v.Clear()
+	color := WHITE + DIM
+	fmt.Fprintf(v, "%v%v\n", color, v.Title)
+	fmt.Fprintf(v, "%v%v\n", color, ErrorWindowHeaderHelpText)
+	for index := offset; (index-offset) < h && index < len(lines); index++ {
+		fmt.Fprint(v, formatLogLine(lines[index], 0))
+	}
+}
+
+// Caller must hold the mutex
+func (w *ErrorLogWidget) visibleLines() []*LogLine {
+	if w.includeWarns {
+		return errorLines
+	}
+	lines := make([]*LogLine, 0, len(errorLines))
+	for _, logLine := range errorLines {
+		if logLine.level == ErrorLevel {
+			lines = append(lines, logLine)
+		}
+	}
+	return lines
+}
+
+// Offset of the first displayed line.  When following (viewOffset -1) or
+// scrolled past the end the view shows the newest lines.
+func (w *ErrorLogWidget) clampOffset(lineCount int) int {
+	maxOffset := lineCount - (w.height - WindowHeaderSize)
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	if w.viewOffset < 0 || w.viewOffset >= maxOffset {
+		w.viewOffset = -1
+		return maxOffset
+	}
+	return w.viewOffset
+}
+
+func (w *ErrorLogWidget) scroll(g *gocui.Gui, delta int) error {
+	mu.Lock()
+	lineCount := len(w.visibleLines())
+	mu.Unlock()
+	offset := w.clampOffset(lineCount) + delta
+	if offset < 0 {
+		offset = 0
+	}
+	w.viewOffset = offset
+	return w.Layout(g)
+}
+
+func (w *ErrorLogWidget) arrowUp(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, -1)
+}
+
+func (w *ErrorLogWidget) arrowDown(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, 1)
+}
+
+func (w *ErrorLogWidget) pageUp(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, -(w.height - WindowHeaderSize))
+}
+
+func (w *ErrorLogWidget) pageDown(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, w.height-WindowHeaderSize)
+}
+
+func (w *ErrorLogWidget) toggleWarnsAction(g *gocui.Gui, v *gocui.View) error {
+	w.includeWarns = !w.includeWarns
+	w.viewOffset = -1
+	return w.Layout(g)
+}
+
+func (w *ErrorLogWidget) copyClipboardAction(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	var buffer bytes.Buffer
+	for _, logLine := range w.visibleLines() {
+		buffer.WriteString(formatLogLine(logLine, 0))
+	}
+	mu.Unlock()
+	if err := clipboard.WriteAll(buffer.String()); err != nil {
+		Error("Copy into Clipboard error: " + err.Error())
+	}
+	return nil
+}
+
+func (w *ErrorLogWidget) closeAction(g *gocui.Gui, v *gocui.View) error {
+	return w.masterUI.CloseView(w)
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toplog
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/preferences"
+
+// Restore the log view settings toggled in a previous session.  The
+// -log-wrap and -log-short-time options turn the setting on regardless
+// of the saved value.
+func LoadLogPreferences() {
+	prefs, err := preferences.Load()
+	if err != nil {
+		Warn("Unable to load log preferences: %v", err)
+		return
+	}
+	mu.Lock()
+	shortTimestampsEnabled = prefs.LogShortTimestamps
+	wrapEnabled = prefs.LogWrap
+	mu.Unlock()
+}
+
+func saveLogPreferences() {
+	mu.Lock()
+	shortTimestamps := shortTimestampsEnabled
+	wrap := wrapEnabled
+	mu.Unlock()
+	prefs, err := preferences.Load()
+	if err == nil {
+		prefs.LogShortTimestamps = shortTimestamps
+		prefs.LogWrap = wrap
+		err = prefs.Save()
+	}
+	if err != nil {
+		Error("Unable to save log preferences to %v: %v", preferences.Path(), err)
+	}
+}
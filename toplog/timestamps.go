@@ -0,0 +1,39 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toplog
+
+// Timestamp layouts of log lines.  The short layout drops the date and
+// zone which are usually today and local.
+const FullTimestampLayout = "2006-01-02 15:04:05.000 MST"
+const ShortTimestampLayout = "15:04:05.000"
+
+// Show log line timestamps in the short layout.  Saved in the preferences
+// each time it is toggled.
+var shortTimestampsEnabled bool
+
+func SetShortTimestampsEnabled(isEnabled bool) {
+	mu.Lock()
+	shortTimestampsEnabled = isEnabled
+	mu.Unlock()
+}
+
+// Caller must hold the mutex
+func timestampLayout() string {
+	if shortTimestampsEnabled {
+		return ShortTimestampLayout
+	}
+	return FullTimestampLayout
+}
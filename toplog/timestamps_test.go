@@ -0,0 +1,62 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package toplog
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timestamps", func() {
+
+	timestamp := time.Date(2017, 3, 14, 9, 26, 53, 589000000, time.UTC)
+
+	AfterEach(func() {
+		SetShortTimestampsEnabled(false)
+	})
+
+	It("uses the full layout by default", func() {
+		Expect(timestampLayout()).To(Equal(FullTimestampLayout))
+	})
+
+	It("uses the short layout when enabled", func() {
+		SetShortTimestampsEnabled(true)
+		Expect(timestampLayout()).To(Equal(ShortTimestampLayout))
+	})
+
+	Describe("formatLogLine", func() {
+
+		It("shows the date and zone with full timestamps", func() {
+			line := formatLogLine(NewLogLine(InfoLevel, "started", timestamp), 0)
+			Expect(line).To(Equal(WHITE + DIM + "2017-03-14 09:26:53.589 UTC I started\n"))
+		})
+
+		It("shows only the time with short timestamps", func() {
+			SetShortTimestampsEnabled(true)
+			line := formatLogLine(NewLogLine(InfoLevel, "started", timestamp), 0)
+			Expect(line).To(Equal(WHITE + DIM + "09:26:53.589 I started\n"))
+		})
+
+		It("keeps the category and offsets the message in short mode", func() {
+			SetShortTimestampsEnabled(true)
+			line := formatLogLine(NewCategoryLogLine(ErrorLevel, "metadata", "load failed", timestamp), 5)
+			Expect(line).To(Equal(RED + DIM + "09:26:53.589 E [metadata] failed\n"))
+		})
+	})
+
+})
@@ -20,12 +20,19 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/atotto/clipboard"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/jroimartin/gocui"
 )
 
@@ -51,7 +58,36 @@ const WindowHeaderSize = 2
 const WindowHeaderText = "Top Internal Log View"
 const WindowHeaderHelpText = WHITE + BRIGHT + "ENTER" + WHITE + DIM + ":close  " +
 	WHITE + BRIGHT + "UP" + WHITE + DIM + "/" + WHITE + BRIGHT + "DOWN" + WHITE + DIM + " arrow to scroll  " +
-	WHITE + BRIGHT + "a" + WHITE + DIM + ":auto open toggle"
+	WHITE + BRIGHT + "LEFT" + WHITE + DIM + "/" + WHITE + BRIGHT + "RIGHT" + WHITE + DIM + " (alt for larger step)  " +
+	WHITE + BRIGHT + "HOME" + WHITE + DIM + "/" + WHITE + BRIGHT + "END" + WHITE + DIM + " line start/end  " +
+	WHITE + BRIGHT + "a" + WHITE + DIM + ":auto open toggle  " +
+	WHITE + BRIGHT + "t" + WHITE + DIM + ":time range  " +
+	WHITE + BRIGHT + "g" + WHITE + DIM + ":category  " +
+	WHITE + BRIGHT + "l" + WHITE + DIM + ":wrap  " +
+	WHITE + BRIGHT + "s" + WHITE + DIM + ":short time  " +
+	WHITE + BRIGHT + "m" + WHITE + DIM + ":since marker"
+
+// Number of columns the log view scrolls horizontally per LEFT/RIGHT arrow
+// and per alt-LEFT/alt-RIGHT arrow
+const HorizontalScrollStep = 5
+const HorizontalScrollLargeStep = 40
+
+// Written to the temp directory if a panic is recovered in the log view
+const PanicLogFileName = "cf-top-panic.log"
+
+// Log message categories.  Messages logged without a category (e.g., Info)
+// are in DefaultCategory.
+const (
+	DefaultCategory  = "general"
+	FirehoseCategory = "firehose"
+	MetadataCategory = "metadata"
+	ApiCategory      = "api"
+)
+
+// Layouts accepted when entering a time range filter.  The time-only
+// layout is assumed to be today.
+const TimeRangeLayout = "2006-01-02 15:04:05"
+const TimeRangeTimeOnlyLayout = "15:04:05"
 
 type MasterUIInterface interface {
 	SetCurrentViewOnTop(*gocui.Gui) error
@@ -67,6 +103,7 @@ type MasterUIInterface interface {
 	GetDisplayPaused() bool
 	SetDisplayPaused(paused bool)
 	GetTargetDisplay() string
+	OpenInputDialog(g *gocui.Gui, name, titleText, labelText, valueText string, maxLength int, applyFunc func(inputValue string) error) error
 }
 
 type LogLevel string
@@ -88,6 +125,18 @@ var (
 	mu                   sync.Mutex
 	debugEnabled         bool
 	autoShowErrorEnabled bool
+	testMessagesEnabled  bool
+	// Wrap long log lines instead of scrolling horizontally.  Kept when the
+	// log window is closed and reopened.
+	wrapEnabled bool
+	// Seconds without scrolling before auto scroll is resumed (0 disables)
+	autoScrollResumeSeconds int
+	// Incremented on each scroll that freezes auto scroll so only the
+	// resume timer of the latest scroll takes effect
+	scrollGeneration int
+	resumeTimer      *time.Timer
+	// Categories in the order first logged, used to cycle the category filter
+	categories []string
 
 	// msg delta fields are counts by message level of log lines that have
 	// occured since the log window has been closed.
@@ -110,50 +159,94 @@ func IsDebugEnabled() bool {
 	return debugEnabled
 }
 
+// Test message keybindings are for development only and are not
+// bound unless explicitly enabled
+func SetTestMessagesEnabled(isEnabled bool) {
+	testMessagesEnabled = isEnabled
+}
+
+func IsTestMessagesEnabled() bool {
+	return testMessagesEnabled
+}
+
 func SetAutoShowErrorEnabled(isEnabled bool) {
 	autoShowErrorEnabled = isEnabled
 }
 
+func SetWrapEnabled(isEnabled bool) {
+	mu.Lock()
+	wrapEnabled = isEnabled
+	mu.Unlock()
+}
+
+func SetAutoScrollResumeSeconds(seconds int) {
+	if seconds >= 0 {
+		autoScrollResumeSeconds = seconds
+	}
+}
+
 func GetMsgDeltas() (int, int, int, int) {
 	return debugMsgDelta, infoMsgDelta, warnMsgDelta, errorMsgDelta
 }
 
 type LogLine struct {
 	level     LogLevel
+	category  string
 	message   string
 	timestamp time.Time
 }
 
 func NewLogLine(level LogLevel, message string, timestamp time.Time) *LogLine {
-	logLine := &LogLine{level: level, message: message, timestamp: timestamp}
+	return NewCategoryLogLine(level, DefaultCategory, message, timestamp)
+}
+
+func NewCategoryLogLine(level LogLevel, category string, message string, timestamp time.Time) *LogLine {
+	logLine := &LogLine{level: level, category: category, message: message, timestamp: timestamp}
 	return logLine
 }
 
 func Debug(msg string, a ...interface{}) {
+	DebugC(DefaultCategory, msg, a...)
+}
+
+func Info(msg string, a ...interface{}) {
+	InfoC(DefaultCategory, msg, a...)
+}
+
+func Warn(msg string, a ...interface{}) {
+	WarnC(DefaultCategory, msg, a...)
+}
+
+func Error(msg string, a ...interface{}) {
+	ErrorC(DefaultCategory, msg, a...)
+}
+
+// DebugC logs a debug message in the given category (e.g., FirehoseCategory)
+func DebugC(category string, msg string, a ...interface{}) {
 	if debugEnabled {
-		logMsg(DebugLevel, msg, a...)
+		logMsgCategory(DebugLevel, category, msg, a...)
 		if !windowOpen {
 			debugMsgDelta++
 		}
 	}
 }
 
-func Info(msg string, a ...interface{}) {
-	logMsg(InfoLevel, msg, a...)
+func InfoC(category string, msg string, a ...interface{}) {
+	logMsgCategory(InfoLevel, category, msg, a...)
 	if !windowOpen {
 		infoMsgDelta++
 	}
 }
 
-func Warn(msg string, a ...interface{}) {
-	logMsg(WarnLevel, msg, a...)
+func WarnC(category string, msg string, a ...interface{}) {
+	logMsgCategory(WarnLevel, category, msg, a...)
 	if !windowOpen {
 		warnMsgDelta++
 	}
 }
 
-func Error(msg string, a ...interface{}) {
-	logMsg(ErrorLevel, msg, a...)
+func ErrorC(category string, msg string, a ...interface{}) {
+	logMsgCategory(ErrorLevel, category, msg, a...)
 	if !windowOpen {
 		errorMsgDelta = errorMsgDelta + 1
 	}
@@ -178,45 +271,95 @@ func markLastLocation() {
 }
 
 func Open() {
-	if gui != nil {
-		gui.Execute(func(gui *gocui.Gui) error {
-			if !freezeAutoScroll {
-				debugWidget.calulateViewDimensions(gui)
+	if gui == nil || debugWidget == nil {
+		// InitDebug not called yet
+		return
+	}
+	safeExecute(func(gui *gocui.Gui) error {
+		if !freezeAutoScroll {
+			debugWidget.calulateViewDimensions(gui)
+			func() {
 				mu.Lock()
+				defer mu.Unlock()
 				scrollToLastLogLine()
-				mu.Unlock()
+			}()
+		}
+		openView()
+		return nil
+	})
+}
+
+// Run the function on the gui thread.  A panic in the function is
+// recovered so the UI stays alive.
+func safeExecute(f func(*gocui.Gui) error) {
+	gui.Execute(func(g *gocui.Gui) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				reportPanic(r)
+				err = nil
 			}
-			openView()
-			return nil
-		})
+		}()
+		return f(g)
+	})
+}
+
+// Last resort reporting of a panic in the log subsystem.  The panic and
+// stack are written to a file in the temp directory (stderr is not
+// visible while the UI is running) and then to the log.
+func reportPanic(r interface{}) {
+	stack := debug.Stack()
+	fileName := filepath.Join(os.TempDir(), PanicLogFileName)
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "toplog panic: %v\n%s\n", r, stack)
+	} else {
+		fmt.Fprintf(f, "%v toplog panic: %v\n%s\n", clock.Now().Format("01-02-2006 15:04:05"), r, stack)
+		f.Close()
 	}
+	logMsg(ErrorLevel, "Recovered from log view panic: %v (stack written to %v)", r, fileName)
 }
 
 func scrollToLastLogLine() {
 	// Do not lock mutex here -- as callers should already have the lock
-	logSize := len(debugLines)
-	viewOffset := logSize - (debugWidget.height - WindowHeaderSize)
-	if viewOffset < 0 {
-		viewOffset = 0
-	}
-	debugWidget.viewOffset = viewOffset
+	debugWidget.viewOffset = debugWidget.lastPageOffset(debugWidget.visibleLogLines())
 }
 
 func logMsg(level LogLevel, msg string, a ...interface{}) {
+	logMsgCategory(level, DefaultCategory, msg, a...)
+}
+
+func logMsgCategory(level LogLevel, category string, msg string, a ...interface{}) {
 	mu.Lock()
 	defer mu.Unlock()
 	msg = fmt.Sprintf(msg, a...)
 	msg = strings.Replace(msg, "\n", " | ", -1)
-	logLine := NewLogLine(level, msg, time.Now())
+	if category == "" {
+		category = DefaultCategory
+	}
+	addCategory(category)
+	logLine := NewCategoryLogLine(level, category, msg, clock.Now())
 	debugLines = append(debugLines, logLine)
 	if len(debugLines) > MAX_LOG_FILES {
 		debugLines = debugLines[1:]
 	}
+	if level == ErrorLevel || level == WarnLevel {
+		addErrorLine(logLine)
+	}
 	if windowOpen && !freezeAutoScroll {
 		scrollToLastLogLine()
 	}
 }
 
+// Caller must hold the mutex
+func addCategory(category string) {
+	for _, existing := range categories {
+		if existing == category {
+			return
+		}
+	}
+	categories = append(categories, category)
+}
+
 type DebugWidget struct {
 	masterUI        MasterUIInterface
 	name            string
@@ -224,6 +367,14 @@ type DebugWidget struct {
 	width           int
 	viewOffset      int
 	horizonalOffset int
+
+	// Optional time range filter, zero value means open ended
+	rangeStart time.Time
+	rangeEnd   time.Time
+	// Optional category filter, empty shows all categories
+	categoryFilter string
+	// Only show lines from the most recent marker line on
+	sinceMarker bool
 }
 
 func InitDebug(g *gocui.Gui, masterUI MasterUIInterface) {
@@ -274,6 +425,11 @@ func (w *DebugWidget) calulateViewDimensions(g *gocui.Gui) (left, top, right, bo
 func (w *DebugWidget) Layout(g *gocui.Gui) error {
 
 	left, top, right, bottom := w.calulateViewDimensions(g)
+	// The height may have changed (terminal resize) so the offset
+	// needs to be re-checked against the new height every layout
+	mu.Lock()
+	w.clampViewOffset()
+	mu.Unlock()
 	v, err := g.SetView(w.name, left, top, right, bottom)
 	if err != nil {
 		if err != gocui.ErrUnknownView {
@@ -282,7 +438,7 @@ func (w *DebugWidget) Layout(g *gocui.Gui) error {
 		v.Title = WindowHeaderText
 		v.Frame = true
 		v.Autoscroll = false
-		v.Wrap = false
+		v.Wrap = wrapEnabled
 		/*
 			bgColor := w.getBackgroundColor()
 			v.BgColor = bgColor
@@ -294,52 +450,83 @@ func (w *DebugWidget) Layout(g *gocui.Gui) error {
 		g.SelBgColor = gocui.ColorWhite
 		g.Highlight = true
 
-		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.closeDebugWidget); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.closeDebugWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeDebugWidget); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeDebugWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeDebugWidget); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeDebugWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp, "scroll up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp, "page up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown, "page down"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown, "scroll down"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowRight, gocui.ModNone, w.arrowRight); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowRight, gocui.ModNone, w.arrowRight, "scroll right"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowLeft, gocui.ModNone, w.arrowLeft); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowLeft, gocui.ModNone, w.arrowLeft, "scroll left"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'c', gocui.ModNone, w.copyClipboardAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowRight, gocui.ModAlt, w.arrowRightLarge, "scroll right (large step)"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'e', gocui.ModNone, w.testErrorMsg); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowLeft, gocui.ModAlt, w.arrowLeftLarge, "scroll left (large step)"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'w', gocui.ModNone, w.testWarnMsg); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnd, gocui.ModNone, w.scrollToLineEnd, "scroll to end of longest line"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'i', gocui.ModNone, w.testInfoMsg); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyHome, gocui.ModNone, w.scrollToLineStart, "scroll to start of line"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'd', gocui.ModNone, w.testDebugMsg); err != nil {
+		if err := keybinding.Set(g, w.name, 'c', gocui.ModNone, w.copyClipboardAction, "copy log to clipboard"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'D', gocui.ModNone, w.toggleDebugAction); err != nil {
+		if testMessagesEnabled {
+			if err := keybinding.Set(g, w.name, 'e', gocui.ModNone, w.testErrorMsg, "log test error message"); err != nil {
+				log.Panicln(err)
+			}
+			if err := keybinding.Set(g, w.name, 'w', gocui.ModNone, w.testWarnMsg, "log test warn message"); err != nil {
+				log.Panicln(err)
+			}
+			if err := keybinding.Set(g, w.name, 'i', gocui.ModNone, w.testInfoMsg, "log test info message"); err != nil {
+				log.Panicln(err)
+			}
+			if err := keybinding.Set(g, w.name, 'd', gocui.ModNone, w.testDebugMsg, "log test debug message"); err != nil {
+				log.Panicln(err)
+			}
+		}
+		if !config.IsKioskMode() {
+			if err := keybinding.Set(g, w.name, 'D', gocui.ModNone, w.toggleDebugAction, "toggle debug logging"); err != nil {
+				log.Panicln(err)
+			}
+		}
+		if err := keybinding.Set(g, w.name, 'a', gocui.ModNone, w.toggleAutoOpenAction, "toggle auto open on error"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, 'a', gocui.ModNone, w.toggleAutoOpenAction); err != nil {
+		if err := keybinding.Set(g, w.name, 't', gocui.ModNone, w.editTimeRangeAction, "filter by time range"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, 'g', gocui.ModNone, w.nextCategoryFilterAction, "cycle category filter"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, 'l', gocui.ModNone, w.toggleWrapAction, "toggle line wrap"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, 's', gocui.ModNone, w.toggleShortTimestampsAction, "toggle short / full timestamps"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, 'm', gocui.ModNone, w.toggleSinceMarkerAction, "toggle show only lines since marker"); err != nil {
 			log.Panicln(err)
 		}
 
@@ -352,6 +539,7 @@ func (w *DebugWidget) Layout(g *gocui.Gui) error {
 			v.BgColor = bgColor
 			g.SelBgColor = bgColor
 		*/
+		v.Wrap = wrapEnabled
 		w.writeLogLines(g, v)
 		v.Title = w.windowTitle(g, v)
 	}
@@ -368,6 +556,18 @@ func (w *DebugWidget) windowTitle(g *gocui.Gui, v *gocui.View) string {
 	if debugEnabled {
 		title = fmt.Sprintf("%v, DebugMode:ON", title)
 	}
+	if w.isTimeRangeActive() {
+		title = fmt.Sprintf("%v, Range:%v", title, w.timeRangeText())
+	}
+	if w.categoryFilter != "" {
+		title = fmt.Sprintf("%v, Category:%v", title, w.categoryFilter)
+	}
+	if w.sinceMarker {
+		title = fmt.Sprintf("%v, SinceMarker", title)
+	}
+	if wrapEnabled {
+		title = fmt.Sprintf("%v, Wrap:ON", title)
+	}
 	if freezeAutoScroll {
 		color := YELLOW + DIM
 		title = fmt.Sprintf("%v, %vAUTO SCROLL OFF", title, color)
@@ -386,17 +586,246 @@ func (w *DebugWidget) writeLogLines(g *gocui.Gui, v *gocui.View) {
 	fmt.Fprintf(v, "%v%v\n", color, title)
 
 	fmt.Fprintf(v, "%v%v\n", color, WindowHeaderHelpText)
-	for index := w.viewOffset; (index-w.viewOffset) < (h) && index < len(debugLines); index++ {
-		line := w.getFormattedLogLine(index)
+	logLines := w.visibleLogLines()
+	// Count screen rows, not log lines, as a wrapped line uses several rows
+	rows := 0
+	for index := w.viewOffset; rows < h && index < len(logLines); index++ {
+		line := w.getFormattedLogLine(logLines[index])
 		fmt.Fprintf(v, line)
+		rows += w.lineHeight(logLines[index])
+	}
+}
+
+// Number of screen rows the log line uses, always 1 unless wrap is enabled
+func (w *DebugWidget) lineHeight(logLine *LogLine) int {
+	// Inner width of the framed view
+	width := w.width - 1
+	if !wrapEnabled || width <= 0 {
+		return 1
+	}
+	line := strings.TrimSuffix(formatLogLine(logLine, 0), "\n")
+	// Do not count the leading color escape sequence
+	if strings.HasPrefix(line, "\033[") {
+		if end := strings.Index(line, "m"); end >= 0 {
+			line = line[end+1:]
+		}
+	}
+	length := utf8.RuneCountInString(line)
+	if length <= width {
+		return 1
+	}
+	return (length + width - 1) / width
+}
+
+// Offset of the first log line of the last page, i.e., the offset at which
+// the last log line is at the bottom of the window.  Caller must hold the mutex.
+func (w *DebugWidget) lastPageOffset(logLines []*LogLine) int {
+	h := w.height - WindowHeaderSize
+	rows := 0
+	for index := len(logLines) - 1; index >= 0; index-- {
+		rows += w.lineHeight(logLines[index])
+		if rows > h {
+			offset := index + 1
+			// A single wrapped line taller than the window is still shown
+			if offset > len(logLines)-1 {
+				offset = len(logLines) - 1
+			}
+			return offset
+		}
+	}
+	return 0
+}
+
+// Number of log lines that fit in the window starting at offset
+func (w *DebugWidget) linesOnPage(logLines []*LogLine, offset int) int {
+	h := w.height - WindowHeaderSize
+	rows := 0
+	count := 0
+	for index := offset; index < len(logLines); index++ {
+		rows += w.lineHeight(logLines[index])
+		if rows > h && count > 0 {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// Log lines that pass the time range and category filters.  Caller must
+// hold the mutex.
+func (w *DebugWidget) visibleLogLines() []*LogLine {
+	allLines := debugLines
+	if w.sinceMarker {
+		if markerIndex := lastMarkerIndex(); markerIndex >= 0 {
+			allLines = debugLines[markerIndex:]
+		}
+	}
+	if !w.isTimeRangeActive() && w.categoryFilter == "" {
+		return allLines
 	}
+	logLines := make([]*LogLine, 0, len(allLines))
+	for _, logLine := range allLines {
+		if w.categoryFilter != "" && logLine.level != MarkerLevel && logLine.category != w.categoryFilter {
+			continue
+		}
+		if !w.rangeStart.IsZero() && logLine.timestamp.Before(w.rangeStart) {
+			continue
+		}
+		if !w.rangeEnd.IsZero() && logLine.timestamp.After(w.rangeEnd) {
+			continue
+		}
+		logLines = append(logLines, logLine)
+	}
+	return logLines
 }
 
-func (w *DebugWidget) getFormattedLogLine(index int) string {
-	logLine := debugLines[index]
+// Index of the most recent marker line, -1 if there is none.  Caller must
+// hold the mutex.
+func lastMarkerIndex() int {
+	for index := len(debugLines) - 1; index >= 0; index-- {
+		if debugLines[index].level == MarkerLevel {
+			return index
+		}
+	}
+	return -1
+}
+
+func (w *DebugWidget) isTimeRangeActive() bool {
+	return !w.rangeStart.IsZero() || !w.rangeEnd.IsZero()
+}
+
+func (w *DebugWidget) timeRangeText() string {
+	if !w.isTimeRangeActive() {
+		return ""
+	}
+	startText := "*"
+	if !w.rangeStart.IsZero() {
+		startText = w.rangeStart.Format(TimeRangeLayout)
+	}
+	endText := "*"
+	if !w.rangeEnd.IsZero() {
+		endText = w.rangeEnd.Format(TimeRangeLayout)
+	}
+	return startText + " - " + endText
+}
+
+// Cycle the category filter: all -> each category logged so far -> all
+func (w *DebugWidget) nextCategoryFilterAction(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	defer mu.Unlock()
+	next := ""
+	if w.categoryFilter == "" {
+		if len(categories) > 0 {
+			next = categories[0]
+		}
+	} else {
+		for index, category := range categories {
+			if category == w.categoryFilter && index+1 < len(categories) {
+				next = categories[index+1]
+				break
+			}
+		}
+	}
+	w.categoryFilter = next
+	freezeAutoScroll = false
+	scrollToLastLogLine()
+	return nil
+}
+
+func (w *DebugWidget) toggleSinceMarkerAction(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	if !w.sinceMarker && lastMarkerIndex() < 0 {
+		mu.Unlock()
+		Info("No marker line yet, one is added when the log view is closed")
+		return nil
+	}
+	w.sinceMarker = !w.sinceMarker
+	freezeAutoScroll = false
+	scrollToLastLogLine()
+	mu.Unlock()
+	return nil
+}
+
+func (w *DebugWidget) editTimeRangeAction(g *gocui.Gui, v *gocui.View) error {
+	valueText := ""
+	if w.isTimeRangeActive() {
+		valueText = strings.Replace(w.timeRangeText(), "*", "", -1)
+	}
+	return w.masterUI.OpenInputDialog(g, "logTimeRangeWidget",
+		"Log time range (start - end), blank for all",
+		"Range:", strings.TrimSpace(valueText), 41, w.applyTimeRange)
+}
+
+// Input format is "start - end" where either side may be left blank
+// for an open ended range.  Empty input clears the filter.
+func (w *DebugWidget) applyTimeRange(inputValue string) error {
+	value := strings.TrimSpace(inputValue)
+	startText := value
+	endText := ""
+	if index := strings.Index(value, " - "); index >= 0 {
+		startText = value[:index]
+		endText = value[index+3:]
+	} else if strings.HasPrefix(value, "-") {
+		startText = ""
+		endText = value[1:]
+	} else if strings.HasSuffix(value, "-") {
+		startText = value[:len(value)-1]
+	}
+	rangeStart, err := parseRangeTime(startText)
+	if err != nil {
+		Warn("Invalid log range start time: %v", err)
+		return err
+	}
+	rangeEnd, err := parseRangeTime(endText)
+	if err != nil {
+		Warn("Invalid log range end time: %v", err)
+		return err
+	}
+	if !rangeStart.IsZero() && !rangeEnd.IsZero() && rangeEnd.Before(rangeStart) {
+		err := errors.New("end time is before start time")
+		Warn("Invalid log range: %v", err)
+		return err
+	}
+	mu.Lock()
+	w.rangeStart = rangeStart
+	w.rangeEnd = rangeEnd
+	w.viewOffset = 0
+	freezeAutoScroll = false
+	scrollToLastLogLine()
+	mu.Unlock()
+	return nil
+}
+
+func parseRangeTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.ParseInLocation(TimeRangeLayout, value, time.Local)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.ParseInLocation(TimeRangeTimeOnlyLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := clock.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local), nil
+}
+
+func (w *DebugWidget) getFormattedLogLine(logLine *LogLine) string {
+	if wrapEnabled {
+		return formatLogLine(logLine, 0)
+	}
+	return formatLogLine(logLine, w.horizonalOffset)
+}
+
+// Colored log line (with trailing newline) with the first horizonalOffset
+// characters of the message removed
+func formatLogLine(logLine *LogLine, horizonalOffset int) string {
 	msg := logLine.message
-	if w.horizonalOffset < len(msg) {
-		msg = msg[w.horizonalOffset:len(msg)]
+	if horizonalOffset < len(msg) {
+		msg = msg[horizonalOffset:len(msg)]
 	} else {
 		msg = ""
 	}
@@ -420,11 +849,20 @@ func (w *DebugWidget) getFormattedLogLine(index int) string {
 		line = fmt.Sprintf("%v%v", color, "_________________ New Messages Below _______________________\n")
 	} else {
 		//line = fmt.Sprintf("[%03v] %v %v %v\n", index, logLine.timestamp.Format("2006-01-02 15:04:05 MST"), logLine.level, msg)
-		line = fmt.Sprintf("%v%v %v %v\n", color, logLine.timestamp.Format("2006-01-02 15:04:05.000 MST"), logLine.level, msg)
+		line = fmt.Sprintf("%v%v%v\n", color, logLinePrefix(logLine), msg)
 	}
 	return line
 }
 
+// Timestamp, level and (non default) category shown before the message
+func logLinePrefix(logLine *LogLine) string {
+	category := ""
+	if logLine.category != DefaultCategory {
+		category = "[" + logLine.category + "] "
+	}
+	return fmt.Sprintf("%v %v %v", logLine.timestamp.Format(timestampLayout()), logLine.level, category)
+}
+
 func (w *DebugWidget) getBackgroundColor() gocui.Attribute {
 	/*
 		switch w.getMaxLogLevel() {
@@ -504,6 +942,30 @@ func (w *DebugWidget) toggleAutoOpenAction(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+func (w *DebugWidget) toggleWrapAction(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	wrapEnabled = !wrapEnabled
+	isEnabled := wrapEnabled
+	w.horizonalOffset = 0
+	v.Wrap = wrapEnabled
+	w.clampViewOffset()
+	mu.Unlock()
+	saveLogPreferences()
+	Info("Log view line wrap now set to %v", isEnabled)
+	return nil
+}
+
+func (w *DebugWidget) toggleShortTimestampsAction(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	shortTimestampsEnabled = !shortTimestampsEnabled
+	shortTimestamps := shortTimestampsEnabled
+	w.horizonalOffset = 0
+	mu.Unlock()
+	saveLogPreferences()
+	Info("Log view short timestamps now set to %v", shortTimestamps)
+	return nil
+}
+
 func (w *DebugWidget) copyClipboardAction(g *gocui.Gui, v *gocui.View) error {
 	clipboardValue := w.getAllLogLines()
 	err := clipboard.WriteAll(clipboardValue)
@@ -517,56 +979,171 @@ func (w *DebugWidget) getAllLogLines() string {
 	mu.Lock()
 	defer mu.Unlock()
 	var buffer bytes.Buffer
-	for index := 0; index < len(debugLines); index++ {
-		line := w.getFormattedLogLine(index)
+	for _, logLine := range w.visibleLogLines() {
+		line := w.getFormattedLogLine(logLine)
 		buffer.WriteString(line)
 	}
 	return buffer.String()
 }
 
 func (w *DebugWidget) arrowRight(g *gocui.Gui, v *gocui.View) error {
-	w.horizonalOffset = w.horizonalOffset + 5
+	w.scrollHorizontal(v, HorizontalScrollStep)
 	return nil
 }
 
 func (w *DebugWidget) arrowLeft(g *gocui.Gui, v *gocui.View) error {
-	w.horizonalOffset = w.horizonalOffset - 5
-	if w.horizonalOffset < 0 {
-		w.horizonalOffset = 0
-	}
+	w.scrollHorizontal(v, -HorizontalScrollStep)
+	return nil
+}
+
+func (w *DebugWidget) arrowRightLarge(g *gocui.Gui, v *gocui.View) error {
+	w.scrollHorizontal(v, HorizontalScrollLargeStep)
+	return nil
+}
+
+func (w *DebugWidget) arrowLeftLarge(g *gocui.Gui, v *gocui.View) error {
+	w.scrollHorizontal(v, -HorizontalScrollLargeStep)
+	return nil
+}
+
+// Scroll so the end of the longest visible line is in view
+func (w *DebugWidget) scrollToLineEnd(g *gocui.Gui, v *gocui.View) error {
+	w.horizonalOffset = w.maxHorizontalOffset(v)
+	return nil
+}
+
+func (w *DebugWidget) scrollToLineStart(g *gocui.Gui, v *gocui.View) error {
+	w.horizonalOffset = 0
 	return nil
 }
 
+func (w *DebugWidget) scrollHorizontal(v *gocui.View, delta int) {
+	offset := w.horizonalOffset + delta
+	if maxOffset := w.maxHorizontalOffset(v); offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	w.horizonalOffset = offset
+}
+
+// The furthest right the view can be scrolled while still showing the end
+// of the longest message currently visible in the window
+func (w *DebugWidget) maxHorizontalOffset(v *gocui.View) int {
+	mu.Lock()
+	defer mu.Unlock()
+	if wrapEnabled {
+		return 0
+	}
+	viewX, _ := v.Size()
+	h := w.height - WindowHeaderSize
+	logLines := w.visibleLogLines()
+	maxOffset := 0
+	for index := w.viewOffset; (index-w.viewOffset) < (h) && index < len(logLines); index++ {
+		logLine := logLines[index]
+		if logLine.level == MarkerLevel {
+			continue
+		}
+		prefixLen := len(logLinePrefix(logLine))
+		offset := len(logLine.message) - (viewX - prefixLen)
+		if offset > maxOffset {
+			maxOffset = offset
+		}
+	}
+	return maxOffset
+}
+
+// Keep viewOffset within the visible log lines for the current height.
+// If auto scroll is active the view is kept on the last log line.
+// Caller must hold the mutex.
+func (w *DebugWidget) clampViewOffset() {
+	if !freezeAutoScroll {
+		scrollToLastLogLine()
+		return
+	}
+	maxOffset := w.lastPageOffset(w.visibleLogLines())
+	if w.viewOffset > maxOffset {
+		w.viewOffset = maxOffset
+	}
+	if w.viewOffset < 0 {
+		w.viewOffset = 0
+	}
+}
+
 func (w *DebugWidget) arrowUp(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	defer mu.Unlock()
 	if w.viewOffset > 0 {
 		w.viewOffset--
-		freezeAutoScroll = true
+		freezeScroll()
 	}
 	return nil
 }
 
+// Turn off auto scroll and (re)start the timer that turns it back on
+// after autoScrollResumeSeconds without scrolling.  Caller must hold
+// the mutex.
+func freezeScroll() {
+	freezeAutoScroll = true
+	if autoScrollResumeSeconds <= 0 {
+		return
+	}
+	scrollGeneration++
+	generation := scrollGeneration
+	if resumeTimer != nil {
+		resumeTimer.Stop()
+	}
+	resumeTimer = time.AfterFunc(time.Duration(autoScrollResumeSeconds)*time.Second, func() {
+		safeExecute(func(g *gocui.Gui) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if freezeAutoScroll && generation == scrollGeneration {
+				freezeAutoScroll = false
+				scrollToLastLogLine()
+			}
+			return nil
+		})
+	})
+}
+
 func (w *DebugWidget) arrowDown(g *gocui.Gui, v *gocui.View) error {
 	mu.Lock()
 	defer mu.Unlock()
-	h := w.height - WindowHeaderSize
-	if w.viewOffset < len(debugLines) && (len(debugLines)-h) > w.viewOffset {
+	maxOffset := w.lastPageOffset(w.visibleLogLines())
+	if w.viewOffset < maxOffset {
 		w.viewOffset++
 	}
 
-	if !(w.viewOffset < len(debugLines) && (len(debugLines)-h) > w.viewOffset) {
+	if !(w.viewOffset < maxOffset) {
 		freezeAutoScroll = false
+	} else if freezeAutoScroll {
+		// Still reading history, restart the resume timer
+		freezeScroll()
 	}
 
 	return nil
 }
 
 func (w *DebugWidget) pageUp(g *gocui.Gui, v *gocui.View) error {
+	mu.Lock()
+	defer mu.Unlock()
 	if w.viewOffset > 0 {
-		w.viewOffset = w.viewOffset - (w.height - WindowHeaderSize)
-		if w.viewOffset < 0 {
-			w.viewOffset = 0
+		// Move back as many log lines as fit in the window above the
+		// current offset (fewer than the window height if lines are wrapped)
+		h := w.height - WindowHeaderSize
+		logLines := w.visibleLogLines()
+		offset := w.viewOffset
+		rows := 0
+		for offset > 0 && offset <= len(logLines) {
+			rows += w.lineHeight(logLines[offset-1])
+			if rows > h && offset < w.viewOffset {
+				break
+			}
+			offset--
 		}
-		freezeAutoScroll = true
+		w.viewOffset = offset
+		freezeScroll()
 	}
 	return nil
 }
@@ -574,11 +1151,14 @@ func (w *DebugWidget) pageUp(g *gocui.Gui, v *gocui.View) error {
 func (w *DebugWidget) pageDown(g *gocui.Gui, v *gocui.View) error {
 	mu.Lock()
 	defer mu.Unlock()
-	h := w.height - WindowHeaderSize
-	w.viewOffset = w.viewOffset + h
-	if !(w.viewOffset < len(debugLines) && (len(debugLines)-h) > w.viewOffset) {
-		w.viewOffset = len(debugLines) - h
+	logLines := w.visibleLogLines()
+	maxOffset := w.lastPageOffset(logLines)
+	w.viewOffset = w.viewOffset + w.linesOnPage(logLines, w.viewOffset)
+	if !(w.viewOffset < maxOffset) {
+		w.viewOffset = maxOffset
 		freezeAutoScroll = false
+	} else if freezeAutoScroll {
+		freezeScroll()
 	}
 	return nil
 }
@@ -0,0 +1,138 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataCommon
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+)
+
+// Plain (JSON friendly) per-app stats for use outside of the UI
+type AppSummary struct {
+	AppId                    string  `json:"app_id"`
+	AppName                  string  `json:"app_name"`
+	SpaceName                string  `json:"space_name"`
+	OrgName                  string  `json:"org_name"`
+	StackName                string  `json:"stack_name,omitempty"`
+	IsolationSegmentName     string  `json:"isolation_segment_name,omitempty"`
+	Monitored                bool    `json:"monitored"`
+	DesiredContainers        int     `json:"desired_containers"`
+	TotalReportingContainers int     `json:"reporting_containers"`
+	TotalCpuPercentage       float64 `json:"cpu_percentage"`
+	CpuTrend                 int     `json:"cpu_trend"`
+	TotalMemoryUsed          int64   `json:"memory_used_bytes"`
+	TotalDiskUsed            int64   `json:"disk_used_bytes"`
+	Crash1hCount             int     `json:"crash_1h_count"`
+	Crash24hCount            int     `json:"crash_24h_count"`
+	EventL1Rate              int     `json:"http_requests_1s"`
+	EventL10Rate             int     `json:"http_requests_10s"`
+	EventL60Rate             int     `json:"http_requests_60s"`
+	HttpAllCount             int64   `json:"http_all_count"`
+	Http2xxCount             int64   `json:"http_2xx_count"`
+	Http3xxCount             int64   `json:"http_3xx_count"`
+	Http4xxCount             int64   `json:"http_4xx_count"`
+	Http5xxCount             int64   `json:"http_5xx_count"`
+	HealthScore              int     `json:"health_score"`
+}
+
+type appSummaryArray []*AppSummary
+
+func (slice appSummaryArray) Len() int {
+	return len(slice)
+}
+
+func (slice appSummaryArray) Less(i, j int) bool {
+	if slice[i].AppName == slice[j].AppName {
+		return slice[i].AppId < slice[j].AppId
+	}
+	return slice[i].AppName < slice[j].AppName
+}
+
+func (slice appSummaryArray) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+func (das *DisplayAppStats) Summary() *AppSummary {
+	summary := &AppSummary{
+		AppId:                    das.AppId,
+		AppName:                  das.AppName,
+		SpaceName:                das.SpaceName,
+		OrgName:                  das.OrgName,
+		StackName:                das.StackName,
+		IsolationSegmentName:     das.IsolationSegmentName,
+		Monitored:                das.Monitored,
+		DesiredContainers:        das.DesiredContainers,
+		TotalReportingContainers: das.TotalReportingContainers,
+		TotalCpuPercentage:       das.TotalCpuPercentage,
+		CpuTrend:                 das.CpuTrend,
+		TotalMemoryUsed:          das.TotalMemoryUsed,
+		TotalDiskUsed:            das.TotalDiskUsed,
+		Crash1hCount:             das.Crash1hCount,
+		Crash24hCount:            das.Crash24hCount,
+		HttpAllCount:             das.HttpAllCount,
+		Http2xxCount:             das.Http2xxCount,
+		Http3xxCount:             das.Http3xxCount,
+		Http4xxCount:             das.Http4xxCount,
+		Http5xxCount:             das.Http5xxCount,
+		HealthScore:              das.HealthScore,
+	}
+	if summary.TotalCpuPercentage < 0 {
+		// Remove the sort placeholder used for apps with no containers
+		summary.TotalCpuPercentage = 0
+	}
+	if das.TotalTraffic != nil {
+		summary.EventL1Rate = das.TotalTraffic.EventL1Rate
+		summary.EventL10Rate = das.TotalTraffic.EventL10Rate
+		summary.EventL60Rate = das.TotalTraffic.EventL60Rate
+	}
+	return summary
+}
+
+// Post process the current event data and return a summary of each app
+// sorted by app name.  To keep state such as CPU trend between calls, reuse
+// the same CommonData instance.
+func (cd *CommonData) AppSummaries() []*AppSummary {
+	return summarize(cd.PostProcessData())
+}
+
+// Summary of each app from the last call to PostProcessData (no
+// additional processing is done)
+func (cd *CommonData) LastAppSummaries() []*AppSummary {
+	return summarize(cd.displayAppStatsMap)
+}
+
+func summarize(displayStatsMap map[string]*DisplayAppStats) []*AppSummary {
+	summaries := make([]*AppSummary, 0, len(displayStatsMap))
+	for _, displayAppStats := range displayStatsMap {
+		summaries = append(summaries, displayAppStats.Summary())
+	}
+	sort.Sort(appSummaryArray(summaries))
+	return summaries
+}
+
+// One shot headless snapshot of the per-app stats of the given processor.
+// startTime is when stats collection started (used for warm-up).  A nil
+// monitoredAppGuids means all apps are monitored.  Empty scope guids
+// include all orgs / spaces.
+func GetAppSummaries(processor *eventdata.EventProcessor, startTime time.Time, monitoredAppGuids map[string]bool,
+	scopeOrgGuid, scopeSpaceGuid string) []*AppSummary {
+	source := NewProcessorStatsSource(processor, startTime)
+	commonData := NewCommonData(source, monitoredAppGuids)
+	commonData.SetScope(scopeOrgGuid, scopeSpaceGuid)
+	return commonData.AppSummaries()
+}
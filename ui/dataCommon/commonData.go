@@ -16,23 +16,57 @@
 package dataCommon
 
 import (
+	"math"
+	"sort"
 	"time"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/config"
-	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/route"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
 
+// Source of the event data that CommonData post processes along with the
+// time stats collection started (reset when stats are cleared).  This is
+// satisfied by *eventrouting.EventRouter or by NewProcessorStatsSource when
+// driving the stats layer without the UI.
+type StatsSource interface {
+	GetProcessor() *eventdata.EventProcessor
+	GetStartTime() time.Time
+}
+
+type processorStatsSource struct {
+	processor *eventdata.EventProcessor
+	startTime time.Time
+}
+
+func NewProcessorStatsSource(processor *eventdata.EventProcessor, startTime time.Time) StatsSource {
+	return &processorStatsSource{processor: processor, startTime: startTime}
+}
+
+func (s *processorStatsSource) GetProcessor() *eventdata.EventProcessor {
+	return s.processor
+}
+
+func (s *processorStatsSource) GetStartTime() time.Time {
+	return s.startTime
+}
+
+// CommonData has no dependency on gocui so it can be used headless
+// (see AppSummaries) as well as by the views
 type CommonData struct {
 	//masterUI       masterUIInterface.MasterUIInterface
-	router *eventrouting.EventRouter
+	router StatsSource
 	//eventProcessor *eventdata.EventProcessor
-	appMdMgr *app.AppMetadataManager
+	appMdMgr          *app.AppMetadataManager
+	routeMappingMdMgr *route.RouteMappingManager
 
 	displayAppStatsMap map[string]*DisplayAppStats
 	monitoredAppGuids  map[string]bool
@@ -42,8 +76,73 @@ type CommonData struct {
 	// the correct number of containers running based on app
 	// instance setting
 	appsNotInDesiredState int
+	appsAtMemoryRisk      int
 	totalCrash1hCount     int
 	totalCrash24hCount    int
+
+	// Key: appId
+	cpuTrendMap map[string]*cpuTrend
+	// Key: appId
+	logRateMap map[string]*logRate
+	// Key: appId
+	memoryHistoryMap map[string]*memoryHistory
+	// Key: appId
+	memoryTrendMap map[string]*memoryTrend
+	// Notes attached to apps by the user.  These are only kept for the
+	// session and are not removed when the app stops reporting.
+	// Key: appId
+	appNoteMap map[string]string
+
+	// Optional org or space focus.  When set only apps in the scope
+	// are included in the display stats (and therefore views, totals and alerts)
+	scopeOrgGuid   string
+	scopeSpaceGuid string
+}
+
+// Short history of an app's aggregate CPU percent
+type cpuTrend struct {
+	samples             []float64
+	reportingContainers int
+}
+
+// Longer horizon history of an app's total memory used, used to
+// compute MemorySlope.  History is reset when the number of reporting
+// containers or reserved memory changes as the aggregate is no longer comparable.
+type memoryTrend struct {
+	reportingContainers int
+	reservedMemoryMB    float64
+	samples             []memorySample
+}
+
+type memorySample struct {
+	metricTime  time.Time
+	memoryBytes float64
+}
+
+// Recent container memory samples of an app used to compute MemoryP95.
+// History is reset when the reserved memory of the app changes.
+type memoryHistory struct {
+	reservedMemoryMB float64
+	// Key: container index
+	containers map[int]*containerMemoryHistory
+}
+
+// Bounded ring buffer of memory samples of one container
+type containerMemoryHistory struct {
+	samples    []uint64
+	next       int
+	lastUpdate time.Time
+	// Stats time of the last refresh the container was reporting
+	lastSeen time.Time
+}
+
+// Aggregate log counts of an app at the last display refresh
+type logRate struct {
+	statsTime   time.Time
+	stdoutCount int64
+	stderrCount int64
+	stdoutRate  int64
+	stderrRate  int64
 }
 
 // TODO:  Create a common data struct -- which needs access to masterUI
@@ -51,11 +150,17 @@ type CommonData struct {
 // appView to access this data through  masterUI so we don't process
 // the same data twice
 
-func NewCommonData(router *eventrouting.EventRouter, monitoredAppGuids map[string]bool) *CommonData {
+func NewCommonData(router StatsSource, monitoredAppGuids map[string]bool) *CommonData {
 	cd := &CommonData{router: router}
 
 	cd.appMdMgr = router.GetProcessor().GetMetadataManager().GetAppMdManager()
+	cd.routeMappingMdMgr = router.GetProcessor().GetMetadataManager().GetRouteMappingMdManager()
 	cd.monitoredAppGuids = monitoredAppGuids
+	cd.cpuTrendMap = make(map[string]*cpuTrend)
+	cd.logRateMap = make(map[string]*logRate)
+	cd.memoryHistoryMap = make(map[string]*memoryHistory)
+	cd.memoryTrendMap = make(map[string]*memoryTrend)
+	cd.appNoteMap = make(map[string]string)
 	return cd
 }
 
@@ -63,6 +168,25 @@ func (cd *CommonData) GetDisplayAppStatsMap() map[string]*DisplayAppStats {
 	return cd.displayAppStatsMap
 }
 
+// Note attached to the app, empty if none
+func (cd *CommonData) AppNote(appId string) string {
+	return cd.appNoteMap[appId]
+}
+
+// Attach a note to the app for the rest of the session, an empty note
+// removes it.  The current display stats are updated so the note shows
+// without waiting for the next refresh.
+func (cd *CommonData) SetAppNote(appId string, note string) {
+	if note == "" {
+		delete(cd.appNoteMap, appId)
+	} else {
+		cd.appNoteMap[appId] = note
+	}
+	if displayAppStats := cd.displayAppStatsMap[appId]; displayAppStats != nil {
+		displayAppStats.Note = note
+	}
+}
+
 func (cd *CommonData) IsWarmupComplete() bool {
 	return cd.isWarmupComplete
 }
@@ -71,6 +195,12 @@ func (cd *CommonData) AppsNotInDesiredState() int {
 	return cd.appsNotInDesiredState
 }
 
+// Number of apps with a container using at least config.MemoryRiskPercent
+// of its reserved memory
+func (cd *CommonData) AppsAtMemoryRisk() int {
+	return cd.appsAtMemoryRisk
+}
+
 func (cd *CommonData) TotalCrash1hCount() int {
 	return cd.totalCrash1hCount
 }
@@ -87,6 +217,46 @@ func (cd *CommonData) GetMonitoredAppGuids() map[string]bool {
 	return cd.monitoredAppGuids
 }
 
+// Scope all display stats to an org or a space (if spaceGuid is set it
+// takes precedence).  Empty values clear the scope.
+func (cd *CommonData) SetScope(orgGuid, spaceGuid string) {
+	if spaceGuid != "" && orgGuid == "" {
+		orgGuid = space.FindSpaceMetadata(spaceGuid).OrgGuid
+	}
+	cd.scopeOrgGuid = orgGuid
+	cd.scopeSpaceGuid = spaceGuid
+}
+
+func (cd *CommonData) GetScope() (orgGuid, spaceGuid string) {
+	return cd.scopeOrgGuid, cd.scopeSpaceGuid
+}
+
+func (cd *CommonData) IsScoped() bool {
+	return cd.scopeOrgGuid != "" || cd.scopeSpaceGuid != ""
+}
+
+func (cd *CommonData) IsSpaceInScope(spaceGuid string) bool {
+	switch {
+	case cd.scopeSpaceGuid != "":
+		return spaceGuid == cd.scopeSpaceGuid
+	case cd.scopeOrgGuid != "":
+		return space.FindSpaceMetadata(spaceGuid).OrgGuid == cd.scopeOrgGuid
+	}
+	return true
+}
+
+// Text describing the active scope, e.g., "Org: myorg Space: dev"
+func (cd *CommonData) ScopeDisplay() string {
+	if !cd.IsScoped() {
+		return ""
+	}
+	orgName := org.FindOrgMetadata(cd.scopeOrgGuid).Name
+	if cd.scopeSpaceGuid == "" {
+		return "Org: " + orgName
+	}
+	return "Org: " + orgName + " Space: " + space.FindSpaceName(cd.scopeSpaceGuid)
+}
+
 func (cd *CommonData) IsMonitoredAppGuid(appGuid string) bool {
 	// Check if we're only monitoring a subset of apps and if this app is one of them
 	if cd.monitoredAppGuids != nil {
@@ -109,29 +279,54 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 
 	appMap := cd.router.GetProcessor().GetDisplayedEventData().AppMap
 	appsNotInDesiredState := 0
+	appsAtMemoryRisk := 0
 	totalCrash1hCount := 0
 	totalCrash24hCount := 0
 
 	for appId, appStats := range appMap {
+		appMetadata := cd.appMdMgr.FindAppMetadata(appStats.AppId)
+		if !cd.IsSpaceInScope(appMetadata.SpaceGuid) {
+			continue
+		}
+
 		displayAppStats := NewDisplayAppStats(appStats)
 
 		displayAppStats.Monitored = cd.IsMonitoredAppGuid(appId)
+		displayAppStats.Note = cd.appNoteMap[appId]
 
 		displayStatsMap[appId] = displayAppStats
-		appMetadata := cd.appMdMgr.FindAppMetadata(appStats.AppId)
 
 		displayAppStats.AppName = appMetadata.Name
 		displayAppStats.SpaceId = appMetadata.SpaceGuid
 
 		spaceMetadata := space.FindSpaceMetadata(appMetadata.SpaceGuid)
 		displayAppStats.SpaceName = spaceMetadata.Name
+		if appMetadata.SpaceName != "" {
+			// Returned inline with app metadata (inline-relations-depth)
+			displayAppStats.SpaceName = appMetadata.SpaceName
+		}
+
+		if appMetadata.OrgName != "" {
+			displayAppStats.OrgId, displayAppStats.OrgName = appMetadata.OrgGuid, appMetadata.OrgName
+		} else {
+			displayAppStats.OrgId, displayAppStats.OrgName = org.FindBySpaceGuid(appMetadata.SpaceGuid)
+		}
 
-		displayAppStats.OrgId, displayAppStats.OrgName = org.FindBySpaceGuid(appMetadata.SpaceGuid)
+		if appStats.LastEventTime.IsZero() {
+			displayAppStats.LastSeenAge = -1
+		} else {
+			displayAppStats.LastSeenAge = statsTime.Sub(appStats.LastEventTime)
+		}
 
 		totalCpuPercentage := 0.0
+		displayCpuPercentage := 0.0
 		totalMemoryUsed := int64(0)
 		totalDiskUsed := int64(0)
 		totalReportingContainers := 0
+		maxContainerMemoryPercent := 0.0
+		reservedMemory := float64(appMetadata.MemoryMB) * util.MEGABYTE
+		memHistory := cd.getMemoryHistory(appId, appMetadata.MemoryMB)
+		var latestMetricTime time.Time
 
 		if appMetadata.State == "STARTED" {
 			displayAppStats.DesiredContainers = int(appMetadata.Instances)
@@ -140,6 +335,22 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 		stack := stack.FindStackMetadata(appMetadata.StackGuid)
 		displayAppStats.StackId = appMetadata.StackGuid
 		displayAppStats.StackName = stack.Name
+		displayAppStats.StartCommand = appMetadata.DetectedStartCmd
+		displayAppStats.SshEnabled = appMetadata.EnableSsh
+		displayAppStats.SshUnknown = appMetadata.SshUnknown
+
+		if !appMetadata.UpdatedAt.IsZero() {
+			updatedTime := appMetadata.UpdatedAt
+			displayAppStats.UpdatedTime = &updatedTime
+			recentWindow := time.Duration(config.RecentDeployMinutes()) * time.Minute
+			displayAppStats.RecentlyDeployed = recentWindow > 0 && statsTime.Sub(updatedTime) < recentWindow
+		}
+
+		if routeCount, loaded := cd.routeMappingMdMgr.RouteCountForApp(appId); loaded {
+			displayAppStats.RouteCount = routeCount
+		} else {
+			displayAppStats.RouteCount = -1
+		}
 
 		isoSeg := isolationSegment.FindMetadata(spaceMetadata.IsolationSegmentGuid)
 		displayAppStats.IsolationSegmentGuid = isoSeg.Guid
@@ -149,10 +360,22 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 		crash1hCount := crashData.FindCountSinceByApp(appId, -1*time.Hour)
 		crash1hCount = crash1hCount + appStats.Crash1hCount()
 
+		// Crash count in the crash filter window (from call to /v2/events)
+		crashRecentWindow := -1 * time.Duration(config.CrashFilterMinutes()) * time.Minute
+		crashRecentCount := crashData.FindCountSinceByApp(appId, crashRecentWindow)
+		crashRecentCount = crashRecentCount + appStats.CrashCountSince(crashRecentWindow)
+
 		// Crash count in last 24 hours (from call to /v2/events)
 		crash24hCount := crashData.FindCountSinceByApp(appId, -24*time.Hour)
 		crash24hCount = crash24hCount + appStats.Crash24hCount()
 
+		// Crash intervals are only checked when there are enough recent crashes
+		crashLooping := false
+		if crash1hCount >= config.CrashLoopMinCrashes {
+			crashInfoList := crashData.MergeCrashInfo(appStats.ContainerCrashInfo, crashData.FindSinceByApp(appId, -24*time.Hour))
+			crashLooping = crashData.IsCrashLooping(crashInfoList, statsTime)
+		}
+
 		for _, containerTraffic := range appStats.ContainerTrafficMap {
 			for _, httpStatusCodeMap := range containerTraffic.HttpInfoMap {
 				for statusCode, httpCountInfo := range httpStatusCodeMap {
@@ -194,47 +417,297 @@ func (cd *CommonData) PostProcessData() map[string]*DisplayAppStats {
 				*/
 
 				totalCpuPercentage = totalCpuPercentage + *cs.ContainerMetric.CpuPercentage
+				numOfCpus := 0
+				if cellStats := eventData.CellMap[cs.Ip]; cellStats != nil {
+					numOfCpus = cellStats.NumOfCpus
+				}
+				displayCpuPercentage = displayCpuPercentage + util.CpuPerCore(*cs.ContainerMetric.CpuPercentage, numOfCpus)
 				totalMemoryUsed = totalMemoryUsed + int64(*cs.ContainerMetric.MemoryBytes)
+				if reservedMemory > 0 {
+					memoryPercent := float64(*cs.ContainerMetric.MemoryBytes) / reservedMemory * 100
+					if memoryPercent > maxContainerMemoryPercent {
+						maxContainerMemoryPercent = memoryPercent
+					}
+				}
 				totalDiskUsed = totalDiskUsed + int64(*cs.ContainerMetric.DiskBytes)
 				totalReportingContainers++
+				memHistory.record(containerIndex, cs, statsTime)
+				if cs.LastUpdate.After(latestMetricTime) {
+					latestMetricTime = cs.LastUpdate
+				}
 			}
 		}
+		displayAppStats.MemoryP95 = memHistory.p95(statsTime)
 		if displayAppStats.Monitored && totalReportingContainers < displayAppStats.DesiredContainers {
 			appsNotInDesiredState = appsNotInDesiredState + 1
 		}
 		if totalReportingContainers > 0 {
 			displayAppStats.TotalCpuPercentage = totalCpuPercentage
+			displayAppStats.DisplayCpuPercentage = displayCpuPercentage
 		} else {
 			// In PCF 1.9 running containers can report 0.00 CPU percent usage
 			// To help distiquish between a container with 0 CPU and no container
 			// at all we set this to a very small negative number to help sort
 			// no-container apps to the bottom when sorting by CPU%
 			displayAppStats.TotalCpuPercentage = -0.0001
+			displayAppStats.DisplayCpuPercentage = -0.0001
 		}
+		displayAppStats.CpuTrend = cd.updateCpuTrend(appId, totalCpuPercentage, totalReportingContainers)
 		displayAppStats.TotalMemoryUsed = totalMemoryUsed
+		cd.updateMemoryTrend(appId, appMetadata.MemoryMB, latestMetricTime, totalReportingContainers, displayAppStats)
+		displayAppStats.MaxContainerMemoryPercent = maxContainerMemoryPercent
+		displayAppStats.MemoryRisk = maxContainerMemoryPercent >= float64(config.MemoryRiskPercent())
+		if displayAppStats.Monitored && displayAppStats.MemoryRisk {
+			appsAtMemoryRisk = appsAtMemoryRisk + 1
+		}
 		displayAppStats.TotalDiskUsed = totalDiskUsed
 		displayAppStats.TotalReportingContainers = totalReportingContainers
 		displayAppStats.Crash1hCount = crash1hCount
 		displayAppStats.Crash24hCount = crash24hCount
+		displayAppStats.CrashRecentCount = crashRecentCount
+		displayAppStats.CrashLooping = crashLooping
 		totalCrash1hCount = totalCrash1hCount + crash1hCount
 		totalCrash24hCount = totalCrash24hCount + crash24hCount
-		/*
-			logStdoutCount := int64(0)
-			logStderrCount := int64(0)
-			for _, cs := range appStats.ContainerArray {
-				if cs != nil {
-					logStdoutCount = logStdoutCount + cs.OutCount
-					logStderrCount = logStderrCount + cs.ErrCount
-				}
+		displayAppStats.HealthScore = displayAppStats.computeHealthScore(cd.isWarmupComplete)
+
+		logStdoutCount := int64(0)
+		logStderrCount := int64(0)
+		for _, cs := range appStats.ContainerArray {
+			if cs != nil {
+				logStdoutCount = logStdoutCount + cs.OutCount
+				logStderrCount = logStderrCount + cs.ErrCount
 			}
-			displayAppStats.TotalLogStdout = logStdoutCount + appStats.NonContainerStdout
-			displayAppStats.TotalLogStderr = logStderrCount + appStats.NonContainerStderr
-		*/
+		}
+		displayAppStats.TotalLogStdout = logStdoutCount + appStats.NonContainerStdout
+		displayAppStats.TotalLogStderr = logStderrCount + appStats.NonContainerStderr
+		cd.updateLogRate(appId, statsTime, displayAppStats)
+	}
+
+	// Forget trend history of apps that are no longer reported
+	for appId := range cd.cpuTrendMap {
+		if appMap[appId] == nil {
+			delete(cd.cpuTrendMap, appId)
+		}
+	}
+	for appId := range cd.logRateMap {
+		if appMap[appId] == nil {
+			delete(cd.logRateMap, appId)
+		}
+	}
+	for appId := range cd.memoryHistoryMap {
+		if appMap[appId] == nil {
+			delete(cd.memoryHistoryMap, appId)
+		}
+	}
+	for appId := range cd.memoryTrendMap {
+		if appMap[appId] == nil {
+			delete(cd.memoryTrendMap, appId)
+		}
 	}
 
 	cd.displayAppStatsMap = displayStatsMap
 	cd.appsNotInDesiredState = appsNotInDesiredState
+	cd.appsAtMemoryRisk = appsAtMemoryRisk
 	cd.totalCrash1hCount = totalCrash1hCount
 	cd.totalCrash24hCount = totalCrash24hCount
 	return displayStatsMap
 }
+
+// Set the stdout / stderr log rates of the app to the number of log events
+// received since the previous display refresh.  Calls made against the same
+// snapshot of event data (same stats time) return the previous rates.  A
+// count lower than the last one (stats were cleared) resets the rate to 0.
+func (cd *CommonData) updateLogRate(appId string, statsTime time.Time, displayAppStats *DisplayAppStats) {
+	stdoutCount := displayAppStats.TotalLogStdout
+	stderrCount := displayAppStats.TotalLogStderr
+
+	rate := cd.logRateMap[appId]
+	if rate == nil {
+		rate = &logRate{statsTime: statsTime, stdoutCount: stdoutCount, stderrCount: stderrCount}
+		cd.logRateMap[appId] = rate
+	} else if !statsTime.Equal(rate.statsTime) {
+		rate.stdoutRate = stdoutCount - rate.stdoutCount
+		if rate.stdoutRate < 0 {
+			rate.stdoutRate = 0
+		}
+		rate.stderrRate = stderrCount - rate.stderrCount
+		if rate.stderrRate < 0 {
+			rate.stderrRate = 0
+		}
+		rate.statsTime = statsTime
+		rate.stdoutCount = stdoutCount
+		rate.stderrCount = stderrCount
+	}
+	displayAppStats.LogStdoutRate = rate.stdoutRate
+	displayAppStats.LogStderrRate = rate.stderrRate
+}
+
+func (cd *CommonData) getMemoryHistory(appId string, reservedMemoryMB float64) *memoryHistory {
+	history := cd.memoryHistoryMap[appId]
+	if history == nil || history.reservedMemoryMB != reservedMemoryMB {
+		history = &memoryHistory{
+			reservedMemoryMB: reservedMemoryMB,
+			containers:       make(map[int]*containerMemoryHistory),
+		}
+		cd.memoryHistoryMap[appId] = history
+	}
+	return history
+}
+
+// Record the memory of a reporting container.  Container metrics only
+// arrive periodically so a sample is only added when a new metric has been
+// received since the last one recorded.
+func (mh *memoryHistory) record(containerIndex int, cs *eventApp.ContainerStats, statsTime time.Time) {
+	ch := mh.containers[containerIndex]
+	if ch == nil {
+		ch = &containerMemoryHistory{}
+		mh.containers[containerIndex] = ch
+	}
+	ch.lastSeen = statsTime
+	if cs.LastUpdate.Equal(ch.lastUpdate) {
+		return
+	}
+	ch.lastUpdate = cs.LastUpdate
+	memoryBytes := cs.ContainerMetric.GetMemoryBytes()
+	if len(ch.samples) < config.MemoryHistorySamples {
+		ch.samples = append(ch.samples, memoryBytes)
+		return
+	}
+	ch.samples[ch.next] = memoryBytes
+	ch.next = (ch.next + 1) % config.MemoryHistorySamples
+}
+
+// Forget containers that did not report in the refresh at statsTime (stopped,
+// crashed or stale) and return the 95th percentile of the remaining samples
+func (mh *memoryHistory) p95(statsTime time.Time) int64 {
+	samples := make([]float64, 0)
+	for containerIndex, ch := range mh.containers {
+		if !ch.lastSeen.Equal(statsTime) {
+			delete(mh.containers, containerIndex)
+			continue
+		}
+		for _, sample := range ch.samples {
+			samples = append(samples, float64(sample))
+		}
+	}
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Float64s(samples)
+	index := int(math.Ceil(0.95*float64(len(samples)))) - 1
+	return int64(samples[index])
+}
+
+// Record the total memory used by the app and set the memory slope and leak
+// flag (see memoryLeakState).
+func (cd *CommonData) updateMemoryTrend(appId string, reservedMemoryMB float64, metricTime time.Time, reportingContainers int, displayAppStats *DisplayAppStats) {
+	trend := cd.memoryTrendMap[appId]
+	if trend == nil || trend.reportingContainers != reportingContainers || trend.reservedMemoryMB != reservedMemoryMB {
+		trend = &memoryTrend{reportingContainers: reportingContainers, reservedMemoryMB: reservedMemoryMB}
+		cd.memoryTrendMap[appId] = trend
+	}
+	if reportingContainers == 0 {
+		return
+	}
+
+	trend.add(metricTime, float64(displayAppStats.TotalMemoryUsed))
+	displayAppStats.MemorySlope, displayAppStats.MemorySlopeKnown, displayAppStats.MemoryLeakSuspect = trend.memoryLeakState()
+}
+
+// Add a sample when at least config.MemoryTrendSampleSeconds of metric time
+// has passed since the last one recorded.  A multi-instance app receives a
+// newer container metric on almost every refresh so sampling each one would
+// shrink the window below config.MemoryLeakMinMinutes.
+func (trend *memoryTrend) add(metricTime time.Time, memoryBytes float64) {
+	samplesLen := len(trend.samples)
+	if samplesLen > 0 && metricTime.Sub(trend.samples[samplesLen-1].metricTime) < config.MemoryTrendSampleSeconds*time.Second {
+		return
+	}
+	trend.samples = append(trend.samples, memorySample{metricTime: metricTime, memoryBytes: memoryBytes})
+	if len(trend.samples) > config.MemoryTrendSamples {
+		trend.samples = trend.samples[1:]
+	}
+}
+
+// Memory slope in bytes per hour and the leak flag.  The slope is only
+// known once there are config.MemoryLeakMinSamples covering at least
+// config.MemoryLeakMinMinutes.  An app is a leak suspect when memory grows
+// over the full window at or over the threshold and is still growing over
+// the most recent half of the window.
+func (trend *memoryTrend) memoryLeakState() (slope float64, known bool, suspect bool) {
+	samplesLen := len(trend.samples)
+	if samplesLen < config.MemoryLeakMinSamples {
+		return 0, false, false
+	}
+	span := trend.samples[samplesLen-1].metricTime.Sub(trend.samples[0].metricTime)
+	if span < time.Duration(config.MemoryLeakMinMinutes)*time.Minute {
+		return 0, false, false
+	}
+
+	slope = memorySlopePerHour(trend.samples)
+
+	threshold := config.MemoryLeakMinMBPerHour * util.MEGABYTE
+	if trend.reservedMemoryMB > 0 {
+		totalReserved := trend.reservedMemoryMB * util.MEGABYTE * float64(trend.reportingContainers)
+		threshold = totalReserved * config.MemoryLeakPercentPerHour / 100
+	}
+	suspect = slope >= threshold && memorySlopePerHour(trend.samples[samplesLen/2:]) > 0
+	return slope, true, suspect
+}
+
+// Least squares slope of memory over metric time in bytes per hour
+func memorySlopePerHour(samples []memorySample) float64 {
+	n := float64(len(samples))
+	if n < 2 {
+		return 0
+	}
+	start := samples[0].metricTime
+	sumX, sumY, sumXY, sumXX := 0.0, 0.0, 0.0, 0.0
+	for _, sample := range samples {
+		x := sample.metricTime.Sub(start).Hours()
+		sumX += x
+		sumY += sample.memoryBytes
+		sumXY += x * sample.memoryBytes
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// Record the aggregate CPU for the app and return the trend direction.
+// Container metrics only arrive periodically so a new sample is only
+// recorded when the value changes.  History is reset when the number of
+// reporting containers changes as the aggregate is no longer comparable.
+func (cd *CommonData) updateCpuTrend(appId string, totalCpuPercentage float64, reportingContainers int) int {
+	trend := cd.cpuTrendMap[appId]
+	if trend == nil || trend.reportingContainers != reportingContainers {
+		trend = &cpuTrend{reportingContainers: reportingContainers}
+		cd.cpuTrendMap[appId] = trend
+	}
+	if reportingContainers == 0 {
+		return 0
+	}
+
+	samplesLen := len(trend.samples)
+	if samplesLen == 0 || trend.samples[samplesLen-1] != totalCpuPercentage {
+		trend.samples = append(trend.samples, totalCpuPercentage)
+		if len(trend.samples) > config.CpuTrendSamples {
+			trend.samples = trend.samples[1:]
+		}
+	}
+
+	if len(trend.samples) < 2 {
+		return 0
+	}
+	delta := trend.samples[len(trend.samples)-1] - trend.samples[0]
+	switch {
+	case delta >= config.CpuTrendThreshold:
+		return 1
+	case delta <= -config.CpuTrendThreshold:
+		return -1
+	}
+	return 0
+}
@@ -18,9 +18,18 @@ package dataCommon
 import (
 	"time"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 )
 
+type HealthLevel int
+
+const (
+	HEALTH_GOOD HealthLevel = iota
+	HEALTH_WARN
+	HEALTH_BAD
+)
+
 type DisplayAppStats struct {
 	*eventApp.AppStats
 
@@ -34,6 +43,18 @@ type DisplayAppStats struct {
 	StackName            string
 	IsolationSegmentGuid string
 	IsolationSegmentName string
+	StartCommand         string
+	SshEnabled           bool
+	// SSH state not reported by the CC API in use (v3)
+	SshUnknown bool
+	// Number of routes mapped to the app, -1 if route mappings not loaded
+	RouteCount int
+	// App metadata updated_at (nil if not known) and if that is within
+	// config.RecentDeployMinutes
+	UpdatedTime      *time.Time
+	RecentlyDeployed bool
+	// Session note / tag attached by the user (see CommonData.SetAppNote)
+	Note string
 
 	// Indicate if this app is monitored.  For privileged users
 	// this should always be true.
@@ -42,8 +63,28 @@ type DisplayAppStats struct {
 	//TotalTraffic *eventdata.TrafficStats
 
 	TotalCpuPercentage float64
-	TotalMemoryUsed    int64
-	TotalDiskUsed      int64
+	// TotalCpuPercentage normalized per cell CPU when enabled (see config.IsCpuPerCore)
+	DisplayCpuPercentage float64
+	TotalMemoryUsed      int64
+	TotalDiskUsed        int64
+	// Highest percent of reserved memory used by any reporting container
+	// (0 if reserved memory is not known)
+	MaxContainerMemoryPercent float64
+	// MaxContainerMemoryPercent is at or over config.MemoryRiskPercent
+	MemoryRisk bool
+	// 95th percentile of container memory used over the recent samples
+	// of all containers (see config.MemoryHistorySamples), 0 if no samples
+	MemoryP95 int64
+	// Linear regression slope of total memory used in bytes per hour
+	// (see config.MemoryTrendSamples), only valid when MemorySlopeKnown
+	MemorySlope      float64
+	MemorySlopeKnown bool
+	// Memory has been growing persistently at or over
+	// config.MemoryLeakPercentPerHour of reserved memory
+	MemoryLeakSuspect bool
+
+	// 1 trending up, -1 trending down, 0 flat / not enough samples
+	CpuTrend int
 
 	TotalReportingContainers int
 	TotalLogStdout           int64
@@ -51,6 +92,17 @@ type DisplayAppStats struct {
 	Crash1hCount             int
 	Crash24hCount            int
 	LastCrashTime            *time.Time
+	// Crash count within config.CrashFilterMinutes
+	CrashRecentCount int
+	// An instance is crashing again shortly after each restart
+	// (see crashData.IsCrashLooping)
+	CrashLooping bool
+	// Log events received since the previous display refresh
+	LogStdoutRate int64
+	LogStderrRate int64
+	// Time since last event of any type was seen for this app.
+	// A negative value indicates no event has been seen yet.
+	LastSeenAge time.Duration
 
 	// Summerize HTTP response codes
 	HttpAllCount int64
@@ -58,6 +110,9 @@ type DisplayAppStats struct {
 	Http3xxCount int64
 	Http4xxCount int64
 	Http5xxCount int64
+
+	// 0-100 where 100 is healthy.  See computeHealthScore
+	HealthScore int
 }
 
 func NewDisplayAppStats(appStats *eventApp.AppStats) *DisplayAppStats {
@@ -65,3 +120,46 @@ func NewDisplayAppStats(appStats *eventApp.AppStats) *DisplayAppStats {
 	stats.AppStats = appStats
 	return stats
 }
+
+func (das *DisplayAppStats) HealthLevel() HealthLevel {
+	switch {
+	case das.HealthScore < config.HealthScoreBad():
+		return HEALTH_BAD
+	case das.HealthScore < config.HealthScoreWarn():
+		return HEALTH_WARN
+	}
+	return HEALTH_GOOD
+}
+
+// Average CPU percent of the reporting containers, used for the mini-bar
+// display.  Uses DisplayCpuPercentage so per-core normalization applies.
+func (das *DisplayAppStats) AvgContainerCpuPercentage() float64 {
+	if das.TotalReportingContainers == 0 {
+		return 0
+	}
+	return das.DisplayCpuPercentage / float64(das.TotalReportingContainers)
+}
+
+// Score is reduced by crashes in the last hour, desired instances that
+// are not reporting and the percent of HTTP responses that are 5xx.
+// Instance shortfall is only counted after warm-up as container metrics
+// may not have been received yet.
+func (das *DisplayAppStats) computeHealthScore(isWarmupComplete bool) int {
+	penalty := float64(das.Crash1hCount * config.HealthCrashPenalty())
+
+	if isWarmupComplete && das.Monitored && das.DesiredContainers > 0 &&
+		das.TotalReportingContainers < das.DesiredContainers {
+		missing := das.DesiredContainers - das.TotalReportingContainers
+		penalty += float64(config.HealthMissingInstancePenalty()) * float64(missing) / float64(das.DesiredContainers)
+	}
+
+	if das.HttpAllCount > 0 {
+		penalty += float64(config.HealthHttp5xxPenalty()) * float64(das.Http5xxCount) / float64(das.HttpAllCount)
+	}
+
+	score := 100 - int(penalty+0.5)
+	if score < 0 {
+		score = 0
+	}
+	return score
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataCommon
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health score", func() {
+	var stats *DisplayAppStats
+
+	BeforeEach(func() {
+		stats = NewDisplayAppStats(eventApp.NewAppStats("app-1"))
+		stats.Monitored = true
+		stats.DesiredContainers = 4
+		stats.TotalReportingContainers = 4
+	})
+
+	AfterEach(func() {
+		config.SetHealthScoreThresholds(config.DefaultHealthScoreWarn, config.DefaultHealthScoreBad)
+		config.SetHealthPenalties(config.DefaultHealthCrashPenalty, config.DefaultHealthMissingInstancePenalty, config.DefaultHealthHttp5xxPenalty)
+	})
+
+	It("is 100 for a healthy app", func() {
+		stats.HealthScore = stats.computeHealthScore(true)
+		Expect(stats.HealthScore).To(Equal(100))
+		Expect(stats.HealthLevel()).To(Equal(HEALTH_GOOD))
+	})
+
+	It("applies the default penalties", func() {
+		stats.Crash1hCount = 1
+		stats.TotalReportingContainers = 3
+		stats.HttpAllCount = 100
+		stats.Http5xxCount = 5
+		// 25 + 100 * 1/4 + 300 * 5/100
+		Expect(stats.computeHealthScore(true)).To(Equal(35))
+	})
+
+	It("does not count missing instances during warm-up", func() {
+		stats.TotalReportingContainers = 0
+		Expect(stats.computeHealthScore(false)).To(Equal(100))
+	})
+
+	It("applies the configured penalties", func() {
+		config.SetHealthPenalties(10, 0, 100)
+		stats.Crash1hCount = 2
+		stats.TotalReportingContainers = 0
+		stats.HttpAllCount = 10
+		stats.Http5xxCount = 1
+		Expect(stats.computeHealthScore(true)).To(Equal(70))
+	})
+
+	It("uses the configured thresholds", func() {
+		config.SetHealthScoreThresholds(90, 70)
+		stats.HealthScore = 85
+		Expect(stats.HealthLevel()).To(Equal(HEALTH_WARN))
+		stats.HealthScore = 69
+		Expect(stats.HealthLevel()).To(Equal(HEALTH_BAD))
+		stats.HealthScore = 90
+		Expect(stats.HealthLevel()).To(Equal(HEALTH_GOOD))
+	})
+})
@@ -0,0 +1,106 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataCommon
+
+import (
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/gogo/protobuf/proto"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Memory history", func() {
+	start := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var history *memoryHistory
+
+	containerStats := func(containerIndex int, lastUpdate time.Time, memoryBytes uint64) *eventApp.ContainerStats {
+		cs := eventApp.NewContainerStats(containerIndex)
+		cs.LastUpdate = lastUpdate
+		cs.ContainerMetric = &events.ContainerMetric{MemoryBytes: proto.Uint64(memoryBytes)}
+		return cs
+	}
+
+	// Record memoryBytes of each refresh (one second apart) for one container
+	recordRefreshes := func(containerIndex int, memoryBytes ...uint64) time.Time {
+		statsTime := start
+		for i, bytes := range memoryBytes {
+			statsTime = start.Add(time.Duration(i) * time.Second)
+			history.record(containerIndex, containerStats(containerIndex, statsTime, bytes), statsTime)
+		}
+		return statsTime
+	}
+
+	sequence := func(from, to uint64) []uint64 {
+		values := make([]uint64, 0, to-from+1)
+		for value := from; value <= to; value++ {
+			values = append(values, value)
+		}
+		return values
+	}
+
+	BeforeEach(func() {
+		history = &memoryHistory{reservedMemoryMB: 512, containers: make(map[int]*containerMemoryHistory)}
+	})
+
+	It("is 0 with no samples", func() {
+		Expect(history.p95(start)).To(BeZero())
+	})
+
+	It("is the 95th percentile of the samples of all containers", func() {
+		statsTime := start
+		for i := uint64(0); i < 50; i++ {
+			statsTime = start.Add(time.Duration(i) * time.Second)
+			history.record(0, containerStats(0, statsTime, 2*i+1), statsTime)
+			history.record(1, containerStats(1, statsTime, 2*i+2), statsTime)
+		}
+		Expect(history.p95(statsTime)).To(Equal(int64(95)))
+	})
+
+	It("is the only sample of a single sample", func() {
+		statsTime := recordRefreshes(0, 300)
+		Expect(history.p95(statsTime)).To(Equal(int64(300)))
+	})
+
+	It("does not add a sample until a new container metric arrives", func() {
+		metricTime := start
+		for i := 0; i < 19; i++ {
+			history.record(0, containerStats(0, metricTime, 10), start.Add(time.Duration(i)*time.Second))
+		}
+		statsTime := start.Add(20 * time.Second)
+		history.record(0, containerStats(0, statsTime, 1000), statsTime)
+		Expect(history.containers[0].samples).To(HaveLen(2))
+		Expect(history.p95(statsTime)).To(Equal(int64(1000)))
+	})
+
+	It("keeps only the latest samples of a container", func() {
+		statsTime := recordRefreshes(0, sequence(1, config.MemoryHistorySamples+10)...)
+		Expect(history.containers[0].samples).To(HaveLen(config.MemoryHistorySamples))
+		// Samples 11 to 70 remain, the 57th of the 60 is 67
+		Expect(history.p95(statsTime)).To(Equal(int64(67)))
+	})
+
+	It("forgets containers that did not report at the refresh", func() {
+		recordRefreshes(1, 5000)
+		statsTime := recordRefreshes(0, sequence(1, 20)...)
+		Expect(history.p95(statsTime)).To(Equal(int64(19)))
+		Expect(history.containers).NotTo(HaveKey(1))
+	})
+})
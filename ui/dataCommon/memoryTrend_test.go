@@ -0,0 +1,160 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataCommon
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Memory trend", func() {
+	start := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Samples every interval growing by bytesPerHour
+	linearSamples := func(count int, interval time.Duration, startBytes, bytesPerHour float64) []memorySample {
+		samples := make([]memorySample, 0, count)
+		for i := 0; i < count; i++ {
+			offset := time.Duration(i) * interval
+			samples = append(samples, memorySample{
+				metricTime:  start.Add(offset),
+				memoryBytes: startBytes + bytesPerHour*offset.Hours(),
+			})
+		}
+		return samples
+	}
+
+	Describe("memorySlopePerHour", func() {
+		It("is 0 with fewer than 2 samples", func() {
+			Expect(memorySlopePerHour(nil)).To(Equal(0.0))
+			Expect(memorySlopePerHour(linearSamples(1, time.Minute, 100, 1000))).To(Equal(0.0))
+		})
+
+		It("is 0 when all samples have the same time", func() {
+			samples := linearSamples(3, 0, 100, 1000)
+			Expect(memorySlopePerHour(samples)).To(Equal(0.0))
+		})
+
+		It("is the growth per hour of linear samples", func() {
+			samples := linearSamples(20, 30*time.Second, 200*util.MEGABYTE, 60*util.MEGABYTE)
+			Expect(memorySlopePerHour(samples)).To(BeNumerically("~", 60*util.MEGABYTE, 1))
+		})
+
+		It("is negative when memory shrinks", func() {
+			samples := linearSamples(20, 30*time.Second, 200*util.MEGABYTE, -30*util.MEGABYTE)
+			Expect(memorySlopePerHour(samples)).To(BeNumerically("~", -30*util.MEGABYTE, 1))
+		})
+
+		It("is 0 for flat memory", func() {
+			samples := linearSamples(20, 30*time.Second, 200*util.MEGABYTE, 0)
+			Expect(memorySlopePerHour(samples)).To(BeNumerically("~", 0, 0.001))
+		})
+	})
+
+	Describe("add", func() {
+		It("records at most one sample per MemoryTrendSampleSeconds", func() {
+			trend := &memoryTrend{reportingContainers: 8}
+			// A multi-instance app gets a newer container metric every second
+			for i := 0; i < 120; i++ {
+				trend.add(start.Add(time.Duration(i)*time.Second), float64(i))
+			}
+			Expect(trend.samples).To(HaveLen(120 / config.MemoryTrendSampleSeconds))
+		})
+
+		It("keeps the last MemoryTrendSamples samples", func() {
+			trend := &memoryTrend{reportingContainers: 1}
+			for i := 0; i < config.MemoryTrendSamples+5; i++ {
+				trend.add(start.Add(time.Duration(i)*time.Minute), float64(i))
+			}
+			Expect(trend.samples).To(HaveLen(config.MemoryTrendSamples))
+			Expect(trend.samples[0].memoryBytes).To(Equal(5.0))
+		})
+
+		It("covers MemoryLeakMinMinutes with container metrics every second", func() {
+			trend := &memoryTrend{reportingContainers: 10}
+			for i := 0; i < config.MemoryLeakMinMinutes*60+30; i++ {
+				trend.add(start.Add(time.Duration(i)*time.Second), float64(i))
+			}
+			_, known, _ := trend.memoryLeakState()
+			Expect(known).To(BeTrue())
+		})
+	})
+
+	Describe("memoryLeakState", func() {
+		interval := config.MemoryTrendSampleSeconds * time.Second
+		minSpanSamples := config.MemoryLeakMinMinutes*60/config.MemoryTrendSampleSeconds + 1
+
+		It("is not known with fewer than MemoryLeakMinSamples", func() {
+			trend := &memoryTrend{reportingContainers: 1, reservedMemoryMB: 512,
+				samples: linearSamples(config.MemoryLeakMinSamples-1, time.Minute, 100*util.MEGABYTE, 500*util.MEGABYTE)}
+			_, known, suspect := trend.memoryLeakState()
+			Expect(known).To(BeFalse())
+			Expect(suspect).To(BeFalse())
+		})
+
+		It("is not known before the samples span MemoryLeakMinMinutes", func() {
+			trend := &memoryTrend{reportingContainers: 1, reservedMemoryMB: 512,
+				samples: linearSamples(minSpanSamples-1, interval, 100*util.MEGABYTE, 500*util.MEGABYTE)}
+			_, known, _ := trend.memoryLeakState()
+			Expect(known).To(BeFalse())
+		})
+
+		It("flags growth at or over the percent of total reserved memory", func() {
+			// 2 x 512MB reserved, 10% per hour is 102.4MB per hour
+			trend := &memoryTrend{reportingContainers: 2, reservedMemoryMB: 512,
+				samples: linearSamples(minSpanSamples, interval, 300*util.MEGABYTE, 110*util.MEGABYTE)}
+			slope, known, suspect := trend.memoryLeakState()
+			Expect(known).To(BeTrue())
+			Expect(slope).To(BeNumerically("~", 110*util.MEGABYTE, 1))
+			Expect(suspect).To(BeTrue())
+		})
+
+		It("does not flag growth under the threshold", func() {
+			trend := &memoryTrend{reportingContainers: 2, reservedMemoryMB: 512,
+				samples: linearSamples(minSpanSamples, interval, 300*util.MEGABYTE, 90*util.MEGABYTE)}
+			_, known, suspect := trend.memoryLeakState()
+			Expect(known).To(BeTrue())
+			Expect(suspect).To(BeFalse())
+		})
+
+		It("uses MemoryLeakMinMBPerHour when reserved memory is unknown", func() {
+			over := &memoryTrend{reportingContainers: 1,
+				samples: linearSamples(minSpanSamples, interval, 300*util.MEGABYTE, (config.MemoryLeakMinMBPerHour+5)*util.MEGABYTE)}
+			under := &memoryTrend{reportingContainers: 1,
+				samples: linearSamples(minSpanSamples, interval, 300*util.MEGABYTE, (config.MemoryLeakMinMBPerHour-5)*util.MEGABYTE)}
+			_, _, overSuspect := over.memoryLeakState()
+			_, _, underSuspect := under.memoryLeakState()
+			Expect(overSuspect).To(BeTrue())
+			Expect(underSuspect).To(BeFalse())
+		})
+
+		It("does not flag memory that grew but is shrinking over the recent half", func() {
+			samples := linearSamples(minSpanSamples*2, interval, 300*util.MEGABYTE, 400*util.MEGABYTE)
+			half := len(samples) / 2
+			for i := half; i < len(samples); i++ {
+				samples[i].memoryBytes = samples[half].memoryBytes - float64(i-half)*util.MEGABYTE
+			}
+			trend := &memoryTrend{reportingContainers: 1, reservedMemoryMB: 512, samples: samples}
+			slope, known, suspect := trend.memoryLeakState()
+			Expect(known).To(BeTrue())
+			Expect(slope).To(BeNumerically(">", 0))
+			Expect(suspect).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,54 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+)
+
+// Event types the header and common stats need whichever views are open:
+// container and HTTP totals, cell totals (ValueMetric) and the dropped
+// message warning (CounterEvent)
+var baseEventTypes = []events.Envelope_EventType{
+	events.Envelope_ContainerMetric,
+	events.Envelope_HttpStartStop,
+	events.Envelope_ValueMetric,
+	events.Envelope_CounterEvent,
+}
+
+type eventTypesView interface {
+	GetEventTypes() []events.Envelope_EventType
+}
+
+// Tell the event processor which event types the open views use so the
+// others are discarded on arrival.  A view that does not declare its event
+// types needs all of them.
+func (mui *MasterUI) updateNeededEventTypes() {
+	needed := append([]events.Envelope_EventType{}, baseEventTypes...)
+	for _, m := range mui.layoutManager.Managers() {
+		if _, ok := m.(masterUIInterface.UpdatableView); !ok {
+			continue
+		}
+		view, ok := m.(eventTypesView)
+		if !ok || view.GetEventTypes() == nil {
+			needed = nil
+			break
+		}
+		needed = append(needed, view.GetEventTypes()...)
+	}
+	mui.router.GetProcessor().SetNeededEventTypes(needed)
+}
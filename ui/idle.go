@@ -0,0 +1,125 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/jroimartin/gocui"
+)
+
+// Idle detection: after config.IdleTimeoutMinutes without a key press the
+// display (and optionally event ingestion) is paused.  The next key press
+// resumes and is otherwise ignored.  While paused an overlay view has the
+// focus so keys without a keybinding also reach it (through its editor).
+// The firehose stays connected while paused, pausing ingestion only
+// discards the events received.
+
+const idleViewName = "idleView"
+
+const idleMessage = " Paused (idle) - press any key to resume "
+
+type idleWidget struct {
+	mui *MasterUI
+}
+
+func (w *idleWidget) Name() string {
+	return idleViewName
+}
+
+func (w *idleWidget) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	x0 := (maxX - len(idleMessage)) / 2
+	y0 := maxY/2 - 1
+	v, err := g.SetView(idleViewName, x0, y0, x0+len(idleMessage)+1, y0+2)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Editable = true
+		v.Editor = gocui.EditorFunc(w.edit)
+		fmt.Fprint(v, idleMessage)
+	}
+	return nil
+}
+
+// Any key without a keybinding
+func (w *idleWidget) edit(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	w.mui.keyPressActivity(w.mui.gui)
+}
+
+func (mui *MasterUI) initIdleDetection() {
+	mui.lastKeyPressTime = clock.Now()
+	keybinding.SetActivityListener(mui.keyPressActivity)
+}
+
+// Called on every key press.  Returns true if the key press was consumed
+// to resume from idle.
+func (mui *MasterUI) keyPressActivity(g *gocui.Gui) bool {
+	mui.lastKeyPressTime = clock.Now()
+	if !mui.idlePaused {
+		return false
+	}
+	mui.resumeFromIdle(g)
+	return true
+}
+
+func (mui *MasterUI) IsIdlePaused() bool {
+	return mui.idlePaused
+}
+
+// Called on each display update cycle
+func (mui *MasterUI) checkIdle(g *gocui.Gui) {
+	minutes := config.IdleTimeoutMinutes()
+	if minutes <= 0 || config.IsKioskMode() || mui.idlePaused || mui.displayPaused {
+		return
+	}
+	timeout := time.Duration(minutes) * time.Minute
+	if clock.Since(mui.lastKeyPressTime) < timeout {
+		return
+	}
+	toplog.Info("No key press for %v, pausing display (idle)", timeout)
+	mui.idlePaused = true
+	if config.IsIdlePauseIngest() {
+		toplog.Info("Event ingestion paused (idle)")
+		mui.router.SetIngestPaused(true)
+	}
+	mui.SetDisplayPaused(true)
+	mui.layoutManager.Add(&idleWidget{mui: mui})
+	if err := mui.SetCurrentViewOnTop(g); err != nil {
+		toplog.Error("Idle view error: %v", err)
+	}
+}
+
+func (mui *MasterUI) resumeFromIdle(g *gocui.Gui) {
+	toplog.Info("Key press detected, resuming from idle")
+	mui.idlePaused = false
+	mui.router.SetIngestPaused(false)
+	mui.SetDisplayPaused(false)
+	if mui.layoutManager.ContainsViewName(idleViewName) {
+		if err := mui.CloseViewByName(idleViewName); err != nil {
+			toplog.Error("Idle view error: %v", err)
+		}
+	}
+	if err := mui.currentDataView.RefreshDisplay(g); err != nil {
+		toplog.Error("Resume from idle error: %v", err)
+	}
+}
@@ -24,6 +24,7 @@ type LayoutManagerInterface interface {
 	AddToBack(Manager)
 	Remove(Manager) Manager
 	Top() Manager
+	Managers() []Manager
 	GetManagerByViewName(viewName string) Manager
 	RemoveByName(managerViewNameToRemove string) Manager
 }
@@ -0,0 +1,145 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keybinding
+
+import (
+	"fmt"
+	"sync"
+	"unicode"
+
+	"github.com/jroimartin/gocui"
+)
+
+// Binding is a keybinding registered via Set, used to build the
+// keybinding help overlay
+type Binding struct {
+	ViewName    string
+	Key         interface{}
+	Mod         gocui.Modifier
+	Description string
+}
+
+var (
+	mu sync.Mutex
+	// Key: viewName ("" is global bindings)
+	bindingsByView = make(map[string][]*Binding)
+	// Called before every keybinding handler (see SetActivityListener)
+	activityListener func(g *gocui.Gui) bool
+)
+
+// SetActivityListener registers a func called on every key press handled by
+// a keybinding set through Set.  If the listener returns true the key press
+// is consumed and the keybinding handler is not called.
+func SetActivityListener(listener func(g *gocui.Gui) bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	activityListener = listener
+}
+
+func notifyActivity(handler func(*gocui.Gui, *gocui.View) error) func(*gocui.Gui, *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		mu.Lock()
+		listener := activityListener
+		mu.Unlock()
+		if listener != nil && listener(g) {
+			return nil
+		}
+		return handler(g, v)
+	}
+}
+
+// Set registers the keybinding with gocui and records it in the registry
+// so it is listed in the keybinding help overlay.  All keybindings
+// should be set through here instead of calling g.SetKeybinding directly.
+func Set(g *gocui.Gui, viewName string, key interface{}, mod gocui.Modifier,
+	handler func(*gocui.Gui, *gocui.View) error, description string) error {
+
+	if err := g.SetKeybinding(viewName, key, mod, notifyActivity(handler)); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	binding := &Binding{ViewName: viewName, Key: key, Mod: mod, Description: description}
+	bindings := bindingsByView[viewName]
+	for i, existing := range bindings {
+		if existing.Key == key && existing.Mod == mod {
+			bindings[i] = binding
+			return nil
+		}
+	}
+	bindingsByView[viewName] = append(bindings, binding)
+	return nil
+}
+
+// Delete removes all keybindings of the view from gocui and the registry
+func Delete(g *gocui.Gui, viewName string) {
+	g.DeleteKeybindings(viewName)
+	mu.Lock()
+	defer mu.Unlock()
+	delete(bindingsByView, viewName)
+}
+
+// ForView returns the keybindings of the view in the order they were set
+func ForView(viewName string) []*Binding {
+	mu.Lock()
+	defer mu.Unlock()
+	bindings := make([]*Binding, len(bindingsByView[viewName]))
+	copy(bindings, bindingsByView[viewName])
+	return bindings
+}
+
+var keyNames = map[gocui.Key]string{
+	gocui.KeyEnter:      "ENTER",
+	gocui.KeyEsc:        "ESC",
+	gocui.KeySpace:      "SPACE",
+	gocui.KeyTab:        "TAB",
+	gocui.KeyArrowUp:    "UP",
+	gocui.KeyArrowDown:  "DOWN",
+	gocui.KeyArrowLeft:  "LEFT",
+	gocui.KeyArrowRight: "RIGHT",
+	gocui.KeyPgup:       "PGUP",
+	gocui.KeyPgdn:       "PGDN",
+	gocui.KeyHome:       "HOME",
+	gocui.KeyEnd:        "END",
+	gocui.KeyDelete:     "DELETE",
+	gocui.KeyBackspace:  "BACKSPACE",
+	gocui.KeyBackspace2: "BACKSPACE",
+	gocui.KeyCtrlC:      "ctrl-C",
+}
+
+// KeyName is the display name of a key, e.g., "x", "shift-D", "alt-LEFT"
+func KeyName(key interface{}, mod gocui.Modifier) string {
+	name := ""
+	switch k := key.(type) {
+	case rune:
+		name = string(k)
+		if unicode.IsUpper(k) {
+			name = "shift-" + name
+		}
+	case gocui.Key:
+		name = keyNames[k]
+		if name == "" {
+			name = fmt.Sprintf("key(%v)", k)
+		}
+	default:
+		name = fmt.Sprintf("%v", key)
+	}
+	if mod == gocui.ModAlt {
+		name = "alt-" + name
+	}
+	return name
+}
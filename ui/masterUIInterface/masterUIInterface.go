@@ -43,7 +43,12 @@ type MasterUIInterface interface {
 	GetCommonData() *dataCommon.CommonData
 	GetDisplayPaused() bool
 	SetDisplayPaused(paused bool)
+	IsIdlePaused() bool
+	IsRecording() bool
 	GetTargetDisplay() string
+	GetUsername() string
+	SetFollowCallback(callback func(g *gocui.Gui) error)
+	IsFollowMode() bool
 }
 
 type UpdatableView interface {
@@ -21,36 +21,50 @@ import (
 	"log"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ansel1/merry"
 	termbox "github.com/nsf/termbox-go"
 
 	"github.com/cloudfoundry/cli/plugin"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/shutdown"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/recorder"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/aboutView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/alertView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/topTalkersView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/capacityPlanView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellHealthView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/eventRateHistoryView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/eventViews/eventView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/headerView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/orgSpaceViews/orgView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/routeViews/routeView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
 const DefaultRefreshInternalMS = 1000
 const HELP_TEXT_VIEW_NAME = "helpTextTipsView"
+const KEYBINDING_HELP_VIEW_NAME = "keybindingHelpView"
 
 type MasterUI struct {
 	layoutManager  *uiCommon.LayoutManager
@@ -78,6 +92,25 @@ type MasterUI struct {
 	helpTextTipsViewSize int
 
 	displayMenuId string
+
+	// Called on each display update (when not paused) before the current
+	// data view is updated.  Used by app list follow mode.
+	followCallback func(g *gocui.Gui) error
+
+	// Screen recording, nil until first started (see toggleRecordAction)
+	recorder *recorder.Recorder
+	// Set by updateDisplay so the frame it draws is recorded (see recordLayout)
+	recordFramePending bool
+
+	// Idle detection (see idle.go)
+	lastKeyPressTime time.Time
+	idlePaused       bool
+
+	// Org/space scope requested at startup (applied once commonData exists)
+	initialScopeOrgGuid   string
+	initialScopeSpaceGuid string
+	// View opened at startup (see config.StartViewNames)
+	startView string
 }
 
 func NewMasterUI(cliConnection plugin.CliConnection, pluginMetadata *plugin.PluginMetadata, privileged bool) *MasterUI {
@@ -141,6 +174,33 @@ func (mui *MasterUI) GetTargetDisplay() string {
 	return mui.targetDisplay
 }
 
+// Logged in CF user, "UNKNOWN" if it could not be determined
+func (mui *MasterUI) GetUsername() string {
+	return mui.username
+}
+
+// Set (or clear with nil) the callback invoked on each display update
+// before the current data view is updated
+func (mui *MasterUI) SetFollowCallback(callback func(g *gocui.Gui) error) {
+	mui.followCallback = callback
+}
+
+func (mui *MasterUI) IsFollowMode() bool {
+	return mui.followCallback != nil
+}
+
+// SetInitialScope sets the org/space scope that is applied when the UI starts
+func (mui *MasterUI) SetInitialScope(orgGuid, spaceGuid string) {
+	mui.initialScopeOrgGuid = orgGuid
+	mui.initialScopeSpaceGuid = spaceGuid
+}
+
+// SetStartView sets the view opened when the UI starts, one of
+// config.StartViewNames.  Default is the app list.
+func (mui *MasterUI) SetStartView(startView string) {
+	mui.startView = startView
+}
+
 func (mui *MasterUI) Start(monitoredAppGuids map[string]bool) {
 	mui.router.GetProcessor().Start()
 	mui.initGui(monitoredAppGuids)
@@ -154,10 +214,19 @@ func (mui *MasterUI) initGui(monitoredAppGuids map[string]bool) {
 	}
 	mui.gui = g
 	g.InputEsc = true
-	defer g.Close()
+	// Terminal is restored as the last shutdown step.  The deferred close
+	// covers exits that do not get as far as running the shutdown steps.
+	var closeOnce sync.Once
+	closeGui := func() error {
+		closeOnce.Do(g.Close)
+		return nil
+	}
+	defer closeGui()
+	shutdown.Register("recorder", mui.stopRecording)
+	shutdown.Register("terminal", closeGui)
 
 	mui.layoutManager = uiCommon.NewLayoutManager()
-	g.SetManager(mui.layoutManager)
+	g.SetManager(mui.layoutManager, gocui.ManagerFunc(mui.recordLayout))
 
 	toplog.InitDebug(g, mui)
 
@@ -166,6 +235,7 @@ func (mui *MasterUI) initGui(monitoredAppGuids map[string]bool) {
 	mui.layoutManager.Add(helpTextTipsView)
 
 	mui.commonData = dataCommon.NewCommonData(mui.router, monitoredAppGuids)
+	mui.commonData.SetScope(mui.initialScopeOrgGuid, mui.initialScopeSpaceGuid)
 
 	mui.alertManager = alertView.NewAlertManager(mui, mui.commonData)
 	//mui.baseHeaderSize = 3
@@ -177,20 +247,26 @@ func (mui *MasterUI) initGui(monitoredAppGuids map[string]bool) {
 	// that no DataView is open
 	mui.AddCommonDataViewKeybindings(g, "headerView")
 
-	mui.createAndOpenView(g, "appListView")
+	mui.openStartView(g)
 
 	// default refresh to 1 second
 	mui.refreshIntervalMS = DefaultRefreshInternalMS * time.Millisecond
 
-	if err := g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, mui.quit); err != nil {
+	if err := keybinding.Set(g, "", gocui.KeyCtrlC, gocui.ModNone, mui.quit, "quit"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, "", '?', gocui.ModNone, mui.keybindingHelpAction, "show active keybindings"); err != nil {
 		log.Panicln(err)
 	}
 
+	mui.initIdleDetection()
+
 	go mui.refreshDataAndDisplayThread(g)
 	if err := g.MainLoop(); err != nil && err != gocui.ErrQuit {
 		m := merry.Details(err)
 		log.Panicln(m)
 	}
+	shutdown.Run()
 
 }
 
@@ -231,10 +307,10 @@ func (mui *MasterUI) GetTopMargin() int {
 // Add keybindings for top level data views -- note must also call addCommonDataViewKeybindings
 // to get a full set of keybindings
 func (mui *MasterUI) addTopLevelDataViewKeybindings(g *gocui.Gui, viewName string) error {
-	if err := g.SetKeybinding(viewName, 'q', gocui.ModNone, mui.quit); err != nil {
+	if err := keybinding.Set(g, viewName, 'q', gocui.ModNone, mui.quit, "quit"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, 'd', gocui.ModNone, mui.selectDisplayAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'd', gocui.ModNone, mui.selectDisplayAction, "select display view"); err != nil {
 		log.Panicln(err)
 	}
 	return nil
@@ -244,58 +320,86 @@ func (mui *MasterUI) addTopLevelDataViewKeybindings(g *gocui.Gui, viewName strin
 // keybindings for "top level" data views which are ones that are selectable from
 // the "select view" menu ('d' command)
 func (mui *MasterUI) AddCommonDataViewKeybindings(g *gocui.Gui, viewName string) error {
-	if err := g.SetKeybinding(viewName, 'C', gocui.ModNone, mui.clearStats); err != nil {
-		log.Panicln(err)
+	if !config.IsKioskMode() {
+		if err := keybinding.Set(g, viewName, 'C', gocui.ModNone, mui.clearStats, "clear stats"); err != nil {
+			log.Panicln(err)
+		}
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeySpace, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+	if err := keybinding.Set(g, viewName, gocui.KeySpace, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		mui.RefeshNow()
 		return nil
-	}); err != nil {
+	}, "refresh screen now"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, 's', gocui.ModNone, mui.editUpdateInterval); err != nil {
+	if err := keybinding.Set(g, viewName, 's', gocui.ModNone, mui.editUpdateInterval, "set refresh interval"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, 'r', gocui.ModNone, mui.refreshMetadata); err != nil {
+	if err := keybinding.Set(g, viewName, 'r', gocui.ModNone, mui.refreshMetadata, "reload metadata"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, 'p', gocui.ModNone, mui.toggleDisplayPauseAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'p', gocui.ModNone, mui.toggleDisplayPauseAction, "pause / resume display update"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, 'H', gocui.ModNone, mui.toggleHeaderMinimizeAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'H', gocui.ModNone, mui.toggleHeaderMinimizeAction, "toggle full / minimal header"); err != nil {
 		log.Panicln(err)
 	}
-
-	if err := g.SetKeybinding(viewName, 'E', gocui.ModNone, mui.logTestError); err != nil {
+	if err := keybinding.Set(g, viewName, 'U', gocui.ModNone, mui.togglePerSecondDisplayAction, "toggle totals / per second rates"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'u', gocui.ModNone, mui.toggleUsedFreeDisplayAction, "cycle used+free / used / free memory and disk columns"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'O', gocui.ModNone, mui.selectScopeAction, "select org / space scope"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'P', gocui.ModNone, mui.presetAction, "save / apply named sort, filter, column and scope presets"); err != nil {
 		log.Panicln(err)
 	}
 
-	if err := g.SetKeybinding(viewName, 'Z', gocui.ModNone,
+	if toplog.IsTestMessagesEnabled() {
+		if err := keybinding.Set(g, viewName, 'E', gocui.ModNone, mui.logTestError, "log test error message"); err != nil {
+			log.Panicln(err)
+		}
+	}
+
+	if err := keybinding.Set(g, viewName, 'Z', gocui.ModNone,
 		func(g *gocui.Gui, v *gocui.View) error {
 			toplog.Debug("Top: %v", mui.layoutManager.Top().Name())
 			return nil
-		}); err != nil {
+		}, "log name of top view (debug)"); err != nil {
 		log.Panicln(err)
 	}
 
-	if err := g.SetKeybinding(viewName, 'D', gocui.ModNone,
+	if err := keybinding.Set(g, viewName, 'D', gocui.ModNone,
 		func(g *gocui.Gui, v *gocui.View) error {
 			toplog.Open()
 			return nil
-		}); err != nil {
+		}, "open log window"); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := keybinding.Set(g, viewName, 'V', gocui.ModNone, mui.toggleRecordAction, "start / stop screen recording"); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := keybinding.Set(g, viewName, 'W', gocui.ModNone,
+		func(g *gocui.Gui, v *gocui.View) error {
+			toplog.OpenErrorView()
+			return nil
+		}, "open errors view"); err != nil {
 		log.Panicln(err)
 	}
 	/*
 		// TODO: Testing -- remove later
-		if err := g.SetKeybinding(viewName, 'z', gocui.ModNone, mui.testShowUserMessage); err != nil {
+		if err := keybinding.Set(g, viewName, 'z', gocui.ModNone, mui.testShowUserMessage, "test message"); err != nil {
 			log.Panicln(err)
 		}
 		// TODO: Testing -- remove later
-		if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, mui.test2ShowUserMessage); err != nil {
+		if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, mui.test2ShowUserMessage, "test message"); err != nil {
 			log.Panicln(err)
 		}
 		// TODO: Testing -- remove later
-		if err := g.SetKeybinding(viewName, 'a', gocui.ModNone, mui.testClearUserMessage); err != nil {
+		if err := keybinding.Set(g, viewName, 'a', gocui.ModNone, mui.testClearUserMessage, "test message"); err != nil {
 			log.Panicln(err)
 		}
 	*/
@@ -317,8 +421,9 @@ func (mui *MasterUI) testClearUserMessage(g *gocui.Gui, v *gocui.View) error {
 func (mui *MasterUI) CloseView(m managerUI.Manager) error {
 
 	mui.gui.DeleteView(m.Name())
-	mui.gui.DeleteKeybindings(m.Name())
+	keybinding.Delete(mui.gui, m.Name())
 	nextForFocus := mui.layoutManager.Remove(m)
+	mui.updateNeededEventTypes()
 	nextViewName := nextForFocus.Name()
 	if err := mui.SetCurrentViewOnTop(mui.gui); err != nil {
 		return merry.Wrap(err).Appendf("SetCurrentViewOnTop viewName:[%v]", nextViewName)
@@ -384,16 +489,18 @@ func (mui *MasterUI) selectDisplayAction(g *gocui.Gui, v *gocui.View) error {
 
 	menuItems := make([]*uiCommon.MenuItem, 0, 5)
 	menuItems = append(menuItems, uiCommon.NewMenuItem("appListView", "App Stats"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("topTalkersView", "Top Talkers"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("orgListView", "Org Stats"))
-	if mui.privileged {
-		menuItems = append(menuItems, uiCommon.NewMenuItem("cellListView", "Cell Stats"))
-	}
+	menuItems = append(menuItems, uiCommon.NewMenuItem("cellListView", "Cell Stats"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("cellHealthView", "Cell Health"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("routeListView", "Route Stats"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("eventRateHistoryListView", "Event Rate History"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("eventListView", "Event Stats"))
-	if mui.privileged {
-		menuItems = append(menuItems, uiCommon.NewMenuItem("capacityPlanView", "Capacity Plan (memory)"))
+	capacityPlanMenuItem := uiCommon.NewMenuItem("capacityPlanView", "Capacity Plan (memory)")
+	if !mui.privileged {
+		capacityPlanMenuItem.Disable("privileged mode only")
 	}
+	menuItems = append(menuItems, capacityPlanMenuItem)
 	menuItems = append(menuItems, uiCommon.NewMenuItem("aboutView", "About Top"))
 
 	selectDisplayView := uiCommon.NewSelectMenuWidget(mui, "selectDisplayView", "Select Display", menuItems, mui.selectDisplayCallback)
@@ -404,12 +511,111 @@ func (mui *MasterUI) selectDisplayAction(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// Show an overlay listing the keybindings active for the current view
+func (mui *MasterUI) keybindingHelpAction(g *gocui.Gui, v *gocui.View) error {
+	// gocui runs global keybindings instead of the editor of the current
+	// view, so pass '?' on to input fields to keep it typeable there
+	if v != nil && v.Editable && v.Editor != nil {
+		v.Editor.Edit(v, 0, '?', gocui.ModNone)
+		return nil
+	}
+	if v == nil || v.Name() == KEYBINDING_HELP_VIEW_NAME {
+		return nil
+	}
+	keybindingHelpView := helpView.NewKeybindingHelpView(mui, KEYBINDING_HELP_VIEW_NAME, v.Name())
+	mui.LayoutManager().Add(keybindingHelpView)
+	mui.SetCurrentViewOnTop(g)
+	return nil
+}
+
+// Select an org or space that all views are scoped to
+func (mui *MasterUI) selectScopeAction(g *gocui.Gui, v *gocui.View) error {
+
+	orgs := org.All()
+	sort.Sort(orgsByName(orgs))
+	spacesByOrg := make(map[string][]space.Space)
+	for _, s := range space.All() {
+		spacesByOrg[s.OrgGuid] = append(spacesByOrg[s.OrgGuid], s)
+	}
+
+	menuItems := make([]*uiCommon.MenuItem, 0, len(orgs)+len(space.All())+1)
+	menuItems = append(menuItems, uiCommon.NewMenuItem(scopeMenuIdAll, "All orgs and spaces"))
+	for _, o := range orgs {
+		menuItems = append(menuItems, uiCommon.NewMenuItem(scopeMenuIdOrg+o.Guid, "Org: "+o.Name))
+		spaces := spacesByOrg[o.Guid]
+		sort.Sort(spacesByName(spaces))
+		for _, s := range spaces {
+			menuItems = append(menuItems, uiCommon.NewMenuItem(scopeMenuIdSpace+s.Guid, "    Space: "+s.Name))
+		}
+	}
+
+	selectScopeView := uiCommon.NewSelectMenuWidget(mui, "selectScopeView", "Select Org / Space Scope", menuItems, mui.selectScopeCallback)
+	orgGuid, spaceGuid := mui.commonData.GetScope()
+	switch {
+	case spaceGuid != "":
+		selectScopeView.SetMenuId(scopeMenuIdSpace + spaceGuid)
+	case orgGuid != "":
+		selectScopeView.SetMenuId(scopeMenuIdOrg + orgGuid)
+	}
+
+	mui.LayoutManager().Add(selectScopeView)
+	mui.SetCurrentViewOnTop(g)
+	return nil
+}
+
+func (mui *MasterUI) selectScopeCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	switch {
+	case strings.HasPrefix(menuId, scopeMenuIdSpace):
+		mui.commonData.SetScope("", strings.TrimPrefix(menuId, scopeMenuIdSpace))
+	case strings.HasPrefix(menuId, scopeMenuIdOrg):
+		mui.commonData.SetScope(strings.TrimPrefix(menuId, scopeMenuIdOrg), "")
+	default:
+		mui.commonData.SetScope("", "")
+	}
+	toplog.Info("Scope set to: %v", mui.commonData.ScopeDisplay())
+	mui.RefeshNow()
+	return nil
+}
+
 func (mui *MasterUI) selectDisplayCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
 	mui.displayMenuId = menuId
 	mui.createAndOpenView(g, menuId)
 	return nil
 }
 
+// Display menu view names of the start views, log is handled by openStartView
+var startViewMenuIds = map[string]string{
+	config.StartViewApps:         "appListView",
+	config.StartViewOrgs:         "orgListView",
+	config.StartViewCells:        "cellListView",
+	config.StartViewCellHealth:   "cellHealthView",
+	config.StartViewRoutes:       "routeListView",
+	config.StartViewEventHistory: "eventRateHistoryListView",
+	config.StartViewEvents:       "eventListView",
+	config.StartViewCapacityPlan: "capacityPlanView",
+}
+
+func (mui *MasterUI) openStartView(g *gocui.Gui) {
+	menuId := startViewMenuIds[mui.startView]
+	if menuId == "" {
+		menuId = "appListView"
+	}
+	if menuId == "capacityPlanView" && !mui.privileged {
+		toplog.Warn("Capacity plan view is only available in privileged mode, opening app list")
+		menuId = "appListView"
+	}
+	mui.displayMenuId = menuId
+	if err := mui.createAndOpenView(g, menuId); err != nil {
+		toplog.Error("Unable to open start view %v: %v", mui.startView, err)
+		mui.displayMenuId = "appListView"
+		mui.createAndOpenView(g, "appListView")
+	}
+	if mui.startView == config.StartViewLog {
+		toplog.SetDebugEnabled(true)
+		toplog.Open()
+	}
+}
+
 func (mui *MasterUI) createAndOpenView(g *gocui.Gui, viewName string) error {
 
 	if mui.layoutManager.ContainsViewName(viewName) {
@@ -424,11 +630,16 @@ func (mui *MasterUI) createAndOpenView(g *gocui.Gui, viewName string) error {
 	switch viewName {
 	case "appListView":
 		dataView = appView.NewAppListView(mui, nil, "appListView", mui.helpTextTipsViewSize, ep, "")
+	case "topTalkersView":
+		dataView = topTalkersView.NewTopTalkersView(mui, "topTalkersView", mui.helpTextTipsViewSize, ep)
 	case "orgListView":
 		dataView = orgView.NewOrgListView(mui, "orgListView", mui.helpTextTipsViewSize, ep)
 	case "cellListView":
 		dataView = cellView.NewCellListView(mui, "cellListView", mui.helpTextTipsViewSize, ep)
+	case "cellHealthView":
+		dataView = cellHealthView.NewCellHealthView(mui, "cellHealthView", mui.helpTextTipsViewSize, ep)
 	case "routeListView":
+		ep.LoadDeferredRouteData()
 		dataView = routeView.NewRouteListView(mui, "routeListView", mui.helpTextTipsViewSize, ep)
 	case "eventListView":
 		dataView = eventView.NewEventListView(mui, "eventListView", mui.helpTextTipsViewSize, ep)
@@ -450,6 +661,7 @@ func (mui *MasterUI) createAndOpenView(g *gocui.Gui, viewName string) error {
 func (mui *MasterUI) OpenView(g *gocui.Gui, dataView masterUIInterface.UpdatableView) error {
 	mui.currentDataView = dataView
 	mui.layoutManager.Add(dataView)
+	mui.updateNeededEventTypes()
 	dataView.Layout(g)
 	mui.AddCommonDataViewKeybindings(g, dataView.Name())
 	mui.updateDisplay(g)
@@ -486,6 +698,29 @@ func (mui *MasterUI) editUpdateInterval(g *gocui.Gui, v *gocui.View) error {
 	return intervalWidget.Init(g)
 }
 
+// Generic single value input dialog for widgets (e.g., toplog) that cannot
+// depend on uiCommon directly
+func (mui *MasterUI) OpenInputDialog(g *gocui.Gui, name, titleText, labelText, valueText string,
+	maxLength int, applyFunc func(inputValue string) error) error {
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		if err := applyFunc(inputValue); err != nil {
+			return err
+		}
+		return w.(*uiCommon.InputDialogWidget).CloseWidget(g, v)
+	}
+
+	width := len(labelText) + maxLength + 8
+	if width < len(titleText)+4 {
+		width = len(titleText) + 4
+	}
+	dialogWidget := uiCommon.NewInputDialogWidget(mui,
+		name, width, 6, labelText, maxLength, titleText, "no help",
+		valueText, applyCallbackFunc)
+
+	return dialogWidget.Init(g)
+}
+
 func (mui *MasterUI) clearStats(g *gocui.Gui, v *gocui.View) error {
 	mui.router.Clear()
 	mui.updateDisplay(g)
@@ -503,6 +738,34 @@ func (mui *MasterUI) toggleHeaderMinimizeAction(g *gocui.Gui, v *gocui.View) err
 	return mui.currentDataView.RefreshDisplay(mui.gui)
 }
 
+// Rate capable columns switch between totals and per second rates
+func (mui *MasterUI) togglePerSecondDisplayAction(g *gocui.Gui, v *gocui.View) error {
+	config.SetPerSecondDisplay(!config.IsPerSecondDisplay())
+	if config.IsPerSecondDisplay() {
+		toplog.Info("Showing per second rates")
+	} else {
+		toplog.Info("Showing totals")
+	}
+	return mui.currentDataView.RefreshDisplay(mui.gui)
+}
+
+// Container memory and disk columns cycle between showing both used and
+// free, only used or only free (one narrower combined column)
+func (mui *MasterUI) toggleUsedFreeDisplayAction(g *gocui.Gui, v *gocui.View) error {
+	switch config.GetUsedFreeDisplay() {
+	case config.UsedFreeBoth:
+		config.SetUsedFreeDisplay(config.UsedFreeUsedOnly)
+		toplog.Info("Showing memory / disk used only")
+	case config.UsedFreeUsedOnly:
+		config.SetUsedFreeDisplay(config.UsedFreeFreeOnly)
+		toplog.Info("Showing memory / disk free only")
+	default:
+		config.SetUsedFreeDisplay(config.UsedFreeBoth)
+		toplog.Info("Showing memory / disk used and free")
+	}
+	return mui.currentDataView.RefreshDisplay(mui.gui)
+}
+
 func (mui *MasterUI) logTestError(g *gocui.Gui, v *gocui.View) error {
 	toplog.Error("test error")
 	return nil
@@ -547,20 +810,93 @@ func (mui *MasterUI) snapshotLiveData() {
 func (mui *MasterUI) updateDisplay(g *gocui.Gui) {
 	g.Execute(func(g *gocui.Gui) error {
 
+		mui.checkIdle(g)
 		if !mui.displayPaused {
 			// This takes a snapshot of the live data
+			startTime := time.Now()
 			mui.snapshotLiveData()
-			mui.commonData.PostProcessData()
+			displayStatsMap := mui.commonData.PostProcessData()
+			dataView.CheckRefreshBudget("snapshot and app stats", len(displayStatsMap), startTime)
+			if mui.followCallback != nil {
+				if err := mui.followCallback(g); err != nil {
+					toplog.Error("Follow mode error: %v", err)
+				}
+			}
 		}
 
 		mui.updateHeaderDisplay(g)
 		mui.currentDataView.UpdateDisplay(g)
+		mui.recordFramePending = mui.IsRecording()
+		return nil
+	})
+}
+
+// Last gocui manager, its Layout runs in the flush that draws each update
+// but before the views are drawn.  A func queued with Execute from here is
+// only taken by the gocui main loop once that flush has completed, so the
+// capture sees the new frame.  Queued from updateDisplay it would run
+// ahead of the flush and record the previous frame.
+func (mui *MasterUI) recordLayout(g *gocui.Gui) error {
+	if !mui.recordFramePending {
+		return nil
+	}
+	mui.recordFramePending = false
+	g.Execute(func(g *gocui.Gui) error {
+		if err := mui.recorder.Capture(); err != nil {
+			toplog.Error("Screen recording error: %v", err)
+			mui.stopRecording()
+		}
 		return nil
 	})
+	return nil
+}
+
+func (mui *MasterUI) IsRecording() bool {
+	return mui.recorder != nil && mui.recorder.IsRecording()
+}
+
+func (mui *MasterUI) toggleRecordAction(g *gocui.Gui, v *gocui.View) error {
+	if mui.IsRecording() {
+		return mui.stopRecording()
+	}
+	mui.recorder = recorder.NewRecorder(config.RecordFileName(), config.IsRecordAnsi())
+	if err := mui.recorder.Start(); err != nil {
+		toplog.Error("Unable to start screen recording to %v: %v", mui.recorder.FileName(), err)
+		return nil
+	}
+	toplog.Info("Screen recording started: %v", mui.recorder.FileName())
+	mui.RefeshNow()
+	return nil
+}
+
+func (mui *MasterUI) stopRecording() error {
+	if !mui.IsRecording() {
+		return nil
+	}
+	frameCount, err := mui.recorder.Stop()
+	if err != nil {
+		toplog.Error("Screen recording close error: %v", err)
+		return err
+	}
+	toplog.Info("Screen recording stopped: %v (%v frames)", mui.recorder.FileName(), frameCount)
+	return nil
 }
 
 func (mui *MasterUI) refreshMetadata(g *gocui.Gui, v *gocui.View) error {
-	go mui.router.GetProcessor().FlushCache()
+	processor := mui.router.GetProcessor()
+	if processor.GetMetadataManager().IsLoadMetadataInProgress() {
+		toplog.Info("Metadata refresh already in progress, ignoring request")
+		return nil
+	}
+	toplog.Info("Metadata refresh started")
+	go func() {
+		startTime := time.Now()
+		if processor.FlushCache() {
+			toplog.Info("Metadata refresh complete (%v)", time.Since(startTime).Truncate(time.Millisecond))
+		}
+		mui.RefeshNow()
+	}()
+	mui.RefeshNow()
 	return nil
 }
 
@@ -596,3 +932,37 @@ func (mui *MasterUI) updateHeaderDisplay(g *gocui.Gui) error {
 
 	return mui.alertManager.CheckForAlerts(g)
 }
+
+const (
+	scopeMenuIdAll   = "ALL"
+	scopeMenuIdOrg   = "org:"
+	scopeMenuIdSpace = "space:"
+)
+
+type orgsByName []org.Org
+
+func (slice orgsByName) Len() int {
+	return len(slice)
+}
+
+func (slice orgsByName) Less(i, j int) bool {
+	return util.CaseInsensitiveLess(slice[i].Name, slice[j].Name)
+}
+
+func (slice orgsByName) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
+
+type spacesByName []space.Space
+
+func (slice spacesByName) Len() int {
+	return len(slice)
+}
+
+func (slice spacesByName) Less(i, j int) bool {
+	return util.CaseInsensitiveLess(slice[i].Name, slice[j].Name)
+}
+
+func (slice spacesByName) Swap(i, j int) {
+	slice[i], slice[j] = slice[j], slice[i]
+}
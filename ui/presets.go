@@ -0,0 +1,194 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/preferences"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/jroimartin/gocui"
+)
+
+// Presets: named bundles of the scope and the current list view's sort,
+// filters, visible columns and view filter toggles saved in the
+// preferences file.
+
+const (
+	presetMenuIdSave   = "save"
+	presetMenuIdApply  = "apply:"
+	presetMenuIdDelete = "delete:"
+)
+
+type listWidgetView interface {
+	GetListWidget() *uiCommon.ListWidget
+}
+
+// Implemented by data views that have filter toggles of their own (such as
+// the app list crash filter) so they are saved with and restored by presets.
+type presetStateView interface {
+	PresetState() map[string]string
+	ApplyPresetState(state map[string]string)
+}
+
+func (mui *MasterUI) currentListWidget() *uiCommon.ListWidget {
+	if view, ok := mui.currentDataView.(listWidgetView); ok {
+		return view.GetListWidget()
+	}
+	return nil
+}
+
+func (mui *MasterUI) presetAction(g *gocui.Gui, v *gocui.View) error {
+	if mui.currentListWidget() == nil {
+		toplog.Info("Presets are not available for this view")
+		return nil
+	}
+	prefs, err := preferences.Load()
+	if err != nil {
+		toplog.Error("Unable to load presets: %v", err)
+		return nil
+	}
+	presets := prefs.PresetsForView(mui.currentDataView.Name())
+
+	menuItems := make([]*uiCommon.MenuItem, 0, len(presets)*2+1)
+	menuItems = append(menuItems, uiCommon.NewMenuItem(presetMenuIdSave, "Save current sort / filters / columns / scope..."))
+	for _, preset := range presets {
+		menuItems = append(menuItems, uiCommon.NewMenuItem(presetMenuIdApply+preset.Name, "Apply: "+preset.Name))
+	}
+	for _, preset := range presets {
+		menuItems = append(menuItems, uiCommon.NewMenuItem(presetMenuIdDelete+preset.Name, "Delete: "+preset.Name))
+	}
+
+	selectPresetView := uiCommon.NewSelectMenuWidget(mui, "selectPresetView", "Presets", menuItems, mui.presetMenuCallback)
+	mui.LayoutManager().Add(selectPresetView)
+	mui.SetCurrentViewOnTop(g)
+	return nil
+}
+
+func (mui *MasterUI) presetMenuCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	viewName := mui.currentDataView.Name()
+	switch {
+	case menuId == presetMenuIdSave:
+		return mui.OpenInputDialog(g, "presetNameWidget", "Save Preset", "Name:", "", 30,
+			func(inputValue string) error {
+				name := strings.TrimSpace(inputValue)
+				if name == "" {
+					return nil
+				}
+				mui.savePreset(viewName, name)
+				return nil
+			})
+	case strings.HasPrefix(menuId, presetMenuIdApply):
+		mui.applyPreset(g, viewName, strings.TrimPrefix(menuId, presetMenuIdApply))
+	case strings.HasPrefix(menuId, presetMenuIdDelete):
+		name := strings.TrimPrefix(menuId, presetMenuIdDelete)
+		return uiCommon.OpenConfirmDialog(mui, g, "confirmDeletePresetView", "Delete Preset", "Delete "+name,
+			fmt.Sprintf("Delete preset %v of %v", name, viewName),
+			func(g *gocui.Gui) {
+				mui.deletePreset(viewName, name)
+			})
+	}
+	return nil
+}
+
+func (mui *MasterUI) savePreset(viewName, name string) {
+	listWidget := mui.currentListWidget()
+	if listWidget == nil {
+		return
+	}
+	prefs, err := preferences.Load()
+	if err != nil {
+		toplog.Error("Unable to load presets: %v", err)
+		return
+	}
+	orgGuid, spaceGuid := mui.commonData.GetScope()
+	preset := &preferences.Preset{
+		Name:           name,
+		ViewName:       viewName,
+		OrgGuid:        orgGuid,
+		SpaceGuid:      spaceGuid,
+		Filters:        listWidget.FilterTexts(),
+		VisibleColumns: listWidget.VisibleColumnIds(),
+	}
+	for _, sortColumn := range listWidget.GetSortColumns() {
+		preset.SortColumns = append(preset.SortColumns, &preferences.SortColumn{Id: sortColumn.Id, ReverseSort: sortColumn.ReverseSort})
+	}
+	if view, ok := mui.currentDataView.(presetStateView); ok {
+		preset.ViewState = view.PresetState()
+	}
+	prefs.SetPreset(preset)
+	if err := prefs.Save(); err != nil {
+		toplog.Error("Unable to save preset %v to %v: %v", name, preferences.Path(), err)
+		return
+	}
+	toplog.Info("Saved preset %v to %v", name, preferences.Path())
+}
+
+func (mui *MasterUI) applyPreset(g *gocui.Gui, viewName, name string) {
+	listWidget := mui.currentListWidget()
+	if listWidget == nil {
+		return
+	}
+	prefs, err := preferences.Load()
+	if err != nil {
+		toplog.Error("Unable to load presets: %v", err)
+		return
+	}
+	var preset *preferences.Preset
+	for _, p := range prefs.PresetsForView(viewName) {
+		if p.Name == name {
+			preset = p
+		}
+	}
+	if preset == nil {
+		toplog.Warn("Preset %v not found", name)
+		return
+	}
+
+	mui.commonData.SetScope(preset.OrgGuid, preset.SpaceGuid)
+	// Applied before the sort as toggles such as the crash filter change the sort.
+	// Presets saved before view state was added leave the toggles as they are.
+	if view, ok := mui.currentDataView.(presetStateView); ok && preset.ViewState != nil {
+		view.ApplyPresetState(preset.ViewState)
+	}
+	if len(preset.SortColumns) > 0 {
+		sortColumns := make([]*uiCommon.SortColumn, 0, len(preset.SortColumns))
+		for _, sortColumn := range preset.SortColumns {
+			sortColumns = append(sortColumns, uiCommon.NewSortColumn(sortColumn.Id, sortColumn.ReverseSort))
+		}
+		listWidget.SetSortColumns(sortColumns)
+	}
+	listWidget.SetFilterTexts(preset.Filters)
+	listWidget.SetVisibleColumns(g, preset.VisibleColumns)
+	toplog.Info("Applied preset %v", name)
+	mui.RefeshNow()
+}
+
+func (mui *MasterUI) deletePreset(viewName, name string) {
+	prefs, err := preferences.Load()
+	if err != nil {
+		toplog.Error("Unable to load presets: %v", err)
+		return
+	}
+	prefs.RemovePreset(viewName, name)
+	if err := prefs.Save(); err != nil {
+		toplog.Error("Unable to save presets to %v: %v", preferences.Path(), err)
+		return
+	}
+	toplog.Info("Deleted preset %v", name)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	termbox "github.com/nsf/termbox-go"
+)
+
+// Recorder appends the rendered screen to a file in asciicast v2 format
+// (https://github.com/asciinema/asciinema/blob/develop/doc/asciicast-v2.md)
+// so a session can be replayed (e.g., asciinema play) or attached to an
+// issue.  Each captured frame is a full screen redraw.  Frames that are
+// unchanged from the previous capture are not written.
+type Recorder struct {
+	mu         sync.Mutex
+	fileName   string
+	ansi       bool
+	file       *os.File
+	writer     *bufio.Writer
+	startTime  time.Time
+	lastFrame  string
+	frameCount int
+}
+
+// NewRecorder creates a recorder writing to fileName.  When ansi is false
+// colors and attributes are not recorded (plain text frames).
+func NewRecorder(fileName string, ansi bool) *Recorder {
+	return &Recorder{fileName: fileName, ansi: ansi}
+}
+
+func (r *Recorder) FileName() string {
+	return r.fileName
+}
+
+func (r *Recorder) IsRecording() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file != nil
+}
+
+// Start recording.  The file is truncated and a new asciicast header written.
+func (r *Recorder) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		return nil
+	}
+	file, err := os.Create(r.fileName)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.startTime = clock.Now()
+	r.lastFrame = ""
+	r.frameCount = 0
+
+	width, height := termbox.Size()
+	header := map[string]interface{}{
+		"version":   2,
+		"width":     width,
+		"height":    height,
+		"timestamp": r.startTime.Unix(),
+		"title":     "cf top",
+	}
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.writer, "%s\n", headerBytes)
+	return nil
+}
+
+// Stop recording and close the file.  Returns the number of frames recorded.
+func (r *Recorder) Stop() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return 0, nil
+	}
+	flushErr := r.writer.Flush()
+	closeErr := r.file.Close()
+	r.file = nil
+	r.writer = nil
+	if flushErr != nil {
+		return r.frameCount, flushErr
+	}
+	return r.frameCount, closeErr
+}
+
+// Capture the screen as last flushed by gocui.  Must be called on the gui
+// thread after the frame has been drawn.
+func (r *Recorder) Capture() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	width, height := termbox.Size()
+	frame := renderFrame(termbox.CellBuffer(), width, height, r.ansi)
+	if frame == r.lastFrame {
+		return nil
+	}
+	r.lastFrame = frame
+
+	elapsed := clock.Since(r.startTime).Seconds()
+	event, err := json.Marshal([]interface{}{elapsed, "o", frame})
+	if err != nil {
+		return err
+	}
+	r.frameCount++
+	_, err = fmt.Fprintf(r.writer, "%s\n", event)
+	return err
+}
+
+// Full screen redraw (clear + home) of the cells
+func renderFrame(cells []termbox.Cell, width, height int, ansi bool) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("\x1b[H\x1b[2J")
+	for y := 0; y < height; y++ {
+		lastFg, lastBg := termbox.ColorDefault, termbox.ColorDefault
+		for x := 0; x < width; x++ {
+			index := y*width + x
+			if index >= len(cells) {
+				break
+			}
+			cell := cells[index]
+			if ansi && (cell.Fg != lastFg || cell.Bg != lastBg) {
+				buffer.WriteString(ansiAttributes(cell.Fg, cell.Bg))
+				lastFg, lastBg = cell.Fg, cell.Bg
+			}
+			ch := cell.Ch
+			if ch == 0 {
+				ch = ' '
+			}
+			buffer.WriteRune(ch)
+		}
+		if ansi {
+			buffer.WriteString("\x1b[0m")
+		}
+		if y < height-1 {
+			buffer.WriteString("\r\n")
+		}
+	}
+	return buffer.String()
+}
+
+// Low bits of a termbox attribute are the (256) color index + 1, 0 is default
+const colorMask = 0x1FF
+
+func ansiAttributes(fg, bg termbox.Attribute) string {
+	var buffer bytes.Buffer
+	buffer.WriteString("\x1b[0")
+	if fg&termbox.AttrBold != 0 {
+		buffer.WriteString(";1")
+	}
+	if fg&termbox.AttrUnderline != 0 {
+		buffer.WriteString(";4")
+	}
+	if fg&termbox.AttrReverse != 0 || bg&termbox.AttrReverse != 0 {
+		buffer.WriteString(";7")
+	}
+	if color := fg & colorMask; color != 0 {
+		fmt.Fprintf(&buffer, ";38;5;%d", color-1)
+	}
+	if color := bg & colorMask; color != 0 {
+		fmt.Fprintf(&buffer, ";48;5;%d", color-1)
+	}
+	buffer.WriteString("m")
+	return buffer.String()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	termbox "github.com/nsf/termbox-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+const clearHome = "\x1b[H\x1b[2J"
+
+func cells(text string, fg, bg termbox.Attribute) []termbox.Cell {
+	cells := make([]termbox.Cell, 0, len(text))
+	for _, ch := range text {
+		cells = append(cells, termbox.Cell{Ch: ch, Fg: fg, Bg: bg})
+	}
+	return cells
+}
+
+var _ = Describe("Recorder", func() {
+
+	DescribeTable("renderFrame",
+		func(cells []termbox.Cell, width, height int, ansi bool, expected string) {
+			Expect(renderFrame(cells, width, height, ansi)).To(Equal(expected))
+		},
+		Entry("plain text rows", cells("abcd", 0, 0), 2, 2, false, clearHome+"ab\r\ncd"),
+		Entry("empty cells are spaces", []termbox.Cell{{Ch: 'a'}, {}}, 2, 1, false, clearHome+"a "),
+		Entry("cell buffer shorter than the screen", cells("abc", 0, 0), 2, 2, false, clearHome+"ab\r\nc"),
+		Entry("plain text drops colors", cells("ab", termbox.ColorRed, 0), 2, 1, false, clearHome+"ab"),
+		Entry("default colors only reset at line end", cells("ab", 0, 0), 2, 1, true, clearHome+"ab\x1b[0m"),
+		Entry("attributes written once per run of cells",
+			cells("ab", termbox.ColorRed, 0), 2, 1, true, clearHome+"\x1b[0;38;5;1mab\x1b[0m"),
+		Entry("attributes written when they change",
+			append(cells("a", 0, 0), cells("b", termbox.ColorRed, 0)...), 2, 1, true,
+			clearHome+"a\x1b[0;38;5;1mb\x1b[0m"),
+		Entry("attributes start over on each line",
+			cells("abcd", termbox.ColorRed, 0), 2, 2, true,
+			clearHome+"\x1b[0;38;5;1mab\x1b[0m\r\n\x1b[0;38;5;1mcd\x1b[0m"),
+	)
+
+	DescribeTable("ansiAttributes",
+		func(fg, bg termbox.Attribute, expected string) {
+			Expect(ansiAttributes(fg, bg)).To(Equal(expected))
+		},
+		Entry("default colors", termbox.ColorDefault, termbox.ColorDefault, "\x1b[0m"),
+		Entry("foreground color", termbox.ColorGreen, termbox.ColorDefault, "\x1b[0;38;5;2m"),
+		Entry("background color", termbox.ColorDefault, termbox.ColorBlue, "\x1b[0;48;5;4m"),
+		Entry("256 color index", termbox.Attribute(197), termbox.ColorDefault, "\x1b[0;38;5;196m"),
+		Entry("bold", termbox.ColorRed|termbox.AttrBold, termbox.ColorDefault, "\x1b[0;1;38;5;1m"),
+		Entry("underline", termbox.ColorDefault|termbox.AttrUnderline, termbox.ColorDefault, "\x1b[0;4m"),
+		Entry("reverse on background", termbox.ColorWhite, termbox.ColorBlack|termbox.AttrReverse, "\x1b[0;7;38;5;7;48;5;0m"),
+	)
+})
@@ -0,0 +1,105 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/jroimartin/gocui"
+)
+
+// Open a menu of all columns defined for the list.  Selecting a column
+// toggles if it is shown or hidden.
+func (asUI *ListWidget) columnManagerAction(g *gocui.Gui, v *gocui.View) error {
+	menuItems := make([]*MenuItem, 0, len(asUI.allColumns))
+	for _, column := range asUI.allColumns {
+		checkbox := "[x]"
+		if column.hidden {
+			checkbox = "[ ]"
+		}
+		menuItems = append(menuItems, NewMenuItem(column.id, fmt.Sprintf("%v %v", checkbox, column.Label())))
+	}
+	columnMenu := NewSelectMenuWidget(asUI.masterUI, asUI.name+".columnManagerView",
+		"Show / Hide Column", menuItems, asUI.toggleColumnCallback)
+	asUI.masterUI.LayoutManager().Add(columnMenu)
+	asUI.masterUI.SetCurrentViewOnTop(g)
+	return nil
+}
+
+func (asUI *ListWidget) toggleColumnCallback(g *gocui.Gui, v *gocui.View, columnId string) error {
+	column := asUI.columnMap[columnId]
+	if column == nil {
+		return nil
+	}
+	if !column.hidden && len(asUI.visibleColumns()) <= 1 {
+		toplog.Info("Unable to hide %v, at least one column must be shown", column.label)
+		return nil
+	}
+	column.hidden = !column.hidden
+	asUI.recomputeDisplayColumns(g)
+	return asUI.RefreshDisplay(g)
+}
+
+// Show the column if it is currently hidden
+func (asUI *ListWidget) ShowColumn(g *gocui.Gui, columnId string) {
+	column := asUI.columnMap[columnId]
+	if column == nil || !column.hidden {
+		return
+	}
+	column.hidden = false
+	asUI.recomputeDisplayColumns(g)
+}
+
+// Ids of the columns that are not hidden.  Columns removed by a display
+// mode (see SetShownFunc) are included.
+func (asUI *ListWidget) VisibleColumnIds() []string {
+	columnIds := make([]string, 0, len(asUI.allColumns))
+	for _, column := range asUI.allColumns {
+		if !column.hidden {
+			columnIds = append(columnIds, column.id)
+		}
+	}
+	return columnIds
+}
+
+// Show only the given columns and hide all others.  Ids of columns that
+// are not defined for the list are ignored.
+func (asUI *ListWidget) SetVisibleColumns(g *gocui.Gui, columnIds []string) {
+	show := make(map[string]bool)
+	for _, columnId := range columnIds {
+		if asUI.columnMap[columnId] != nil {
+			show[columnId] = true
+		}
+	}
+	if len(show) == 0 {
+		return
+	}
+	for _, column := range asUI.allColumns {
+		column.hidden = !show[column.id]
+	}
+	asUI.recomputeDisplayColumns(g)
+}
+
+// Force displayed columns to be recomputed for the current view width
+func (asUI *ListWidget) recomputeDisplayColumns(g *gocui.Gui) {
+	width := asUI.compactWidth
+	if listView, err := g.View(asUI.name); err == nil {
+		width, _ = listView.Size()
+	}
+	asUI.compactWidth = -1
+	asUI.updateDisplayColumns(width)
+}
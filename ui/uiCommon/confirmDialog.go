@@ -0,0 +1,57 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	confirmCancelMenuId = "cancel"
+	confirmActionMenuId = "confirm"
+)
+
+// Confirmation prompt shared by all actions that change the foundation
+// (restart, scale, etc.).  The title should state exactly what will
+// happen, e.g., "Restart app payments-api, 4 instances?".  Cancel is the
+// first (highlighted) item so ENTER by accident does nothing.  When
+// confirmed the description is logged with the user and time before
+// action is called.
+func OpenConfirmDialog(masterUI masterUIInterface.MasterUIInterface, g *gocui.Gui,
+	name, title, confirmLabel, description string, action func(g *gocui.Gui)) error {
+
+	menuItems := make([]*MenuItem, 0, 2)
+	menuItems = append(menuItems, NewMenuItem(confirmCancelMenuId, "No - cancel"))
+	menuItems = append(menuItems, NewMenuItem(confirmActionMenuId, confirmLabel))
+
+	confirmCallback := func(g *gocui.Gui, v *gocui.View, menuId string) error {
+		if menuId != confirmActionMenuId {
+			toplog.Info("Cancelled: %v", description)
+			return nil
+		}
+		toplog.Info("Confirmed by %v at %v: %v", masterUI.GetUsername(),
+			clock.Now().Format("2006-01-02 15:04:05 MST"), description)
+		action(g)
+		return nil
+	}
+	confirmView := NewSelectMenuWidget(masterUI, name, title, menuItems, confirmCallback)
+
+	masterUI.LayoutManager().Add(confirmView)
+	return masterUI.SetCurrentViewOnTop(g)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/jroimartin/gocui"
 )
@@ -86,22 +87,22 @@ func (w *EditColumnViewAbs) Layout(g *gocui.Gui) error {
 		//v.BgColor = gocui.ColorRed
 		//v.FgColor = gocui.ColorGreen
 		fmt.Fprintln(v, "...")
-		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.applyAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.applyAction, "apply"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.applyAction); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.applyAction, "apply"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowRight, gocui.ModNone, w.keyArrowRightAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowRight, gocui.ModNone, w.keyArrowRightAction, "select next column"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowLeft, gocui.ModNone, w.keyArrowLeftAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowLeft, gocui.ModNone, w.keyArrowLeftAction, "select previous column"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.cancelAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.cancelAction, "cancel"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, 'q', gocui.ModNone, w.cancelAction); err != nil {
+		if err := keybinding.Set(g, w.name, 'q', gocui.ModNone, w.cancelAction, "cancel"); err != nil {
 			return err
 		}
 
@@ -0,0 +1,263 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/preferences"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+const (
+	MIN_COLUMN_WIDTH = 2
+	MAX_COLUMN_WIDTH = 200
+)
+
+// Column widths of all views read from the preferences file by the first
+// list widget created and updated when widths are saved
+var (
+	columnWidthsMu     sync.Mutex
+	columnWidthsLoaded bool
+	columnWidthsPrefs  = &preferences.Preferences{}
+)
+
+type EditColumnWidthView struct {
+	*EditColumnViewAbs
+
+	// Width overrides (zero if none) of all columns for cancel
+	oldWidths map[string]int
+}
+
+func NewEditColumnWidthView(masterUI masterUIInterface.MasterUIInterface, name string, listWidget *ListWidget) *EditColumnWidthView {
+	w := &EditColumnWidthView{EditColumnViewAbs: NewEditColumnViewAbs(masterUI, name, listWidget)}
+	w.width = 55
+	w.height = 11
+	w.title = "Edit Column Width"
+
+	w.refreshDisplayCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.refreshDisplayCallback(g, v)
+	}
+
+	w.initialLayoutCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.initialLayoutCallback(g, v)
+	}
+
+	w.applyActionCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.applyActionCallback(g, v)
+	}
+
+	w.cancelActionCallbackFunc = func(g *gocui.Gui, v *gocui.View) error {
+		return w.cancelActionCallback(g, v)
+	}
+
+	w.oldWidths = make(map[string]int)
+	for _, column := range listWidget.allColumns {
+		w.oldWidths[column.id] = column.width
+	}
+	return w
+}
+
+func (w *EditColumnWidthView) initialLayoutCallback(g *gocui.Gui, v *gocui.View) error {
+
+	if err := keybinding.Set(g, w.name, '+', gocui.ModNone, w.widenAction, "widen column"); err != nil {
+		return err
+	}
+	if err := keybinding.Set(g, w.name, '=', gocui.ModNone, w.widenAction, "widen column"); err != nil {
+		return err
+	}
+	if err := keybinding.Set(g, w.name, '-', gocui.ModNone, w.narrowAction, "narrow column"); err != nil {
+		return err
+	}
+	if err := keybinding.Set(g, w.name, '0', gocui.ModNone, w.resetAction, "reset column to default width"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *EditColumnWidthView) refreshDisplayCallback(g *gocui.Gui, v *gocui.View) error {
+
+	v.Clear()
+	fmt.Fprintln(v, " ")
+	fmt.Fprintln(v, "  RIGHT or LEFT arrow - select column")
+	fmt.Fprintln(v, "  + or - - widen / narrow selected column")
+	fmt.Fprintln(v, "  0 - reset selected column to default width")
+	fmt.Fprintln(v, "  ENTER - apply and save widths, ESC to cancel")
+	fmt.Fprintln(v, "")
+
+	column := w.listWidget.columnMap[w.listWidget.selectedColumnId]
+	if column == nil {
+		return nil
+	}
+	fmt.Fprintf(v, "    %v%-13v%v width: %v (default %v)\n",
+		util.REVERSE_WHITE, column.Label(), util.CLEAR, column.baseWidth(), column.size)
+	return nil
+}
+
+func (w *EditColumnWidthView) widenAction(g *gocui.Gui, v *gocui.View) error {
+	return w.changeWidth(g, 1)
+}
+
+func (w *EditColumnWidthView) narrowAction(g *gocui.Gui, v *gocui.View) error {
+	return w.changeWidth(g, -1)
+}
+
+func (w *EditColumnWidthView) resetAction(g *gocui.Gui, v *gocui.View) error {
+	column := w.listWidget.columnMap[w.listWidget.selectedColumnId]
+	if column == nil {
+		return nil
+	}
+	column.width = 0
+	return w.applyWidths(g)
+}
+
+func (w *EditColumnWidthView) changeWidth(g *gocui.Gui, delta int) error {
+	column := w.listWidget.columnMap[w.listWidget.selectedColumnId]
+	if column == nil {
+		return nil
+	}
+	width := column.baseWidth() + delta
+	if width < MIN_COLUMN_WIDTH || width > MAX_COLUMN_WIDTH {
+		return nil
+	}
+	if width == column.size {
+		// Back to default, don't keep an override
+		width = 0
+	}
+	column.width = width
+	return w.applyWidths(g)
+}
+
+func (w *EditColumnWidthView) applyWidths(g *gocui.Gui) error {
+	w.listWidget.recomputeDisplayColumns(g)
+	w.RefreshDisplay(g)
+	return w.listWidget.scollSelectedColumnIntoView(g)
+}
+
+func (w *EditColumnWidthView) applyActionCallback(g *gocui.Gui, v *gocui.View) error {
+	w.listWidget.saveColumnWidths()
+	return nil
+}
+
+func (w *EditColumnWidthView) cancelActionCallback(g *gocui.Gui, v *gocui.View) error {
+	for _, column := range w.listWidget.allColumns {
+		column.width = w.oldWidths[column.id]
+	}
+	w.listWidget.recomputeDisplayColumns(g)
+	return nil
+}
+
+// Width overrides of the list columns by column id
+func (asUI *ListWidget) ColumnWidths() map[string]int {
+	widths := make(map[string]int)
+	for _, column := range asUI.allColumns {
+		if column.width > 0 {
+			widths[column.id] = column.width
+		}
+	}
+	return widths
+}
+
+// Column widths saved for the view.  The preferences file is only read
+// the first time.
+func savedColumnWidths(viewName string) map[string]int {
+	columnWidthsMu.Lock()
+	defer columnWidthsMu.Unlock()
+	if !columnWidthsLoaded {
+		columnWidthsLoaded = true
+		prefs, err := preferences.Load()
+		if err != nil {
+			toplog.Error("Unable to load column widths: %v", err)
+		} else {
+			columnWidthsPrefs = prefs
+		}
+	}
+	return columnWidthsPrefs.ColumnWidthsForView(viewName)
+}
+
+// Apply the column widths saved in the preferences file for this list
+func (asUI *ListWidget) loadColumnWidths() {
+	for columnId, width := range savedColumnWidths(asUI.name) {
+		column := asUI.columnMap[columnId]
+		if column != nil && width >= MIN_COLUMN_WIDTH && width <= MAX_COLUMN_WIDTH {
+			column.width = width
+		}
+	}
+}
+
+func (asUI *ListWidget) saveColumnWidths() {
+	prefs, err := preferences.Load()
+	if err != nil {
+		toplog.Error("Unable to load preferences: %v", err)
+		return
+	}
+	widths := asUI.ColumnWidths()
+	prefs.SetColumnWidths(asUI.name, widths)
+	if err := prefs.Save(); err != nil {
+		toplog.Error("Unable to save column widths to %v: %v", preferences.Path(), err)
+		return
+	}
+	columnWidthsMu.Lock()
+	columnWidthsPrefs.SetColumnWidths(asUI.name, widths)
+	columnWidthsMu.Unlock()
+	toplog.Info("Saved column widths to %v", preferences.Path())
+}
+
+// Value to display in a column with a width override (or compact mode
+// extra width).  Display functions
+// size their value to the column's default size, so an alphanumeric value
+// they truncated is taken from the raw value instead.
+func (c *ListColumn) overrideValue(rowData IData, value string) string {
+	value = strings.TrimSpace(value)
+	if !c.isResized() || c.columnType != ALPHANUMERIC || c.rawValueFunc == nil {
+		return value
+	}
+	rawValue := c.rawValueFunc(rowData)
+	if strings.HasPrefix(rawValue, strings.TrimSuffix(value, util.Ellipsis)) {
+		return rawValue
+	}
+	return value
+}
+
+// Pad / truncate a displayed cell to the column's width override (or
+// compact mode extra width).  Cells that contain color codes are only padded.
+func (c *ListColumn) fitWidth(rowData IData, text string) string {
+	if !c.isResized() {
+		return text
+	}
+	width := c.Width()
+	if strings.Contains(text, "\033") {
+		padding := width - len([]rune(ansiEscapeRegex.ReplaceAllString(text, "")))
+		if padding <= 0 {
+			return text
+		}
+		if c.leftJustifyLabel {
+			return text + strings.Repeat(" ", padding)
+		}
+		return strings.Repeat(" ", padding) + text
+	}
+	value := c.overrideValue(rowData, text)
+	if c.leftJustifyLabel {
+		return util.FormatDisplayDataLeft(value, width)
+	}
+	return util.FormatDisplayDataRight(value, width)
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/preferences"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type widthRow struct {
+	name string
+}
+
+func (r *widthRow) Id() string {
+	return r.name
+}
+
+func newNameColumn() *ListColumn {
+	return &ListColumn{
+		id: "NAME", size: 10, columnType: ALPHANUMERIC, leftJustifyLabel: true,
+		rawValueFunc: func(data IData) string { return data.(*widthRow).name },
+	}
+}
+
+var _ = Describe("Column width", func() {
+	row := &widthRow{name: "my-long-app-name"}
+	// Value as sized by a display function to the default column size
+	displayed := util.FormatDisplayDataLeft(row.name, 10)
+
+	Describe("fitWidth", func() {
+
+		It("keeps the displayed value without a width override", func() {
+			Expect(newNameColumn().fitWidth(row, displayed)).To(Equal(displayed))
+		})
+
+		It("shows more of a truncated value in a wider column", func() {
+			column := newNameColumn()
+			column.width = 20
+			Expect(column.fitWidth(row, displayed)).To(Equal("my-long-app-name    "))
+		})
+
+		It("truncates the value to a narrower column", func() {
+			column := newNameColumn()
+			column.width = 8
+			Expect(column.fitWidth(row, displayed)).To(Equal("my-long" + util.Ellipsis))
+		})
+
+		It("uses the width given in compact mode", func() {
+			column := newNameColumn()
+			column.compactExtraWidth = 6
+			Expect(column.fitWidth(row, displayed)).To(Equal("my-long-app-name"))
+		})
+
+		It("right justifies numeric columns", func() {
+			column := &ListColumn{id: "COUNT", size: 4, columnType: NUMERIC, width: 6}
+			Expect(column.fitWidth(row, "  42")).To(Equal("    42"))
+		})
+
+		It("only pads cells with color codes", func() {
+			column := newNameColumn()
+			column.width = 6
+			text := util.DIM_RED + "red" + util.CLEAR
+			Expect(column.fitWidth(row, text)).To(Equal(text + "   "))
+			column.width = 2
+			Expect(column.fitWidth(row, text)).To(Equal(text))
+		})
+	})
+
+	Describe("overrideValue", func() {
+
+		It("uses the raw value of a truncated alphanumeric value", func() {
+			column := newNameColumn()
+			column.width = 20
+			Expect(column.overrideValue(row, displayed)).To(Equal(row.name))
+		})
+
+		It("keeps a displayed value that is not taken from the raw value", func() {
+			column := newNameColumn()
+			column.width = 20
+			Expect(column.overrideValue(row, " stopped ")).To(Equal("stopped"))
+		})
+
+		It("keeps the value of columns that are not alphanumeric", func() {
+			column := &ListColumn{id: "COUNT", size: 4, columnType: NUMERIC, width: 6,
+				rawValueFunc: func(data IData) string { return "1042" }}
+			Expect(column.overrideValue(row, "1k")).To(Equal("1k"))
+		})
+	})
+
+	Describe("saved widths", func() {
+		var cfHome, savedCfHome string
+
+		resetColumnWidthsCache := func() {
+			columnWidthsLoaded = false
+			columnWidthsPrefs = &preferences.Preferences{}
+		}
+
+		newWidget := func() *ListWidget {
+			return NewListWidget(nil, "widthList", 0, nil, []*ListColumn{newNameColumn()}, nil)
+		}
+
+		BeforeEach(func() {
+			var err error
+			cfHome, err = ioutil.TempDir("", "top-column-widths")
+			Expect(err).NotTo(HaveOccurred())
+			savedCfHome = os.Getenv("CF_HOME")
+			os.Setenv("CF_HOME", cfHome)
+			resetColumnWidthsCache()
+
+			prefs := &preferences.Preferences{}
+			prefs.SetColumnWidths("widthList", map[string]int{"NAME": 25, "GONE": 12})
+			Expect(prefs.Save()).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.Setenv("CF_HOME", savedCfHome)
+			os.RemoveAll(cfHome)
+			resetColumnWidthsCache()
+		})
+
+		It("applies the saved widths of the list's columns", func() {
+			Expect(newWidget().ColumnWidths()).To(Equal(map[string]int{"NAME": 25}))
+		})
+
+		It("reads the preferences file once", func() {
+			newWidget()
+			Expect(os.Remove(preferences.Path())).To(Succeed())
+			Expect(newWidget().ColumnWidths()).To(Equal(map[string]int{"NAME": 25}))
+		})
+
+		It("uses the widths saved since the file was read", func() {
+			w := newWidget()
+			w.columnMap["NAME"].width = 30
+			w.saveColumnWidths()
+			Expect(newWidget().ColumnWidths()).To(Equal(map[string]int{"NAME": 30}))
+
+			prefs, err := preferences.Load()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefs.ColumnWidthsForView("widthList")).To(Equal(map[string]int{"NAME": 30}))
+		})
+	})
+
+})
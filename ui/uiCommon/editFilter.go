@@ -23,6 +23,7 @@ import (
 
 	"github.com/Knetic/govaluate"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
@@ -74,10 +75,10 @@ func NewEditFilterView(masterUI masterUIInterface.MasterUIInterface, name string
 func (w *EditFilterView) initialLayoutCallback(g *gocui.Gui, v *gocui.View) error {
 
 	v.Wrap = true
-	if err := g.SetKeybinding(w.name, gocui.KeySpace, gocui.ModNone, w.keySpaceAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeySpace, gocui.ModNone, w.keySpaceAction, "edit filter of selected column"); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(w.name, 'c', gocui.ModNone, w.clearFilterAction); err != nil {
+	if err := keybinding.Set(g, w.name, 'c', gocui.ModNone, w.clearFilterAction, "clear filter of selected column"); err != nil {
 		return err
 	}
 
@@ -18,6 +18,7 @@ package uiCommon
 import (
 	"fmt"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
@@ -78,22 +79,22 @@ func NewEditSortView(masterUI masterUIInterface.MasterUIInterface, name string,
 
 func (w *EditSortView) initialLayoutCallback(g *gocui.Gui, v *gocui.View) error {
 
-	if err := g.SetKeybinding(w.name, gocui.KeyArrowDown, gocui.ModNone, w.keyArrowDownAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.keyArrowDownAction, "next sort position"); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(w.name, gocui.KeyArrowUp, gocui.ModNone, w.keyArrowUpAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.keyArrowUpAction, "previous sort position"); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(w.name, gocui.KeySpace, gocui.ModNone, w.keySpaceAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeySpace, gocui.ModNone, w.keySpaceAction, "set / toggle sort column direction"); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(w.name, gocui.KeyDelete, gocui.ModNone, w.keyDeleteAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeyDelete, gocui.ModNone, w.keyDeleteAction, "remove sort column"); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(w.name, gocui.KeyBackspace, gocui.ModNone, w.keyDeleteAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeyBackspace, gocui.ModNone, w.keyDeleteAction, "remove sort column"); err != nil {
 		return err
 	}
-	if err := g.SetKeybinding(w.name, gocui.KeyBackspace2, gocui.ModNone, w.keyDeleteAction); err != nil {
+	if err := keybinding.Set(g, w.name, gocui.KeyBackspace2, gocui.ModNone, w.keyDeleteAction, "remove sort column"); err != nil {
 		return err
 	}
 	return nil
@@ -121,7 +122,7 @@ func (w *EditSortView) refreshDisplayCallback(g *gocui.Gui, v *gocui.View) error
 			if sc.ReverseSort {
 				sortDirection = DescendingText
 			}
-			columnLabel := w.listWidget.columnMap[sc.Id].label
+			columnLabel := w.listWidget.columnMap[sc.Id].Label()
 			displayName = fmt.Sprintf("%-13v %v", columnLabel, sortDirection)
 		}
 		fmt.Fprintf(v, " Sort #%v: %v \n", i+1, displayName)
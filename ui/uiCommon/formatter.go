@@ -0,0 +1,249 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+const (
+	FormatCsv  = "csv"
+	FormatJson = "json"
+	FormatText = "txt"
+)
+
+// Formatter writes rows of list data using the given columns.  All export
+// actions write through a Formatter so every export supports the same
+// formats and a new format only needs to be added here.
+type Formatter interface {
+	Format(rows []IData, cols []*ListColumn, w io.Writer) error
+}
+
+type formatterEntry struct {
+	id        string
+	label     string
+	formatter Formatter
+}
+
+// Menu order of the export formats
+var formatters = []formatterEntry{
+	{FormatCsv, "CSV", &CsvFormatter{Comma: ','}},
+	{FormatJson, "JSON", &JsonFormatter{}},
+	{FormatText, "Text (aligned columns)", &TextFormatter{}},
+}
+
+// Formatter for a format id (e.g., FormatCsv), nil if unknown.  The
+// format id is also used as the export file name extension.
+func FindFormatter(format string) Formatter {
+	for _, entry := range formatters {
+		if entry.id == format {
+			return entry.formatter
+		}
+	}
+	return nil
+}
+
+// Menu items (id is the format) for an export format select menu
+func FormatterMenuItems() []*MenuItem {
+	menuItems := make([]*MenuItem, 0, len(formatters))
+	for _, entry := range formatters {
+		menuItems = append(menuItems, NewMenuItem(entry.id, entry.label))
+	}
+	return menuItems
+}
+
+// Write rows to path in the given format
+func WriteFormattedFile(path, format string, rows []IData, cols []*ListColumn) error {
+	formatter := FindFormatter(format)
+	if formatter == nil {
+		return fmt.Errorf("unknown export format: %v", format)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := formatter.Format(rows, cols, file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Column for data that is only exported, never displayed in a list.  The
+// raw value is also the display value.
+func NewExportColumn(id, label string, columnType ColumnType, rawValueFunc getRowRawValueFunc) *ListColumn {
+	displayFunc := func(data IData, columnOwner IColumnOwner) string {
+		return rawValueFunc(data)
+	}
+	return NewListColumn(id, label, 0, columnType, columnType == ALPHANUMERIC, nil, false, displayFunc, rawValueFunc, nil)
+}
+
+// CSV with a header row of column labels and the raw value of each column
+type CsvFormatter struct {
+	Comma rune
+}
+
+func (f *CsvFormatter) Format(rows []IData, cols []*ListColumn, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if f.Comma != 0 {
+		writer.Comma = f.Comma
+	}
+	record := make([]string, len(cols))
+	for i, column := range cols {
+		record[i] = column.Label()
+	}
+	writer.Write(record)
+	for _, rowData := range rows {
+		for i, column := range cols {
+			record[i] = column.rawValueFunc(rowData)
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// JSON array with one object per row keyed by column id.  Numeric columns
+// are written as numbers when the raw value parses as one.  Rows that
+// implement json.Marshaler are written as they marshal themselves.
+type JsonFormatter struct {
+}
+
+func (f *JsonFormatter) Format(rows []IData, cols []*ListColumn, w io.Writer) error {
+	records := make([]interface{}, 0, len(rows))
+	for _, rowData := range rows {
+		if marshaler, ok := rowData.(json.Marshaler); ok {
+			records = append(records, marshaler)
+			continue
+		}
+		record := &orderedRecord{keys: make([]string, len(cols)), values: make([]interface{}, len(cols))}
+		for i, column := range cols {
+			record.keys[i] = column.id
+			record.values[i] = jsonValue(column, column.rawValueFunc(rowData))
+		}
+		records = append(records, record)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+func jsonValue(column *ListColumn, value string) interface{} {
+	if column.columnType == NUMERIC {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
+
+// JSON object that keeps the column order of the export
+type orderedRecord struct {
+	keys   []string
+	values []interface{}
+}
+
+func (r *orderedRecord) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, key := range r.keys {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueData, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyData)
+		buffer.WriteString(":")
+		buffer.Write(valueData)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// Plain text table of the display values with any ANSI color codes removed.
+// Each cell is padded / truncated to the column's display width, columns
+// without a display width (see NewExportColumn) are sized to fit.
+type TextFormatter struct {
+	// Passed to the column display functions, may be nil for export columns
+	ColumnOwner IColumnOwner
+}
+
+func (f *TextFormatter) Format(rows []IData, cols []*ListColumn, w io.Writer) error {
+	values := make([][]string, len(rows))
+	for rowIndex, rowData := range rows {
+		values[rowIndex] = make([]string, len(cols))
+		for colIndex, column := range cols {
+			value := ansiEscapeRegex.ReplaceAllString(column.displayFunc(rowData, f.ColumnOwner), "")
+			values[rowIndex][colIndex] = column.overrideValue(rowData, value)
+		}
+	}
+
+	widths := make([]int, len(cols))
+	for colIndex, column := range cols {
+		widths[colIndex] = column.Width()
+		if widths[colIndex] > 0 {
+			continue
+		}
+		widths[colIndex] = len(column.Label())
+		for _, rowValues := range values {
+			if len(rowValues[colIndex]) > widths[colIndex] {
+				widths[colIndex] = len(rowValues[colIndex])
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	writeRow := func(rowValues []string) {
+		for colIndex, column := range cols {
+			if colIndex > 0 {
+				buffer.WriteString(" ")
+			}
+			if column.leftJustifyLabel {
+				buffer.WriteString(util.FormatDisplayDataLeft(rowValues[colIndex], widths[colIndex]))
+			} else {
+				buffer.WriteString(util.FormatDisplayDataRight(rowValues[colIndex], widths[colIndex]))
+			}
+		}
+		buffer.WriteString("\n")
+	}
+	labels := make([]string, len(cols))
+	for colIndex, column := range cols {
+		labels[colIndex] = column.Label()
+	}
+	writeRow(labels)
+	for _, rowValues := range values {
+		writeRow(rowValues)
+	}
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
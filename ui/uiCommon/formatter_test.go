@@ -0,0 +1,136 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testRow struct {
+	name  string
+	count int
+}
+
+func (r *testRow) Id() string {
+	return r.name
+}
+
+// Row that marshals itself for the JSON export
+type testJsonRow struct {
+	testRow
+}
+
+func (r *testJsonRow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"row": r.name})
+}
+
+func testColumns() []*uiCommon.ListColumn {
+	return []*uiCommon.ListColumn{
+		uiCommon.NewExportColumn("name", "NAME", uiCommon.ALPHANUMERIC, func(data uiCommon.IData) string {
+			return data.(*testRow).name
+		}),
+		uiCommon.NewExportColumn("count", "COUNT", uiCommon.NUMERIC, func(data uiCommon.IData) string {
+			return strconv.Itoa(data.(*testRow).count)
+		}),
+	}
+}
+
+func testRows() []uiCommon.IData {
+	return []uiCommon.IData{
+		&testRow{name: "web", count: 12},
+		&testRow{name: "worker, \"batch\"", count: 0},
+	}
+}
+
+func format(formatter uiCommon.Formatter, rows []uiCommon.IData, cols []*uiCommon.ListColumn) string {
+	var buffer bytes.Buffer
+	Expect(formatter.Format(rows, cols, &buffer)).To(Succeed())
+	return buffer.String()
+}
+
+var _ = Describe("CsvFormatter", func() {
+	It("writes a header of column labels and quotes values when needed", func() {
+		Expect(format(&uiCommon.CsvFormatter{Comma: ','}, testRows(), testColumns())).To(Equal(
+			"NAME,COUNT\n" +
+				"web,12\n" +
+				"\"worker, \"\"batch\"\"\",0\n"))
+	})
+
+	It("uses the given separator", func() {
+		Expect(format(&uiCommon.CsvFormatter{Comma: ';'}, testRows()[:1], testColumns())).To(Equal("NAME;COUNT\nweb;12\n"))
+	})
+
+	It("defaults to a comma separator", func() {
+		Expect(format(&uiCommon.CsvFormatter{}, testRows()[:1], testColumns())).To(Equal("NAME,COUNT\nweb,12\n"))
+	})
+
+	It("writes only the header when there are no rows", func() {
+		Expect(format(&uiCommon.CsvFormatter{Comma: ','}, nil, testColumns())).To(Equal("NAME,COUNT\n"))
+	})
+})
+
+var _ = Describe("TextFormatter", func() {
+	It("sizes export columns to fit, left justifying text and right justifying numbers", func() {
+		rows := []uiCommon.IData{
+			&testRow{name: "web", count: 12},
+			&testRow{name: "worker", count: 1500},
+		}
+		Expect(format(&uiCommon.TextFormatter{}, rows, testColumns())).To(Equal(
+			"NAME   COUNT\n" +
+				"web       12\n" +
+				"worker  1500\n"))
+	})
+
+	It("pads and truncates to the display width and removes color codes", func() {
+		column := uiCommon.NewListColumn("name", "NAME", 5, uiCommon.ALPHANUMERIC, true, nil, false,
+			func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+				return "\033[31m" + data.(*testRow).name + "\033[0m"
+			},
+			func(data uiCommon.IData) string {
+				return data.(*testRow).name
+			}, nil)
+		rows := []uiCommon.IData{
+			&testRow{name: "web"},
+			&testRow{name: "worker"},
+		}
+		Expect(format(&uiCommon.TextFormatter{}, rows, []*uiCommon.ListColumn{column})).To(Equal(
+			"NAME \n" +
+				"web  \n" +
+				"work…\n"))
+	})
+})
+
+var _ = Describe("JsonFormatter", func() {
+	It("writes an object per row keyed by column id with numeric columns as numbers", func() {
+		var records []map[string]interface{}
+		Expect(json.Unmarshal([]byte(format(&uiCommon.JsonFormatter{}, testRows(), testColumns())), &records)).To(Succeed())
+		Expect(records).To(Equal([]map[string]interface{}{
+			{"name": "web", "count": float64(12)},
+			{"name": "worker, \"batch\"", "count": float64(0)},
+		}))
+	})
+
+	It("writes rows that implement json.Marshaler as they marshal themselves", func() {
+		rows := []uiCommon.IData{&testJsonRow{testRow{name: "web"}}}
+		Expect(format(&uiCommon.JsonFormatter{}, rows, testColumns())).To(MatchJSON(`[{"row": "web"}]`))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	//"strings"
 	//"log"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/jroimartin/gocui"
 )
@@ -101,11 +102,11 @@ func (w *InputDialogWidget) Layout(g *gocui.Gui) error {
 		fmt.Fprintf(v, " %v", w.titleText)
 
 		/*
-			if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.CloseWidget); err != nil {
+			if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.CloseWidget, "cancel"); err != nil {
 				return err
 			}
 		*/
-		if err := g.SetKeybinding(w.name, 'q', gocui.ModNone, w.CloseWidget); err != nil {
+		if err := keybinding.Set(g, w.name, 'q', gocui.ModNone, w.CloseWidget, "cancel"); err != nil {
 			return err
 		}
 		/*
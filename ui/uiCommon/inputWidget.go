@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/jroimartin/gocui"
 )
 
@@ -126,10 +127,10 @@ func (i *Input) Layout(g *gocui.Gui) error {
 		v.MoveCursor(len(i.inputValue), 0, true)
 		//v.SetOrigin(2,0)
 
-		if err := g.SetKeybinding(i.name, gocui.KeyEnter, gocui.ModNone, i.applyValueAction); err != nil {
+		if err := keybinding.Set(g, i.name, gocui.KeyEnter, gocui.ModNone, i.applyValueAction, "apply"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(i.name, gocui.KeyEsc, gocui.ModNone, i.cancelValueAction); err != nil {
+		if err := keybinding.Set(g, i.name, gocui.KeyEsc, gocui.ModNone, i.cancelValueAction, "cancel"); err != nil {
 			return err
 		}
 
@@ -16,14 +16,16 @@
 package uiCommon
 
 import (
-	//"fmt"
-
+	"fmt"
 	"log"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
 	"github.com/jroimartin/gocui"
 )
 
+const TERMINAL_TOO_SMALL_VIEW_NAME = "terminalTooSmallView"
+
 type LayoutManager struct {
 	managers  []managerUI.Manager
 	viewNames []string
@@ -34,6 +36,17 @@ func NewLayoutManager() *LayoutManager {
 }
 
 func (w *LayoutManager) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	if maxX < config.MinTerminalWidth || maxY < config.MinTerminalHeight {
+		// Views laid out in a tiny terminal end up with degenerate sizes
+		// and render garbled, so show a single message instead
+		return w.layoutTerminalTooSmall(g, maxX, maxY)
+	}
+	if _, err := g.View(TERMINAL_TOO_SMALL_VIEW_NAME); err == nil {
+		// Terminal was resized large enough again
+		g.DeleteView(TERMINAL_TOO_SMALL_VIEW_NAME)
+		defer w.restoreCurrentView(g)
+	}
 	for _, m := range w.managers {
 		if err := m.Layout(g); err != nil {
 			return err
@@ -42,6 +55,36 @@ func (w *LayoutManager) Layout(g *gocui.Gui) error {
 	return nil
 }
 
+func (w *LayoutManager) layoutTerminalTooSmall(g *gocui.Gui, maxX, maxY int) error {
+	if maxX < 2 || maxY < 2 {
+		// Not even room for a view
+		return nil
+	}
+	v, err := g.SetView(TERMINAL_TOO_SMALL_VIEW_NAME, 0, 0, maxX-1, maxY-1)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return err
+		}
+		v.Frame = false
+		v.Wrap = true
+	}
+	g.SetViewOnTop(TERMINAL_TOO_SMALL_VIEW_NAME)
+	g.SetCurrentView(TERMINAL_TOO_SMALL_VIEW_NAME)
+	v.Clear()
+	fmt.Fprintf(v, "terminal too small (need at least %vx%v)", config.MinTerminalWidth, config.MinTerminalHeight)
+	return nil
+}
+
+func (w *LayoutManager) restoreCurrentView(g *gocui.Gui) {
+	top := w.Top()
+	if top == nil {
+		return
+	}
+	if _, err := g.SetCurrentView(top.Name()); err == nil {
+		g.SetViewOnTop(top.Name())
+	}
+}
+
 func (w *LayoutManager) Contains(managerToFind managerUI.Manager) bool {
 	for _, m := range w.managers {
 		if m.Name() == managerToFind.Name() {
@@ -83,6 +126,13 @@ func (w *LayoutManager) Add(addMgr managerUI.Manager) {
 	w.managers = append(w.managers, addMgr)
 }
 
+// All managers, the top (current) one last
+func (w *LayoutManager) Managers() []managerUI.Manager {
+	managers := make([]managerUI.Manager, len(w.managers))
+	copy(managers, w.managers)
+	return managers
+}
+
 func (w *LayoutManager) Top() managerUI.Manager {
 	len := len(w.managers)
 	if len > 0 {
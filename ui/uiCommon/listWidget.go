@@ -25,11 +25,17 @@ import (
 
 	"github.com/Knetic/govaluate"
 	"github.com/ansel1/merry"
+	"github.com/atotto/clipboard"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
+var ansiEscapeRegex = regexp.MustCompile("\033\\[[0-9;]*m")
+
 const (
 	// Unicode characters: http://graphemica.com/unicode/characters/page/34
 	DownArrow       = string('\U00002193')
@@ -45,9 +51,12 @@ const (
 	TwoDot          = string('\U00002025')
 	OneDot          = string('\U00002024')
 	CircleBackslash = string('\U000020E0')
+	ColumnDivider   = string('\U00002502')
+	Star            = string('\U00002605')
 )
 
 type preRowDisplayFunc func(data IData, isSelected bool) string
+type footerNoteFunc func() string
 type getRowDisplayFunc func(data IData, columnOwner IColumnOwner) string
 type getRowRawValueFunc func(data IData) string
 type getDisplayHeaderFunc func() string
@@ -94,10 +103,29 @@ type ListColumn struct {
 	displayFunc        getRowDisplayFunc
 	rawValueFunc       getRowRawValueFunc
 	attentionFunc      getRowAttentionFunc
+	// Zero means the column is always displayed. Larger values are
+	// hidden first when in compact mode.
+	priority int
+	// Hidden columns are not displayed until shown with the column manager
+	hidden bool
+	// Display width set with the column width editor, zero uses size
+	width int
+	// Width given to an essential column from the columns hidden in
+	// compact mode, see updateDisplayColumns
+	compactExtraWidth int
+	// Optional, for columns whose label or presence depends on a display
+	// mode (e.g., config.GetUsedFreeDisplay)
+	labelFunc func() string
+	shownFunc func() bool
 }
 
+// Default number of leftmost columns that stay in view when scrolling horizontally
 const LOCK_COLUMNS = 1
 
+// When the list view is narrower than this, optional (priority > 0)
+// columns are dropped until the remaining columns fit
+const COMPACT_MODE_WIDTH = 100
+
 type IData interface {
 	Id() string
 }
@@ -108,20 +136,36 @@ type ListWidget struct {
 	bottomMargin int
 
 	Title string
+	// Appended to the title on refresh, used by data views to flag data
+	// that is incomplete
+	TitleNote string
 
 	displayView DisplayViewInterface
 
 	highlightKey          string
 	displayRowIndexOffset int
+	// Number of data rows that fit in the view when last displayed
+	displayRowCount       int
 	displayColIndexOffset int
+	// Number of leftmost columns that do not scroll horizontally
+	lockColumns int
 
-	PreRowDisplayFunc  preRowDisplayFunc
+	PreRowDisplayFunc preRowDisplayFunc
+	// Optional text added to the position footer, e.g., rows hidden by a
+	// view level filter
+	FooterNoteFunc     footerNoteFunc
 	columnOwner        IColumnOwner
 	listData           []IData
 	unfilteredListData []IData
 
-	columns   []*ListColumn
-	columnMap map[string]*ListColumn
+	// All defined columns
+	allColumns []*ListColumn
+	// Columns currently displayed (may be a subset of allColumns in compact mode)
+	columns      []*ListColumn
+	columnMap    map[string]*ListColumn
+	compactWidth int
+	// Number of visible columns when displayed columns were last computed
+	visibleColumnCount int
 
 	selectColumnMode bool
 	selectedColumnId string
@@ -184,6 +228,65 @@ func NewListColumn(
 	return column
 }
 
+// Set display priority of column. Zero (default) is always displayed,
+// larger values are hidden first on narrow terminals.
+func (c *ListColumn) SetPriority(priority int) *ListColumn {
+	c.priority = priority
+	return c
+}
+
+// Set if the column is hidden.  Used to define columns that are off by
+// default (e.g., wide columns) which can be shown with the column manager.
+func (c *ListColumn) SetHidden(hidden bool) *ListColumn {
+	c.hidden = hidden
+	return c
+}
+
+func (c *ListColumn) IsHidden() bool {
+	return c.hidden
+}
+
+// Set a function that returns the column label, used instead of the
+// label the column was created with
+func (c *ListColumn) SetLabelFunc(labelFunc func() string) *ListColumn {
+	c.labelFunc = labelFunc
+	return c
+}
+
+// Set a function that returns false when the column should not be
+// displayed in the current display mode.  Unlike hidden, this is not
+// changed by the column manager.
+func (c *ListColumn) SetShownFunc(shownFunc func() bool) *ListColumn {
+	c.shownFunc = shownFunc
+	return c
+}
+
+// Display width of the column, the width override if one is set
+// Display width including any width given to the column in compact mode
+func (c *ListColumn) Width() int {
+	return c.baseWidth() + c.compactExtraWidth
+}
+
+// Size or the width set with the column width editor
+func (c *ListColumn) baseWidth() int {
+	if c.width > 0 {
+		return c.width
+	}
+	return c.size
+}
+
+// Displayed at a width other than its size
+func (c *ListColumn) isResized() bool {
+	return c.width > 0 || c.compactExtraWidth > 0
+}
+
+func (c *ListColumn) Label() string {
+	if c.labelFunc != nil {
+		return c.labelFunc()
+	}
+	return c.label
+}
+
 func NewListWidget(masterUI masterUIInterface.MasterUIInterface, name string,
 	bottomMargin int, displayView DisplayViewInterface,
 	columns []*ListColumn, columnOwner IColumnOwner) *ListWidget {
@@ -192,14 +295,17 @@ func NewListWidget(masterUI masterUIInterface.MasterUIInterface, name string,
 		name:            name,
 		bottomMargin:    bottomMargin,
 		displayView:     displayView,
+		allColumns:      columns,
 		columns:         columns,
 		columnMap:       make(map[string]*ListColumn),
 		filterColumnMap: make(map[string]*FilterColumn),
 		columnOwner:     columnOwner,
+		lockColumns:     LOCK_COLUMNS,
 	}
 	for _, col := range columns {
 		w.columnMap[col.id] = col
 	}
+	w.loadColumnWidths()
 
 	return w
 }
@@ -227,6 +333,8 @@ func (w *ListWidget) Layout(g *gocui.Gui) error {
 		}
 	}
 
+	w.updateDisplayColumns(maxX - 2)
+
 	v, err = g.SetView(w.name, 0, topMargin, maxX-1, bottom)
 	if err != nil {
 		if err != gocui.ErrUnknownView {
@@ -234,46 +342,58 @@ func (w *ListWidget) Layout(g *gocui.Gui) error {
 		}
 		v.Title = w.Title
 		v.Frame = true
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp, "highlight previous row"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown, "highlight next row"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDownAction, "page down"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDownAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgup, gocui.ModNone, w.pageUpAction, "page up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgup, gocui.ModNone, w.pageUpAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowRight, gocui.ModNone, w.arrowRight, "scroll columns right"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowRight, gocui.ModNone, w.arrowRight); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowLeft, gocui.ModNone, w.arrowLeft, "scroll columns left"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowLeft, gocui.ModNone, w.arrowLeft); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyHome, gocui.ModNone, w.arrowHome, "scroll to first column"); err != nil {
+			log.Panicln(err)
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyEnd, gocui.ModNone, w.arrowEnd, "scroll to last column"); err != nil {
+			log.Panicln(err)
+		}
+
+		if err := keybinding.Set(g, w.name, 'o', gocui.ModNone, w.editSortAction, "sort order"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyHome, gocui.ModNone, w.arrowHome); err != nil {
+
+		if err := keybinding.Set(g, w.name, 'f', gocui.ModNone, w.editFilterAction, "filter"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEnd, gocui.ModNone, w.arrowEnd); err != nil {
+
+		if err := keybinding.Set(g, w.name, 'T', gocui.ModNone, w.copyTableAction, "copy displayed rows as table"); err != nil {
 			log.Panicln(err)
 		}
 
-		if err := g.SetKeybinding(w.name, 'o', gocui.ModNone, w.editSortAction); err != nil {
+		if err := keybinding.Set(g, w.name, 'k', gocui.ModNone, w.columnManagerAction, "show / hide columns"); err != nil {
 			log.Panicln(err)
 		}
 
-		if err := g.SetKeybinding(w.name, 'f', gocui.ModNone, w.editFilterAction); err != nil {
+		if err := keybinding.Set(g, w.name, 'w', gocui.ModNone, w.editColumnWidthAction, "column widths"); err != nil {
 			log.Panicln(err)
 		}
 
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone,
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone,
 			func(g *gocui.Gui, v *gocui.View) error {
 				w.highlightKey = ""
 				w.displayRowIndexOffset = 0
 				w.RefreshDisplay(g)
 				return nil
-			}); err != nil {
+			}, "clear highlight"); err != nil {
 			log.Panicln(err)
 		}
 
@@ -285,10 +405,146 @@ func (w *ListWidget) Layout(g *gocui.Gui) error {
 	return w.RefreshDisplay(g)
 }
 
+// Recompute which columns are displayed based on available width.  Once
+// width drops below COMPACT_MODE_WIDTH, the optional columns with the largest
+// priority value are removed first until the rest fit.
+func (asUI *ListWidget) updateDisplayColumns(width int) {
+	visibleColumns := asUI.visibleColumns()
+	// Count changes when a display mode shows / removes columns
+	if width == asUI.compactWidth && len(asUI.columns) > 0 && len(visibleColumns) == asUI.visibleColumnCount {
+		return
+	}
+	asUI.compactWidth = width
+	asUI.visibleColumnCount = len(visibleColumns)
+	for _, column := range asUI.allColumns {
+		column.compactExtraWidth = 0
+	}
+	if width >= COMPACT_MODE_WIDTH {
+		asUI.columns = visibleColumns
+		if asUI.displayColIndexOffset >= len(visibleColumns) {
+			asUI.displayColIndexOffset = 0
+		}
+		return
+	}
+
+	totalWidth := 0
+	maxPriority := 0
+	for _, column := range visibleColumns {
+		totalWidth = totalWidth + column.Width() + 1
+		if column.priority > maxPriority {
+			maxPriority = column.priority
+		}
+	}
+
+	hidePriority := maxPriority + 1
+	for totalWidth > width && hidePriority > 1 {
+		hidePriority--
+		for _, column := range visibleColumns {
+			if column.priority == hidePriority {
+				totalWidth = totalWidth - (column.Width() + 1)
+			}
+		}
+	}
+
+	columns := make([]*ListColumn, 0, len(visibleColumns))
+	for _, column := range visibleColumns {
+		if column.priority < hidePriority {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) < len(visibleColumns) {
+		growColumns(columns, width-totalWidth)
+	}
+	asUI.columns = columns
+	if asUI.displayColIndexOffset >= len(columns) {
+		asUI.displayColIndexOffset = 0
+	}
+}
+
+// Split the width freed by hiding columns between the essential (priority
+// zero) text columns, e.g., the app name, so they show more of the value
+func growColumns(columns []*ListColumn, freeWidth int) {
+	growable := make([]*ListColumn, 0)
+	for _, column := range columns {
+		if column.priority == 0 && column.columnType == ALPHANUMERIC && column.leftJustifyLabel {
+			growable = append(growable, column)
+		}
+	}
+	if freeWidth <= 0 || len(growable) == 0 {
+		return
+	}
+	for i, column := range growable {
+		column.compactExtraWidth = freeWidth / len(growable)
+		if i < freeWidth%len(growable) {
+			column.compactExtraWidth++
+		}
+	}
+}
+
+// Columns that have not been hidden with the column manager and are
+// shown in the current display mode
+func (asUI *ListWidget) visibleColumns() []*ListColumn {
+	columns := make([]*ListColumn, 0, len(asUI.allColumns))
+	for _, column := range asUI.allColumns {
+		if !column.hidden && (column.shownFunc == nil || column.shownFunc()) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Are optional columns currently hidden because of terminal width
+func (asUI *ListWidget) IsCompactMode() bool {
+	return len(asUI.columns) < len(asUI.visibleColumns())
+}
+
+// Set the number of leftmost (identifying) columns that remain visible
+// while the rest of the columns are scrolled horizontally
+func (asUI *ListWidget) SetLockColumns(lockColumns int) {
+	if lockColumns < 0 {
+		lockColumns = 0
+	}
+	asUI.lockColumns = lockColumns
+}
+
 func (asUI *ListWidget) HighlightKey() string {
 	return asUI.highlightKey
 }
 
+// Highlight the (filtered) row with the given key and scroll it into view.
+// Returns false if no displayed row has that key.
+func (asUI *ListWidget) SetHighlightKey(g *gocui.Gui, key string) bool {
+	rowIndex := -1
+	for i, data := range asUI.listData {
+		if data.Id() == key {
+			rowIndex = i
+			break
+		}
+	}
+	if rowIndex < 0 {
+		return false
+	}
+	asUI.highlightKey = key
+
+	v, err := g.View(asUI.name)
+	if err == nil {
+		_, viewY := v.Size()
+		viewSize := viewY - 1
+		if rowIndex < asUI.displayRowIndexOffset || rowIndex >= asUI.displayRowIndexOffset+viewSize {
+			offset := rowIndex - (viewSize / 2)
+			if offset > len(asUI.listData)-viewSize {
+				offset = len(asUI.listData) - viewSize
+			}
+			if offset < 0 {
+				offset = 0
+			}
+			asUI.displayRowIndexOffset = offset
+		}
+	}
+	asUI.RefreshDisplay(g)
+	return true
+}
+
 // Get the highlighted data row
 func (asUI *ListWidget) HighlightData() IData {
 	for _, data := range asUI.unfilteredListData {
@@ -303,6 +559,28 @@ func (asUI *ListWidget) GetFilterColumnMap() map[string]*FilterColumn {
 	return asUI.filterColumnMap
 }
 
+// Filter text by column id of the columns that have a filter
+func (asUI *ListWidget) FilterTexts() map[string]string {
+	filterTexts := make(map[string]string)
+	for columnId, filter := range asUI.filterColumnMap {
+		if filter != nil && filter.filterText != "" {
+			filterTexts[columnId] = filter.filterText
+		}
+	}
+	return filterTexts
+}
+
+// Replace all column filters.  Ids of columns that are not defined for
+// the list are ignored.
+func (asUI *ListWidget) SetFilterTexts(filterTexts map[string]string) {
+	asUI.filterColumnMap = make(map[string]*FilterColumn)
+	for columnId, filterText := range filterTexts {
+		if asUI.columnMap[columnId] != nil {
+			asUI.filterColumnMap[columnId] = &FilterColumn{filterText: filterText}
+		}
+	}
+}
+
 func (asUI *ListWidget) SetListData(listData []IData) {
 	asUI.unfilteredListData = listData
 	asUI.FilterAndSortData()
@@ -314,7 +592,67 @@ func (asUI *ListWidget) GetListData() []IData {
 
 func (asUI *ListWidget) FilterAndSortData() {
 	filteredData := asUI.filterData(asUI.unfilteredListData)
+	previousRowIndex := asUI.highlightRowIndex()
 	asUI.listData = asUI.sortData(filteredData)
+	asUI.trackHighlight(previousRowIndex)
+}
+
+// Index of the highlighted row in the (filtered) list, -1 if none
+func (asUI *ListWidget) highlightRowIndex() int {
+	if asUI.highlightKey == "" {
+		return -1
+	}
+	for i, data := range asUI.listData {
+		if data.Id() == asUI.highlightKey {
+			return i
+		}
+	}
+	return -1
+}
+
+// trackHighlight keeps the highlighted row selected and in view after the
+// list data was re-sorted or refreshed (see config.HighlightTrackMode).
+// If the row is gone the row now at its previous position is highlighted.
+func (asUI *ListWidget) trackHighlight(previousRowIndex int) {
+	trackMode := config.HighlightTrackMode()
+	listSize := len(asUI.listData)
+	if previousRowIndex < 0 || trackMode == config.HighlightTrackOff || listSize == 0 {
+		return
+	}
+	rowIndex := asUI.highlightRowIndex()
+	if rowIndex < 0 {
+		rowIndex = previousRowIndex
+		if rowIndex >= listSize {
+			rowIndex = listSize - 1
+		}
+		asUI.highlightKey = asUI.listData[rowIndex].Id()
+	}
+
+	viewSize := asUI.displayRowCount
+	if viewSize <= 0 {
+		return
+	}
+	offset := asUI.displayRowIndexOffset
+	switch trackMode {
+	case config.HighlightTrackPin:
+		screenRow := previousRowIndex - offset
+		if screenRow >= 0 && screenRow < viewSize {
+			offset = rowIndex - screenRow
+		}
+	case config.HighlightTrackView:
+		if rowIndex < offset {
+			offset = rowIndex
+		} else if rowIndex >= offset+viewSize {
+			offset = rowIndex - viewSize + 1
+		}
+	}
+	if offset > listSize-viewSize {
+		offset = listSize - viewSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	asUI.displayRowIndexOffset = offset
 }
 
 func (asUI *ListWidget) sortData(listData []IData) []IData {
@@ -345,7 +683,7 @@ func (asUI *ListWidget) filterData(listData []IData) []IData {
 }
 
 func (asUI *ListWidget) FilterRow(data IData) bool {
-	for _, column := range asUI.columns {
+	for _, column := range asUI.allColumns {
 		filter := asUI.filterColumnMap[column.id]
 		if filter != nil && filter.filterText != "" {
 			if !asUI.filterRow(data, column, filter) {
@@ -449,6 +787,12 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 	}
 	_, maxY := v.Size()
 	maxRows := maxY - 1
+	// Last line of the view is the position footer when there is room
+	showFooter := maxRows > 1
+	if showFooter {
+		maxRows--
+	}
+	asUI.displayRowCount = maxRows
 
 	title := asUI.Title
 	displayListSize := len(asUI.listData)
@@ -456,6 +800,12 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 	if displayListSize != unfilteredListSize {
 		title = fmt.Sprintf("%v (filter showing %v of %v)", title, displayListSize, unfilteredListSize)
 	}
+	if asUI.IsCompactMode() {
+		title = fmt.Sprintf("%v (compact)", title)
+	}
+	if asUI.TitleNote != "" {
+		title = fmt.Sprintf("%v %v", title, asUI.TitleNote)
+	}
 	v.Title = title
 
 	v.Clear()
@@ -476,6 +826,9 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 			}
 			asUI.writeRowData(g, v, i)
 		}
+		if showFooter {
+			asUI.writePositionFooter(v, maxRows)
+		}
 	} else {
 		if len(asUI.unfilteredListData) > 0 {
 			fmt.Fprint(v, " \n No data to display because of filters")
@@ -487,6 +840,61 @@ func (asUI *ListWidget) RefreshDisplay(g *gocui.Gui) error {
 	return nil
 }
 
+// Write the position text on the last line of the view, below any
+// unused row lines
+func (asUI *ListWidget) writePositionFooter(v *gocui.View, maxRows int) {
+	positionText := asUI.positionText(maxRows)
+	if positionText == "" {
+		return
+	}
+	rowsWritten := len(asUI.listData) - asUI.displayRowIndexOffset
+	if rowsWritten > maxRows {
+		rowsWritten = maxRows
+	} else if rowsWritten < 0 {
+		rowsWritten = 0
+	}
+	for i := rowsWritten; i < maxRows; i++ {
+		fmt.Fprintln(v)
+	}
+	if asUI.FooterNoteFunc != nil {
+		if note := asUI.FooterNoteFunc(); note != "" {
+			positionText = fmt.Sprintf("%v, %v", positionText, note)
+		}
+	}
+	fmt.Fprintf(v, "%v %v%v", util.DIM_WHITE, positionText, util.CLEAR)
+}
+
+// Position within the (filtered) list and primary sort column,
+// e.g., "rows 21-40 of 312, sort: CPU%↓"
+func (asUI *ListWidget) positionText(maxRows int) string {
+	listSize := len(asUI.listData)
+	if listSize == 0 {
+		return ""
+	}
+	firstRow := asUI.displayRowIndexOffset + 1
+	lastRow := asUI.displayRowIndexOffset + maxRows
+	if lastRow > listSize {
+		lastRow = listSize
+	}
+	if firstRow > lastRow {
+		firstRow = lastRow
+	}
+	text := fmt.Sprintf("rows %v-%v of %v", firstRow, lastRow, listSize)
+
+	if len(asUI.sortColumns) > 0 {
+		sortCol := asUI.sortColumns[0]
+		column := asUI.columnMap[sortCol.Id]
+		if column != nil {
+			direction := UpArrow
+			if sortCol.ReverseSort {
+				direction = DownArrow
+			}
+			text = fmt.Sprintf("%v, sort: %v%v", text, column.Label(), direction)
+		}
+	}
+	return text
+}
+
 func (asUI *ListWidget) writeRowData(g *gocui.Gui, v *gocui.View, rowIndex int) {
 	rowData := asUI.listData[rowIndex]
 	isSelected := false
@@ -500,8 +908,10 @@ func (asUI *ListWidget) writeRowData(g *gocui.Gui, v *gocui.View, rowIndex int)
 		sortColumnId = asUI.sortColumns[0].Id
 	}
 
+	preRowString := ""
 	if asUI.PreRowDisplayFunc != nil {
-		fmt.Fprint(v, asUI.PreRowDisplayFunc(rowData, isSelected))
+		preRowString = asUI.PreRowDisplayFunc(rowData, isSelected)
+		fmt.Fprint(v, preRowString)
 	}
 
 	// Loop through all columns
@@ -510,7 +920,7 @@ func (asUI *ListWidget) writeRowData(g *gocui.Gui, v *gocui.View, rowIndex int)
 		if colIndex > asUI.lastColumnCanDisplay(g, asUI.displayColIndexOffset) {
 			break
 		}
-		if colIndex >= LOCK_COLUMNS && colIndex < asUI.displayColIndexOffset+LOCK_COLUMNS {
+		if colIndex >= asUI.lockColumns && colIndex < asUI.displayColIndexOffset+asUI.lockColumns {
 			continue
 		}
 
@@ -550,11 +960,12 @@ func (asUI *ListWidget) writeRowData(g *gocui.Gui, v *gocui.View, rowIndex int)
 			fmt.Fprintf(v, "%v", colorString)
 		}
 
-		fmt.Fprint(v, column.displayFunc(rowData, asUI.columnOwner))
+		fmt.Fprint(v, column.fitWidth(rowData, column.displayFunc(rowData, asUI.columnOwner)))
 		if !isSelected && colorString != "" {
-			fmt.Fprint(v, util.CLEAR)
+			// Restore row level color (if any) for the remaining columns
+			fmt.Fprint(v, util.CLEAR+preRowString)
 		}
-		fmt.Fprint(v, " ")
+		fmt.Fprint(v, asUI.columnSeparator(colIndex))
 	}
 	fmt.Fprint(v, "\n")
 	fmt.Fprint(v, util.CLEAR)
@@ -571,7 +982,7 @@ func (asUI *ListWidget) writeHeader(g *gocui.Gui, v *gocui.View) {
 		if colIndex > lastColumnCanDisplay {
 			break
 		}
-		if colIndex >= LOCK_COLUMNS && colIndex < asUI.displayColIndexOffset+LOCK_COLUMNS {
+		if colIndex >= asUI.lockColumns && colIndex < asUI.displayColIndexOffset+asUI.lockColumns {
 			continue
 		}
 		colorString := ""
@@ -585,10 +996,16 @@ func (asUI *ListWidget) writeHeader(g *gocui.Gui, v *gocui.View) {
 		if column.leftJustifyLabel {
 			buffer.WriteString("-")
 		}
-		buffer.WriteString(strconv.Itoa(column.size))
-		buffer.WriteString("v ")
+		buffer.WriteString(strconv.Itoa(column.Width()))
+		if column.isResized() {
+			// Labels are only truncated when the width has been changed
+			buffer.WriteString(".")
+			buffer.WriteString(strconv.Itoa(column.Width()))
+		}
+		buffer.WriteString("v")
+		buffer.WriteString(asUI.columnSeparator(colIndex))
 
-		label := column.label
+		label := column.Label()
 
 		if len(asUI.sortColumns) > 0 {
 			sortCol := asUI.sortColumns[0]
@@ -617,6 +1034,37 @@ func (asUI *ListWidget) writeHeader(g *gocui.Gui, v *gocui.View) {
 	fmt.Fprint(v, "\n")
 }
 
+// Copy the current (filtered and sorted) list to the clipboard as
+// plain text with aligned columns
+func (asUI *ListWidget) copyTableAction(g *gocui.Gui, v *gocui.View) error {
+	err := clipboard.WriteAll(asUI.TableText())
+	if err != nil {
+		toplog.Error("Copy table into Clipboard error: " + err.Error())
+		return nil
+	}
+	toplog.Info("Copied %v rows to clipboard as table", len(asUI.listData))
+	return nil
+}
+
+// Render the displayed columns of the list as a plain text table.  Each
+// cell is padded / truncated to the column's display width and any ANSI
+// color codes are removed.
+func (asUI *ListWidget) TableText() string {
+	var buffer bytes.Buffer
+	formatter := &TextFormatter{ColumnOwner: asUI.columnOwner}
+	formatter.Format(asUI.listData, asUI.columns, &buffer)
+	return buffer.String()
+}
+
+// The space after a column, or a divider after the last locked
+// column when the columns to its right have been scrolled
+func (asUI *ListWidget) columnSeparator(colIndex int) string {
+	if asUI.displayColIndexOffset > 0 && colIndex == asUI.lockColumns-1 {
+		return ColumnDivider
+	}
+	return " "
+}
+
 func (asUI *ListWidget) lastColumnCanDisplay(g *gocui.Gui, ifDisplayColIndexOffset int) int {
 
 	v, err := g.View(asUI.name)
@@ -628,10 +1076,10 @@ func (asUI *ListWidget) lastColumnCanDisplay(g *gocui.Gui, ifDisplayColIndexOffs
 	totalWidth := 0
 	lastColumnCanDisplay := len(asUI.columns) - 1
 	for colIndex, column := range asUI.columns {
-		if colIndex >= LOCK_COLUMNS && colIndex < ifDisplayColIndexOffset+LOCK_COLUMNS {
+		if colIndex >= asUI.lockColumns && colIndex < ifDisplayColIndexOffset+asUI.lockColumns {
 			continue
 		}
-		totalWidth = totalWidth + column.size
+		totalWidth = totalWidth + column.Width()
 		if totalWidth > maxX {
 			lastColumnCanDisplay = colIndex - 1
 			break
@@ -680,7 +1128,7 @@ func (asUI *ListWidget) columnOffsetFromVisability(g *gocui.Gui, findColumnId st
 	foundColumnIndex := 0
 	// Loop through all columns (for headers)
 	for colIndex, column := range asUI.columns {
-		if colIndex < asUI.displayColIndexOffset+LOCK_COLUMNS {
+		if colIndex < asUI.displayColIndexOffset+asUI.lockColumns {
 			//continue
 		} else {
 			if firstDisplayedColumnIndex == -1 {
@@ -895,6 +1343,19 @@ func (asUI *ListWidget) editSortAction(g *gocui.Gui, v *gocui.View) error {
 	return asUI.RefreshDisplay(g)
 }
 
+func (asUI *ListWidget) editColumnWidthAction(g *gocui.Gui, v *gocui.View) error {
+	editViewName := asUI.name + ".editColumnWidthView"
+	asUI.selectColumnMode = true
+	if asUI.selectedColumnId == "" {
+		asUI.selectedColumnId = asUI.columns[0].id
+	}
+	editView := NewEditColumnWidthView(asUI.masterUI, editViewName, asUI)
+	asUI.masterUI.LayoutManager().Add(editView)
+	asUI.masterUI.SetCurrentViewOnTop(g)
+	asUI.masterUI.SetEditColumnMode(g, true)
+	return asUI.RefreshDisplay(g)
+}
+
 func (asUI *ListWidget) enableSelectColumnMode(enable bool) {
 	asUI.selectColumnMode = enable
 }
@@ -0,0 +1,196 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uiCommon
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type highlightRow struct {
+	id    string
+	count int
+}
+
+func (r *highlightRow) Id() string {
+	return r.id
+}
+
+// Rows with the given ids, the first has the highest count
+func highlightRows(ids ...string) []IData {
+	rows := make([]IData, 0, len(ids))
+	for i, id := range ids {
+		rows = append(rows, &highlightRow{id: id, count: len(ids) - i})
+	}
+	return rows
+}
+
+func rowIds(rows []IData) []string {
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.Id())
+	}
+	return ids
+}
+
+var _ = Describe("ListWidget", func() {
+
+	Describe("updateDisplayColumns", func() {
+		var (
+			name     *ListColumn
+			state    *ListColumn
+			cpu      *ListColumn
+			requests *ListColumn
+			asUI     *ListWidget
+		)
+
+		BeforeEach(func() {
+			name = &ListColumn{id: "NAME", size: 30, columnType: ALPHANUMERIC, leftJustifyLabel: true}
+			state = &ListColumn{id: "STATE", size: 10, columnType: ALPHANUMERIC, leftJustifyLabel: true, priority: 1}
+			cpu = &ListColumn{id: "CPU", size: 8, columnType: NUMERIC}
+			requests = &ListColumn{id: "REQ", size: 40, columnType: NUMERIC, priority: 2}
+			asUI = &ListWidget{allColumns: []*ListColumn{name, state, cpu, requests}}
+		})
+
+		It("shows all columns at full width", func() {
+			asUI.updateDisplayColumns(COMPACT_MODE_WIDTH)
+			Expect(asUI.columns).To(Equal([]*ListColumn{name, state, cpu, requests}))
+			Expect(name.Width()).To(Equal(30))
+		})
+
+		It("gives the width of hidden columns to the name column", func() {
+			asUI.updateDisplayColumns(60)
+			Expect(asUI.columns).To(Equal([]*ListColumn{name, state, cpu}))
+			// 60 - (31 + 11 + 9)
+			Expect(name.Width()).To(Equal(39))
+			Expect(state.Width()).To(Equal(10))
+			Expect(cpu.Width()).To(Equal(8))
+		})
+
+		It("drops the extra width when widened again", func() {
+			asUI.updateDisplayColumns(60)
+			asUI.updateDisplayColumns(COMPACT_MODE_WIDTH)
+			Expect(name.Width()).To(Equal(30))
+			Expect(name.isResized()).To(BeFalse())
+		})
+
+		It("keeps an edited width as the starting point", func() {
+			name.width = 20
+			asUI.updateDisplayColumns(60)
+			Expect(name.baseWidth()).To(Equal(20))
+			Expect(name.Width()).To(Equal(39))
+		})
+	})
+
+	Describe("highlight tracking", func() {
+		var asUI *ListWidget
+
+		BeforeEach(func() {
+			count := &ListColumn{id: "COUNT", sortFunc: func(c1, c2 util.Sortable) bool {
+				return c1.(*highlightRow).count > c2.(*highlightRow).count
+			}}
+			asUI = &ListWidget{
+				allColumns:      []*ListColumn{count},
+				columnMap:       map[string]*ListColumn{"COUNT": count},
+				filterColumnMap: make(map[string]*FilterColumn),
+				sortColumns:     []*SortColumn{NewSortColumn("COUNT", false)},
+				displayRowCount: 3,
+			}
+			asUI.SetListData(highlightRows("a", "b", "c", "d", "e"))
+			asUI.highlightKey = "c"
+		})
+
+		AfterEach(func() {
+			config.SetHighlightTrackMode(config.HighlightTrackPin)
+		})
+
+		It("finds the index of the highlighted row", func() {
+			Expect(asUI.highlightRowIndex()).To(Equal(2))
+			asUI.highlightKey = "x"
+			Expect(asUI.highlightRowIndex()).To(Equal(-1))
+			asUI.highlightKey = ""
+			Expect(asUI.highlightRowIndex()).To(Equal(-1))
+		})
+
+		It("keeps the highlight on the same screen row after a re-sort", func() {
+			config.SetHighlightTrackMode(config.HighlightTrackPin)
+			asUI.displayRowIndexOffset = 1
+			asUI.SetListData(highlightRows("a", "b", "d", "e", "c"))
+			Expect(asUI.highlightKey).To(Equal("c"))
+			Expect(asUI.highlightRowIndex()).To(Equal(4))
+			// c was on screen row 1, offset limited to the end of the list
+			Expect(asUI.displayRowIndexOffset).To(Equal(2))
+		})
+
+		It("scrolls only enough to keep the highlight in view", func() {
+			config.SetHighlightTrackMode(config.HighlightTrackView)
+			asUI.SetListData(highlightRows("a", "b", "d", "c", "e"))
+			Expect(asUI.highlightKey).To(Equal("c"))
+			Expect(asUI.displayRowIndexOffset).To(Equal(1))
+
+			asUI.SetListData(highlightRows("c", "a", "b", "d", "e"))
+			Expect(asUI.displayRowIndexOffset).To(Equal(0))
+		})
+
+		It("does not move the view when tracking is off", func() {
+			config.SetHighlightTrackMode(config.HighlightTrackOff)
+			asUI.SetListData(highlightRows("a", "b", "d", "e", "c"))
+			Expect(asUI.highlightKey).To(Equal("c"))
+			Expect(asUI.displayRowIndexOffset).To(Equal(0))
+		})
+
+		It("highlights the row now at the position of a removed row", func() {
+			asUI.SetListData(highlightRows("a", "b", "d", "e"))
+			Expect(rowIds(asUI.listData)).To(Equal([]string{"a", "b", "d", "e"}))
+			Expect(asUI.highlightKey).To(Equal("d"))
+		})
+
+		It("highlights the last row when the removed row was past the end", func() {
+			asUI.highlightKey = "e"
+			asUI.SetListData(highlightRows("a", "b", "c"))
+			Expect(asUI.highlightKey).To(Equal("c"))
+			Expect(asUI.displayRowIndexOffset).To(Equal(0))
+		})
+
+		It("does not highlight a row when there was no highlight", func() {
+			asUI.highlightKey = ""
+			asUI.SetListData(highlightRows("e", "d", "c"))
+			Expect(asUI.highlightKey).To(Equal(""))
+		})
+	})
+
+	Describe("positionText", func() {
+		It("shows the rows in view and the primary sort", func() {
+			cpu := &ListColumn{id: "CPU", label: "CPU%"}
+			asUI := &ListWidget{
+				listData:              make([]IData, 45),
+				displayRowIndexOffset: 20,
+				columnMap:             map[string]*ListColumn{"CPU": cpu},
+				sortColumns:           []*SortColumn{NewSortColumn("CPU", true)},
+			}
+			Expect(asUI.positionText(20)).To(Equal("rows 21-40 of 45, sort: CPU%" + DownArrow))
+			asUI.displayRowIndexOffset = 40
+			Expect(asUI.positionText(20)).To(Equal("rows 41-45 of 45, sort: CPU%" + DownArrow))
+		})
+
+		It("is empty without rows", func() {
+			Expect((&ListWidget{}).positionText(20)).To(Equal(""))
+		})
+	})
+
+})
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
@@ -30,12 +32,37 @@ type menuItemSelectedCallbackFunc func(g *gocui.Gui, v *gocui.View, menuId strin
 type MenuItem struct {
 	id    string
 	label string
+	// Non-empty if the item is disabled, shown next to the label to explain
+	// why the item is not available
+	disabledReason string
 }
 
 func NewMenuItem(id, label string) *MenuItem {
 	return &MenuItem{id: id, label: label}
 }
 
+// Disable the menu item.  Disabled items are shown dimmed with the reason
+// and are skipped when moving through the menu.
+func (m *MenuItem) Disable(reason string) *MenuItem {
+	if reason == "" {
+		reason = "not available"
+	}
+	m.disabledReason = reason
+	return m
+}
+
+func (m *MenuItem) IsDisabled() bool {
+	return m.disabledReason != ""
+}
+
+// Label with the disabled reason (if any)
+func (m *MenuItem) displayLabel() string {
+	if m.IsDisabled() {
+		return fmt.Sprintf("%v (%v)", m.label, m.disabledReason)
+	}
+	return m.label
+}
+
 type SelectMenuWidget struct {
 	masterUI masterUIInterface.MasterUIInterface
 	name     string
@@ -46,7 +73,9 @@ type SelectMenuWidget struct {
 	menuItemSelectedCallback menuItemSelectedCallbackFunc
 
 	menuPosition int
-	menuItems    []*MenuItem
+	// First menu item displayed when there are more items then fit on screen
+	menuOffset int
+	menuItems  []*MenuItem
 }
 
 func NewSelectMenuWidget(
@@ -66,6 +95,7 @@ func NewSelectMenuWidget(
 
 	w.width = w.getMaxMenuLabelSize() + 14
 	w.height = len(menuItems) + 3
+	w.menuPosition = w.nextEnabledPosition(-1, 1)
 
 	return w
 }
@@ -76,28 +106,32 @@ func (w *SelectMenuWidget) Name() string {
 
 func (w *SelectMenuWidget) Layout(g *gocui.Gui) error {
 	maxX, maxY := g.Size()
+	height := w.height
+	if height > maxY-2 {
+		height = maxY - 2
+	}
 	right := maxX/2 - (w.width / 2)
-	top := maxY/2 - (w.height / 2)
-	v, err := g.SetView(w.name, right, top, right+w.width, top+w.height)
+	top := maxY/2 - (height / 2)
+	v, err := g.SetView(w.name, right, top, right+w.width, top+height)
 	if err != nil {
 		if err != gocui.ErrUnknownView {
 			return errors.New(w.name + " layout error:" + err.Error())
 		}
 		v.Title = w.title
 		v.Frame = true
-		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.menuItemSelectedAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.menuItemSelectedAction, "select menu item"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeSelectMenuWidget); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeSelectMenuWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeSelectMenuWidget); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeSelectMenuWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowDown, gocui.ModNone, w.keyArrowDownAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.keyArrowDownAction, "next menu item"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowUp, gocui.ModNone, w.keyArrowUpAction); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.keyArrowUpAction, "previous menu item"); err != nil {
 			return err
 		}
 
@@ -117,17 +151,38 @@ func (w *SelectMenuWidget) RefreshDisplay(g *gocui.Gui) error {
 
 	v.Clear()
 
+	// Scroll the menu so the selected item is visible
+	_, viewY := v.Size()
+	visibleItems := viewY - 2
+	if visibleItems < 1 {
+		visibleItems = 1
+	}
+	if w.menuPosition < w.menuOffset {
+		w.menuOffset = w.menuPosition
+	} else if w.menuPosition >= w.menuOffset+visibleItems {
+		w.menuOffset = w.menuPosition - visibleItems + 1
+	}
+	if w.menuOffset < 0 {
+		w.menuOffset = 0
+	}
+
 	fmt.Fprintln(v, " ")
 	if len(w.menuItems) == 0 {
 		fmt.Fprintln(v, "--empty menu--")
 	}
 	for i, menuItem := range w.menuItems {
+		if i < w.menuOffset || i >= w.menuOffset+visibleItems {
+			continue
+		}
 		fmt.Fprintf(v, "    ")
-		if w.menuPosition == i {
+		switch {
+		case menuItem.IsDisabled():
+			fmt.Fprintf(v, util.WHITE+util.DIM)
+		case w.menuPosition == i:
 			fmt.Fprintf(v, util.REVERSE_WHITE)
 		}
-		fmt.Fprintf(v, "  %v  \n", menuItem.label)
-		if w.menuPosition == i {
+		fmt.Fprintf(v, "  %v  \n", menuItem.displayLabel())
+		if w.menuPosition == i || menuItem.IsDisabled() {
 			fmt.Fprintf(v, util.CLEAR)
 		}
 	}
@@ -138,7 +193,7 @@ func (w *SelectMenuWidget) RefreshDisplay(g *gocui.Gui) error {
 func (w *SelectMenuWidget) SetMenuId(menuId string) {
 	if menuId != "" {
 		for i, menuItem := range w.menuItems {
-			if menuItem.id == menuId {
+			if menuItem.id == menuId && !menuItem.IsDisabled() {
 				w.menuPosition = i
 				break
 			}
@@ -149,7 +204,7 @@ func (w *SelectMenuWidget) SetMenuId(menuId string) {
 func (w *SelectMenuWidget) getMaxMenuLabelSize() int {
 	maxSize := 0
 	for _, menuItem := range w.menuItems {
-		size := len(menuItem.label)
+		size := len(menuItem.displayLabel())
 		if size > maxSize {
 			maxSize = size
 		}
@@ -157,11 +212,35 @@ func (w *SelectMenuWidget) getMaxMenuLabelSize() int {
 	return maxSize
 }
 
+// Selected menu item, nil if the menu has no enabled items
 func (w *SelectMenuWidget) GetMenuSelection() *MenuItem {
-	return w.menuItems[w.menuPosition]
+	if w.menuPosition < 0 || w.menuPosition >= len(w.menuItems) {
+		return nil
+	}
+	menuItem := w.menuItems[w.menuPosition]
+	if menuItem.IsDisabled() {
+		return nil
+	}
+	return menuItem
+}
+
+// Position of the next enabled menu item from position moving in direction
+// (1 down, -1 up).  Returns position if there is no enabled item that way.
+func (w *SelectMenuWidget) nextEnabledPosition(position, direction int) int {
+	for i := position + direction; i >= 0 && i < len(w.menuItems); i += direction {
+		if !w.menuItems[i].IsDisabled() {
+			return i
+		}
+	}
+	return position
 }
 
 func (w *SelectMenuWidget) menuItemSelectedAction(g *gocui.Gui, v *gocui.View) error {
+	if w.GetMenuSelection() == nil {
+		// Keep the menu open, nothing can be selected
+		toplog.Info("No menu item available to select")
+		return nil
+	}
 	if w.menuItemSelectedCallback != nil {
 		w.menuItemSelectedCallback(g, v, w.GetMenuSelection().id)
 	}
@@ -176,15 +255,11 @@ func (w *SelectMenuWidget) closeSelectMenuWidget(g *gocui.Gui, v *gocui.View) er
 }
 
 func (w *SelectMenuWidget) keyArrowDownAction(g *gocui.Gui, v *gocui.View) error {
-	if w.menuPosition+1 < len(w.menuItems) {
-		w.menuPosition++
-	}
+	w.menuPosition = w.nextEnabledPosition(w.menuPosition, 1)
 	return w.RefreshDisplay(g)
 }
 
 func (w *SelectMenuWidget) keyArrowUpAction(g *gocui.Gui, v *gocui.View) error {
-	if w.menuPosition > 0 {
-		w.menuPosition--
-	}
+	w.menuPosition = w.nextEnabledPosition(w.menuPosition, -1)
 	return w.RefreshDisplay(g)
 }
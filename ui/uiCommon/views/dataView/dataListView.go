@@ -16,11 +16,16 @@
 package dataView
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
@@ -32,6 +37,7 @@ type actionCallback func(g *gocui.Gui, v *gocui.View) error
 type initializeCallback func(g *gocui.Gui, viewName string) error
 type preRowDisplayCallback func(data uiCommon.IData, isSelected bool) string
 type refreshDisplayCallback func(g *gocui.Gui) error
+type footerNoteCallback func() string
 type IColumnOwner interface{}
 
 type GetListData func() []uiCommon.IData
@@ -54,10 +60,16 @@ type DataListView struct {
 	InitializeCallback    initializeCallback
 	UpdateHeaderCallback  updateHeaderCallback
 	PreRowDisplayCallback preRowDisplayCallback
+	FooterNoteCallback    footerNoteCallback
 	columnOwner           IColumnOwner
 
 	RefreshDisplayCallback refreshDisplayCallback
 	GetListData            GetListData
+
+	// Firehose event types the view's data comes from, processed while the
+	// view is open.  Nil if the view needs all types.  The title notes the
+	// ones discarded by the -event-types option.
+	EventTypes []events.Envelope_EventType
 }
 
 func NewDataListView(masterUI masterUIInterface.MasterUIInterface,
@@ -83,6 +95,7 @@ func NewDataListView(masterUI masterUIInterface.MasterUIInterface,
 	listWidget := uiCommon.NewListWidget(asUI.masterUI, asUI.name,
 		asUI.bottomMargin, asUI, columnDefinitions, columnOwner)
 	listWidget.PreRowDisplayFunc = asUI.PreRowDisplay
+	listWidget.FooterNoteFunc = asUI.FooterNote
 	listWidget.SetSortColumns(defaultSortColumns)
 
 	asUI.listWidget = listWidget
@@ -139,6 +152,10 @@ func (asUI *DataListView) GetEventProcessor() *eventdata.EventProcessor {
 	return asUI.eventProcessor
 }
 
+func (asUI *DataListView) GetEventTypes() []events.Envelope_EventType {
+	return asUI.EventTypes
+}
+
 func (asUI *DataListView) GetAppMdMgr() *app.AppMetadataManager {
 	return asUI.appMdMgr
 }
@@ -156,13 +173,13 @@ func (asUI *DataListView) Layout(g *gocui.Gui) error {
 }
 
 func (asUI *DataListView) initialize(g *gocui.Gui) {
-	if err := g.SetKeybinding(asUI.name, 'h', gocui.ModNone,
+	if err := keybinding.Set(g, asUI.name, 'h', gocui.ModNone,
 		func(g *gocui.Gui, v *gocui.View) error {
 			helpView := helpView.NewHelpView(asUI.masterUI, "helpView", 75, 17, asUI.HelpText)
 			asUI.masterUI.LayoutManager().Add(helpView)
 			asUI.masterUI.SetCurrentViewOnTop(g)
 			return nil
-		}); err != nil {
+		}, "help"); err != nil {
 		log.Panicln(err)
 	}
 	if asUI.InitializeCallback != nil {
@@ -210,6 +227,7 @@ func (asUI *DataListView) RefreshDisplay(g *gocui.Gui) error {
 }
 
 func (asUI *DataListView) refreshListDisplay(g *gocui.Gui) error {
+	asUI.listWidget.TitleNote = asUI.filteredEventTypesNote()
 	err := asUI.listWidget.RefreshDisplay(g)
 	if err != nil {
 		return err
@@ -217,6 +235,19 @@ func (asUI *DataListView) refreshListDisplay(g *gocui.Gui) error {
 	return err
 }
 
+func (asUI *DataListView) filteredEventTypesNote() string {
+	filtered := make([]string, 0)
+	for _, eventType := range asUI.EventTypes {
+		if !asUI.eventProcessor.IsEventTypeEnabled(eventType) {
+			filtered = append(filtered, eventType.String())
+		}
+	}
+	if len(filtered) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%v filtered)", strings.Join(filtered, ","))
+}
+
 func (asUI *DataListView) UpdateDisplay(g *gocui.Gui) error {
 	asUI.updateData()
 	return asUI.RefreshDisplay(g)
@@ -232,8 +263,10 @@ func (asUI *DataListView) CloseDetailView(g *gocui.Gui, v *gocui.View) error {
 // XXX
 func (asUI *DataListView) updateData() {
 	//asUI.eventProcessor.UpdateData()
+	startTime := time.Now()
 	listData := asUI.GetListData()
 	asUI.listWidget.SetListData(listData)
+	CheckRefreshBudget(asUI.name, len(listData), startTime)
 }
 
 func (asUI *DataListView) PreRowDisplay(data uiCommon.IData, isSelected bool) string {
@@ -243,6 +276,13 @@ func (asUI *DataListView) PreRowDisplay(data uiCommon.IData, isSelected bool) st
 	return ""
 }
 
+func (asUI *DataListView) FooterNote() string {
+	if asUI.FooterNoteCallback != nil {
+		return asUI.FooterNoteCallback()
+	}
+	return ""
+}
+
 func (asUI *DataListView) updateHeader(g *gocui.Gui) (int, error) {
 
 	v, err := g.View("headerView")
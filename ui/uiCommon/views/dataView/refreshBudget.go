@@ -0,0 +1,58 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataView
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+)
+
+var (
+	overBudgetMu sync.Mutex
+	// Key: refresh step name, true while the step is over budget
+	overBudget = make(map[string]bool)
+)
+
+// Log a warning when a refresh step that started at startTime goes over
+// config.RefreshBudgetMS and again (as info) when it is back within budget.
+// Only changes are logged so a step that is slow on every refresh does
+// not flood the log.  Uses the wall clock (not the clock package) as this
+// measures real processing time, including during a replay.
+func CheckRefreshBudget(name string, rows int, startTime time.Time) {
+	budgetMS := config.RefreshBudgetMS()
+	if budgetMS <= 0 {
+		return
+	}
+	elapsed := time.Since(startTime)
+	isOver := elapsed > time.Duration(budgetMS)*time.Millisecond
+
+	overBudgetMu.Lock()
+	wasOver := overBudget[name]
+	overBudget[name] = isOver
+	overBudgetMu.Unlock()
+
+	switch {
+	case isOver && !wasOver:
+		toplog.Warn("Slow refresh: %v took %v for %v rows (budget %vms)",
+			name, elapsed.Truncate(time.Millisecond), rows, budgetMS)
+	case !isOver && wasOver:
+		toplog.Info("Refresh back within budget: %v took %v for %v rows (budget %vms)",
+			name, elapsed.Truncate(time.Millisecond), rows, budgetMS)
+	}
+}
@@ -22,6 +22,10 @@ const HelpHeaderText = `
   Warm-up      - It can take up to 60 seconds to receive all event
                  information before stats are accurate.
   Duration     - Amount of time stats has been collecting data.
+  Last event   - Time since the last firehose envelope was received,
+                 yellow after 30 seconds.  With -firehose-idle-timeout
+                 (default 120 seconds) the firehose is reconnected
+                 when no event is received for that long.
   Target       - The target URL of monitored foundation.
   IsoSeg       - Isolation Segment (shown if foundation has more then 1).
   Stack        - The Cloud Foundry stack where indented fields below
@@ -58,7 +62,10 @@ Press 'x' to exit current view
 
 const HelpCommonDataViewKeybindings = `
 **Select item detail (if available): **
-Press UP arrow or DOWN arrow to highlight an application row.
+Press UP arrow or DOWN arrow to highlight an application row.  The
+highlighted row stays selected when a refresh changes its position in
+the sort order and, by default, stays on the same screen line
+(see -highlight-track).
 Press ENTER to select the highlighted application and show
 additional detail.
 
@@ -74,16 +81,77 @@ which rows should be displayed
 Press RIGHT or LEFT arrow to scroll the columns into view if the
 window is not wide enough to view all columns.  You can also resize
 terminal window to show more columns/rows (resize of cmd.exe window
-is not supported on windows while top is running).
+is not supported on windows while top is running).  On narrow
+terminals (less than 100 columns) some less important columns are
+hidden and "(compact)" is shown in the title.
+
+**Show / hide columns:**
+Press 'k' to show the column manager.  Select a column to toggle
+whether it is displayed.  Some wide columns (e.g., APP_GUID) are
+hidden by default.
+
+**Column widths:**
+Press 'w' to change column widths.  Use RIGHT or LEFT arrow to select
+a column, '+' or '-' to widen / narrow it and '0' to reset it to the
+default width.  ENTER saves the widths for the view in
+top_preferences.json (see Presets), ESC restores the prior widths.
+
+**Copy as table:**
+Press 'T' to copy the rows currently displayed (after filtering and
+sorting) to the clipboard as a plain text table with aligned columns.
+
+**Active keybindings:**
+Press '?' to show a list of all keybindings active in the current
+window.
 
 **Pause display update:**
 Press 'p' to toggle pause display update.  When display update is
 paused top will continue to capture statstics and display updated
 values when unpaused.
 
+**Screen recording:**
+Press shift-V to start / stop recording the screen.  Each refresh that
+changes the screen is appended to cf-top-record.cast (see -record-file)
+in asciicast format which can be replayed with 'asciinema play'.  Use
+-record-no-ansi to record without colors.  "REC" is shown in the header
+while recording.
+
+**Idle pause:**
+When started with -idle-timeout N the display is paused after N minutes
+without a key press and "IDLE - paused" is shown in the header.  With
+-idle-pause-ingest firehose events are also discarded while idle.  The
+next key press resumes (the key is otherwise ignored).
+
+**Org / Space scope:**
+Press shift-O to select an org or space to focus on.  When a scope is
+set all views, header totals and alerts only include apps in that
+org or space.  The active scope is shown in the header.  Select
+"All orgs and spaces" to clear the scope.
+
+**Presets:**
+Press shift-P to save the current view's sort order, column filters,
+visible columns and the org / space scope as a named preset, or to
+apply or delete a preset saved from the same view.  The app list
+crash, not started and SSH filters and the app detail cell IP filter
+are saved with the preset too.  Presets are kept
+in top_preferences.json in the cf CLI config directory (~/.cf or
+$CF_HOME/.cf).  Saving with an existing name replaces that preset.
+
 **Header display toggle:**
 Press 'H' to toggle between full header display and minimal header.
 
+**Totals / per second toggle:**
+Press shift-U to switch rate capable columns (container LOG_OUT and
+LOG_ERR in the app and cell detail views) between totals and per
+second rates computed over the last refresh interval.  "PER-SEC" is
+shown in the header while rates are shown.
+
+**Used / free toggle:**
+Press 'u' to cycle the container memory and disk columns (app and cell
+detail views) between showing both used and free, only used or only
+free.  The single column mode saves width on narrow terminals, sorting
+follows the value shown.
+
 **Refresh screen interval: **
 Press 's' to set the sleep time between refreshes. Default
 is 1 second.  Valid values are 0.1 - 60.  The refresh interval only
@@ -98,7 +166,14 @@ Press SPACE to force an immediate screen refresh.
 **Log Window: **
 Press shift-D to open log window.  This shows internal top
 logging messages.  This window will open automatically if any error
-message is logged (e.g., connection timeouts).
+message is logged (e.g., connection timeouts).  Press 'g' in the log
+window to cycle the category filter (e.g., firehose, metadata).
+
+**Errors View: **
+Press shift-W to open the errors view.  This shows only the error and
+warning messages logged during the session (the last 500) which are
+kept separately from the log window so they are not pushed out by
+other messages.  Press 'w' in the view to toggle showing warnings.
 
 **Clear stats: **
 Press shift-C to clear the statistics counters.
@@ -107,5 +182,10 @@ Press shift-C to clear the statistics counters.
 Press 'r' to force a reload of metadata for app/space/org.  The
 metadata is loaded at startup and attempts to stay current by
 recognizing when specific data needs to be reloaded. However there
-can be circumstances were data becomes stale.
+can be circumstances were data becomes stale.  The reload runs in the
+background and "Loading metadata..." is shown in the header until it
+completes.  Pressing 'r' again while a reload is running is ignored.
+The header shows the age of each metadata cache, e.g., app:2m.
+Caches older than -metadata-warn-minutes (default: 60) are
+highlighted.
 `
@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/jroimartin/gocui"
 )
@@ -64,25 +65,25 @@ func (w *HelpView) Layout(g *gocui.Gui) error {
 		}
 
 		fmt.Fprintf(v, w.displayText)
-		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.closeHelpView); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.closeHelpView, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeHelpView); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeHelpView, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeHelpView); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeHelpView, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp, "scroll up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown, "scroll down"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp, "page up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown, "page down"); err != nil {
 			log.Panicln(err)
 		}
 
@@ -0,0 +1,58 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helpView
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+)
+
+// NewKeybindingHelpView creates a help view listing the keybindings
+// currently registered (see keybinding.Set) for viewName and the global
+// ones.  The list is built when the view is opened so it always reflects
+// what is active.
+func NewKeybindingHelpView(masterUI masterUIInterface.MasterUIInterface, name string, viewName string) *HelpView {
+	helpText := keybindingHelpText(viewName)
+	height := strings.Count(helpText, "\n") + 2
+	if height > 25 {
+		height = 25
+	}
+	return NewHelpView(masterUI, name, 60, height, helpText)
+}
+
+func keybindingHelpText(viewName string) string {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "\n**Active keybindings: %v**\n\n", viewName)
+	writeBindings(&buffer, keybinding.ForView(viewName))
+	fmt.Fprintf(&buffer, "\n**Global keybindings**\n\n")
+	writeBindings(&buffer, keybinding.ForView(""))
+	return buffer.String()
+}
+
+func writeBindings(buffer *bytes.Buffer, bindings []*keybinding.Binding) {
+	if len(bindings) == 0 {
+		fmt.Fprintf(buffer, "  --none--\n")
+	}
+	for _, binding := range bindings {
+		// Help view text is written with Fprintf so escape any %
+		description := strings.Replace(binding.Description, "%", "%%", -1)
+		fmt.Fprintf(buffer, "  **%-12v** %v\n", keybinding.KeyName(binding.Key, binding.Mod), description)
+	}
+}
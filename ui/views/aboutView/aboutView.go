@@ -27,8 +27,10 @@ import (
 
 	"github.com/cloudfoundry/cli/plugin"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/jroimartin/gocui"
 )
@@ -117,25 +119,25 @@ func (w *TopView) Layout(g *gocui.Gui) error {
 		}
 		v.Title = "About Top"
 		v.Frame = true
-		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.closeView); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.closeView, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeView); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeView, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeView); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeView, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp, "scroll up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown, "scroll down"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp, "page up"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown, "page down"); err != nil {
 			log.Panicln(err)
 		}
 
@@ -315,7 +317,7 @@ func (asUI *TopView) checkVersion() (string, error) {
 }
 
 func (asUI *TopView) getJson(url string, target interface{}) error {
-	var client = &http.Client{Timeout: 10 * time.Second}
+	var client = &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{Proxy: config.ProxyFunc()}}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		toplog.Debug("AboutTop get latest version NewRequest error: %v", err)
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
@@ -54,6 +55,21 @@ func (am *AlertManager) CheckForAlerts(g *gocui.Gui) error {
 	am.checkForAppsNotInDesiredState(g)
 	am.checkForErrorMsgDelta(g)
 	am.checkForCrashedApps(g)
+	am.checkForAppsAtMemoryRisk(g)
+	return nil
+}
+
+func (am *AlertManager) checkForAppsAtMemoryRisk(g *gocui.Gui) error {
+	appsAtMemoryRisk := am.commonData.AppsAtMemoryRisk()
+	if appsAtMemoryRisk > 0 {
+		plural := ""
+		if appsAtMemoryRisk > 1 {
+			plural = "s"
+		}
+		return am.ShowMessage(g, APPS_AT_MEMORY_RISK, appsAtMemoryRisk, plural, config.MemoryRiskPercent())
+	} else if am.isUserMessageOpen(g) {
+		return am.ClearUserMessage(g, APPS_AT_MEMORY_RISK)
+	}
 	return nil
 }
 
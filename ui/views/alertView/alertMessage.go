@@ -34,6 +34,7 @@ const (
 var MessageCatalog = make(map[string]*AlertMessage)
 var APPS_NOT_IN_DESIRED_STATE = NewAlertMessage("ANIDS", AlertType, "%v application%v not in desired state (DCR != RCR column)")
 var CONTAINER_CRASHES = NewAlertMessage("CRASH", WarnType, "%v container%v crashed (CRH column) in last 24 hours (%v in last hour)")
+var APPS_AT_MEMORY_RISK = NewAlertMessage("MEMRISK", WarnType, "%v application%v with a container using %v%% or more of its memory (MEM_MAX%% column)")
 var ErrorsSinceViewed = NewAlertMessage("ESV", AlertType, "%v monitoring errors. Data shown may be inaccurate. (shift-D to display)")
 var TestMessage = NewAlertMessage("TM", InfoType, "Test Message")
 
@@ -0,0 +1,120 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appCompareView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// Side by side stats of two apps (e.g., an A/B or canary deployment)
+type AppCompareView struct {
+	*dataView.DataListView
+	appIdA   string
+	appIdB   string
+	appMdMgr *app.AppMetadataManager
+}
+
+func NewAppCompareView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appIdA, appIdB string) *AppCompareView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &AppCompareView{appIdA: appIdA, appIdB: appIdB, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("ORDER", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	// Keep stat name in view when scrolling
+	dataListView.GetListWidget().SetLockColumns(1)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_HttpStartStop, events.Envelope_LogMessage}
+
+	dataListView.SetTitle(fmt.Sprintf("Compare A: %v  B: %v", asUI.getAppName(appIdA), asUI.getAppName(appIdB)))
+
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+}
+
+func (asUI *AppCompareView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppCompareView, "close view"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppCompareView, "close view"); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *AppCompareView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnStat())
+	columns = append(columns, columnOrder().SetHidden(true))
+	columns = append(columns, columnAppValue("APP_A", "A:"+asUI.getAppName(asUI.appIdA), false))
+	columns = append(columns, columnAppValue("APP_B", "B:"+asUI.getAppName(asUI.appIdB), true))
+	columns = append(columns, columnDelta())
+	return columns
+}
+
+func (asUI *AppCompareView) GetListData() []uiCommon.IData {
+	displayStatsMap := asUI.GetMasterUI().GetCommonData().GetDisplayAppStatsMap()
+	appStatsA := displayStatsMap[asUI.appIdA]
+	appStatsB := displayStatsMap[asUI.appIdB]
+
+	listData := make([]uiCommon.IData, 0, len(compareMetrics))
+	for order, metric := range compareMetrics {
+		listData = append(listData, NewDisplayCompareRow(order, metric, appStatsA, appStatsB))
+	}
+	return listData
+}
+
+func (asUI *AppCompareView) closeAppCompareView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *AppCompareView) getAppName(appId string) string {
+	appName := asUI.appMdMgr.DisplayName(asUI.appMdMgr.FindAppMetadata(appId))
+	if appName == "" {
+		return appId
+	}
+	return appName
+}
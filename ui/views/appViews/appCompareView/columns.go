@@ -0,0 +1,136 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appCompareView
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// Hidden, keeps the rows in compareMetrics order
+func columnOrder() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCompareRow).order < c2.(*DisplayCompareRow).order
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		row := data.(*DisplayCompareRow)
+		return fmt.Sprintf("%3v", row.order)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		row := data.(*DisplayCompareRow)
+		return fmt.Sprintf("%v", row.order)
+	}
+	c := uiCommon.NewListColumn("ORDER", "#", 3,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnStat() *uiCommon.ListColumn {
+	defaultColSize := 25
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayCompareRow).metric.label, c2.(*DisplayCompareRow).metric.label)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		row := data.(*DisplayCompareRow)
+		return util.FormatDisplayData(row.metric.label, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		row := data.(*DisplayCompareRow)
+		return row.metric.label
+	}
+	c := uiCommon.NewListColumn("STAT", "STAT", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// Value of app A (first app marked) or app B.  The label is the app name.
+func columnAppValue(id string, appName string, isAppB bool) *uiCommon.ListColumn {
+	defaultColSize := 14
+	value := func(row *DisplayCompareRow) (float64, bool) {
+		if isAppB {
+			return row.valueB, row.okB
+		}
+		return row.valueA, row.okA
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		v1, _ := value(c1.(*DisplayCompareRow))
+		v2, _ := value(c2.(*DisplayCompareRow))
+		return v1 < v2
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		row := data.(*DisplayCompareRow)
+		v, ok := value(row)
+		if !ok {
+			return fmt.Sprintf("%14v", "--")
+		}
+		return fmt.Sprintf("%14v", row.metric.format(v))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		v, _ := value(data.(*DisplayCompareRow))
+		return fmt.Sprintf("%v", v)
+	}
+	label := appName
+	if len(label) > defaultColSize {
+		label = label[:defaultColSize]
+	}
+	c := uiCommon.NewListColumn(id, label, defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// Change from app A to app B with an up / down arrow and the percent change.
+// Highlighted when app B is worse.
+func columnDelta() *uiCommon.ListColumn {
+	defaultColSize := 22
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCompareRow).delta() < c2.(*DisplayCompareRow).delta()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		row := data.(*DisplayCompareRow)
+		if !row.hasDelta() {
+			return fmt.Sprintf("%22v", "--")
+		}
+		delta := row.delta()
+		if delta == 0 {
+			return fmt.Sprintf("%22v", "=")
+		}
+		direction := uiCommon.UpArrow
+		if delta < 0 {
+			direction = uiCommon.DownArrow
+		}
+		deltaDisplay := fmt.Sprintf("%v %v", direction, row.metric.format(math.Abs(delta)))
+		if percent, ok := row.deltaPercent(); ok {
+			deltaDisplay = fmt.Sprintf("%v (%+.0f%%)", deltaDisplay, percent)
+		}
+		return fmt.Sprintf("%22v", deltaDisplay)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		row := data.(*DisplayCompareRow)
+		return fmt.Sprintf("%v", row.delta())
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayCompareRow).isWorse() {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("DELTA", "DELTA (B-A)", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
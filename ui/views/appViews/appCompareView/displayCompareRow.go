@@ -0,0 +1,155 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appCompareView
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+// A stat compared between the two apps
+type compareMetric struct {
+	id    string
+	label string
+	value func(appStats *dataCommon.DisplayAppStats) float64
+	// Format a value (or the absolute delta) for display
+	format func(value float64) string
+	// Direction of change from app A to app B that is shown as worse:
+	// worseHigher, worseLower or 0 if neither
+	worse int
+}
+
+func formatCount(value float64) string {
+	return util.Format(int64(value))
+}
+
+func formatPercent(value float64) string {
+	return fmt.Sprintf("%.2f%%", value)
+}
+
+func formatBytes(value float64) string {
+	return util.FormatBytes(uint64(math.Abs(value)))
+}
+
+func formatMilliseconds(value float64) string {
+	return fmt.Sprintf("%.1fms", value)
+}
+
+const (
+	worseHigher = 1
+	worseLower  = -1
+)
+
+// Rows of the comparison view in display order
+var compareMetrics = []*compareMetric{
+	{"HEALTH", "Health score", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.HealthScore) }, formatCount, worseLower},
+	{"DCR", "Desired containers", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.DesiredContainers) }, formatCount, 0},
+	{"RCR", "Reporting containers", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalReportingContainers) }, formatCount, 0},
+	{"CPU_PERCENT", "CPU%", func(s *dataCommon.DisplayAppStats) float64 { return positive(s.DisplayCpuPercentage) }, formatPercent, worseHigher},
+	{"MEM_USED", "Memory used", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalMemoryUsed) }, formatBytes, worseHigher},
+	{"MEM_MAX", "Max container memory%", func(s *dataCommon.DisplayAppStats) float64 { return s.MaxContainerMemoryPercent }, formatPercent, worseHigher},
+	{"DSK_USED", "Disk used", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalDiskUsed) }, formatBytes, worseHigher},
+	{"CRASH_1H", "Crashes last 1 hour", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.Crash1hCount) }, formatCount, worseHigher},
+	{"CRH", "Crashes last 24 hours", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.Crash24hCount) }, formatCount, worseHigher},
+	{"REQ1", "Requests last 1 sec", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalTraffic.EventL1Rate) }, formatCount, 0},
+	{"REQ10", "Requests last 10 sec", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalTraffic.EventL10Rate) }, formatCount, 0},
+	{"REQ60", "Requests last 60 sec", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalTraffic.EventL60Rate) }, formatCount, 0},
+	{"RESP", "Avg response last 60 sec", func(s *dataCommon.DisplayAppStats) float64 {
+		return positive(s.TotalTraffic.AvgResponseL60Time) / 1000000
+	}, formatMilliseconds, worseHigher},
+	{"TOT_REQ", "Total requests", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.HttpAllCount) }, formatCount, 0},
+	{"5XX", "5xx responses", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.Http5xxCount) }, formatCount, worseHigher},
+	{"5XX_PERCENT", "5xx% of responses", http5xxPercent, formatPercent, worseHigher},
+	{"LOG_ERR", "Stderr log events", func(s *dataCommon.DisplayAppStats) float64 { return float64(s.TotalLogStderr) }, formatCount, worseHigher},
+}
+
+// CPU and response time use a negative value for "no data"
+func positive(value float64) float64 {
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+func http5xxPercent(s *dataCommon.DisplayAppStats) float64 {
+	if s.HttpAllCount == 0 {
+		return 0
+	}
+	return float64(s.Http5xxCount) / float64(s.HttpAllCount) * 100
+}
+
+// One compared stat.  Values are not known (ok false) if the app has
+// no display stats, e.g., it has not sent any events yet.
+type DisplayCompareRow struct {
+	order  int
+	metric *compareMetric
+	valueA float64
+	okA    bool
+	valueB float64
+	okB    bool
+}
+
+func NewDisplayCompareRow(order int, metric *compareMetric, appStatsA, appStatsB *dataCommon.DisplayAppStats) *DisplayCompareRow {
+	row := &DisplayCompareRow{order: order, metric: metric}
+	if appStatsA != nil {
+		row.valueA = metric.value(appStatsA)
+		row.okA = true
+	}
+	if appStatsB != nil {
+		row.valueB = metric.value(appStatsB)
+		row.okB = true
+	}
+	return row
+}
+
+func (row *DisplayCompareRow) Id() string {
+	return row.metric.id
+}
+
+func (row *DisplayCompareRow) hasDelta() bool {
+	return row.okA && row.okB
+}
+
+// Change from app A to app B
+func (row *DisplayCompareRow) delta() float64 {
+	return row.valueB - row.valueA
+}
+
+// Change from app A to app B as a percent of app A, ok is false when app A
+// is zero
+func (row *DisplayCompareRow) deltaPercent() (float64, bool) {
+	if row.valueA == 0 {
+		return 0, false
+	}
+	return row.delta() / math.Abs(row.valueA) * 100, true
+}
+
+// App B is worse than app A for this stat
+func (row *DisplayCompareRow) isWorse() bool {
+	if !row.hasDelta() {
+		return false
+	}
+	switch row.metric.worse {
+	case worseHigher:
+		return row.delta() > 0
+	case worseLower:
+		return row.delta() < 0
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appCompareView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**App Compare**
+
+App compare view shows the stats of two apps side by side, e.g., the
+blue and green or the canary and stable versions of an app.  In the
+app list press 'm' on the first app (A) and then 'm' on the second
+app (B) to open this view.
+`
+
+const HelpColumnsText = `
+**Compare Columns:**
+
+  STAT - Name of the stat (same values as the app list columns)
+  A:name - Value of app A
+  B:name - Value of app B
+  DELTA (B-A) - Change from app A to app B with an up or down arrow
+                and the percent change from app A.  Yellow when app B
+                is worse (e.g., lower health score or more CPU,
+                memory, crashes or 5xx)
+`
@@ -21,6 +21,7 @@ import (
 	"log"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/jroimartin/gocui"
 )
@@ -72,10 +73,10 @@ func (w *AppCrashItemWidget) Layout(g *gocui.Gui) error {
 		v.Title = "App CRASH Item Detail"
 		v.Frame = true
 		v.Wrap = true
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeAppCrashItemWidget); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeAppCrashItemWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeAppCrashItemWidget); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeAppCrashItemWidget, "close"); err != nil {
 			return err
 		}
 		if err := w.masterUI.SetCurrentViewOnTop(g); err != nil {
@@ -121,8 +122,15 @@ func (w *AppCrashItemWidget) RefreshDisplay(g *gocui.Gui) error {
 	maxX, _ := v.Size()
 
 	fmt.Fprintf(v, " \n")
-	fmt.Fprintf(v, "   App Crash Time: %v\n", w.crashInfo.CrashTimeFormatted)
+	fmt.Fprintf(v, "   App Crash Time: %v\n", w.crashInfo.CrashTime.Local().Format("01-02-2006 15:04:05.000 MST"))
 	fmt.Fprintf(v, "  Container Index: %v\n", w.crashInfo.ContainerIndex)
+	fmt.Fprintf(v, "    Instance GUID: %v\n", valueOrUnknown(w.crashInfo.InstanceGuid))
+	fmt.Fprintf(v, "          Cell ID: %v\n", valueOrUnknown(w.crashInfo.CellId))
+	fmt.Fprintf(v, "      Exit Status: %v\n", valueOrUnknown(w.crashInfo.ExitStatus))
+	fmt.Fprintf(v, "      Exit Reason: %v\n", valueOrUnknown(w.crashInfo.ExitReason))
+	if w.crashInfo.CrashCount > 0 {
+		fmt.Fprintf(v, "      Crash Count: %v\n", w.crashInfo.CrashCount)
+	}
 	exitDescLabel := " Exit Description: "
 	lineBreakIfNeeded := ""
 	if len(w.crashInfo.ExitDescription) > (maxX - len(exitDescLabel) - 1) {
@@ -136,3 +144,10 @@ func (w *AppCrashItemWidget) RefreshDisplay(g *gocui.Gui) error {
 
 	return nil
 }
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "--"
+	}
+	return value
+}
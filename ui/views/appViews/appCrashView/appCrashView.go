@@ -20,10 +20,12 @@ import (
 	"log"
 	"time"
 
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -56,8 +58,12 @@ func NewAppCrashView(masterUI masterUIInterface.MasterUIInterface,
 		eventProcessor, asUI, asUI.columnDefinitions(),
 		defaultSortColumns)
 
+	// Keep crash time and container index in view when scrolling
+	dataListView.GetListWidget().SetLockColumns(2)
+
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_LogMessage}
 
 	dataListView.SetTitle(fmt.Sprintf("App: %v - Container CRASH List (last 24 hours)", asUI.getAppName()))
 
@@ -70,13 +76,16 @@ func NewAppCrashView(masterUI masterUIInterface.MasterUIInterface,
 }
 
 func (asUI *AppCrashView) initializeCallback(g *gocui.Gui, viewName string) error {
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppCrashView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppCrashView, "close view"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppCrashView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppCrashView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show crash detail"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'e', gocui.ModNone, asUI.exportAction, "export crash history"); err != nil {
 		log.Panicln(err)
 	}
 	return nil
@@ -122,10 +131,9 @@ func (asUI *AppCrashView) postProcessData() []*DisplayContainerCrashInfo {
 	}
 
 	crashInfoListFromMetadata := crashData.FindSinceByApp(appStats.AppId, -24*time.Hour)
-	displayCrashInfoList = append(displayCrashInfoList, asUI.createDisplayContainerCrashInfo(crashInfoListFromMetadata)...)
-
 	crashInfoListFromLiveCapture := appStats.CrashSince(-24 * time.Hour)
-	displayCrashInfoList = append(displayCrashInfoList, asUI.createDisplayContainerCrashInfo(crashInfoListFromLiveCapture)...)
+	crashInfoList := crashData.MergeCrashInfo(crashInfoListFromLiveCapture, crashInfoListFromMetadata)
+	displayCrashInfoList = append(displayCrashInfoList, asUI.createDisplayContainerCrashInfo(crashInfoList)...)
 
 	return displayCrashInfoList
 }
@@ -166,6 +174,6 @@ func (asUI *AppCrashView) closeAppCrashView(g *gocui.Gui, v *gocui.View) error {
 
 func (asUI *AppCrashView) getAppName() string {
 	appMetadata := asUI.appMdMgr.FindAppMetadata(asUI.appId)
-	appName := appMetadata.Name
+	appName := asUI.appMdMgr.DisplayName(appMetadata)
 	return appName
 }
@@ -0,0 +1,125 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appCrashView
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/jroimartin/gocui"
+)
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// Exported form of a crash record
+type crashRecord struct {
+	CrashTime       time.Time `json:"crashTime"`
+	ContainerIndex  int       `json:"index"`
+	ExitStatus      string    `json:"exitStatus,omitempty"`
+	ExitReason      string    `json:"reason,omitempty"`
+	ExitDescription string    `json:"exitDescription,omitempty"`
+	CellId          string    `json:"cellId,omitempty"`
+	InstanceGuid    string    `json:"instanceGuid,omitempty"`
+}
+
+// JSON export keeps the full crash time precision and omits empty fields
+func (r *crashRecord) MarshalJSON() ([]byte, error) {
+	type jsonCrashRecord crashRecord
+	return json.Marshal((*jsonCrashRecord)(r))
+}
+
+func (r *crashRecord) Id() string {
+	return r.CrashTime.Format(time.RFC3339Nano) + "/" + strconv.Itoa(r.ContainerIndex)
+}
+
+// Columns of the CSV and text exports, the label is the CSV header.  The
+// JSON export is written from the json tags of crashRecord.
+func crashExportColumns() []*uiCommon.ListColumn {
+	field := func(id, label string, value func(r *crashRecord) string) *uiCommon.ListColumn {
+		return uiCommon.NewExportColumn(id, label, uiCommon.ALPHANUMERIC, func(data uiCommon.IData) string {
+			return value(data.(*crashRecord))
+		})
+	}
+	return []*uiCommon.ListColumn{
+		field("crashTime", "CRASH_TIME", func(r *crashRecord) string { return r.CrashTime.Format(time.RFC3339) }),
+		uiCommon.NewExportColumn("index", "IDX", uiCommon.NUMERIC, func(data uiCommon.IData) string {
+			return strconv.Itoa(data.(*crashRecord).ContainerIndex)
+		}),
+		field("exitStatus", "EXIT_STATUS", func(r *crashRecord) string { return r.ExitStatus }),
+		field("reason", "REASON", func(r *crashRecord) string { return r.ExitReason }),
+		field("exitDescription", "EXIT_DESCRIPTION", func(r *crashRecord) string { return r.ExitDescription }),
+		field("cellId", "CELL_ID", func(r *crashRecord) string { return r.CellId }),
+		field("instanceGuid", "INSTANCE_GUID", func(r *crashRecord) string { return r.InstanceGuid }),
+	}
+}
+
+func (asUI *AppCrashView) exportAction(g *gocui.Gui, v *gocui.View) error {
+	menuItems := uiCommon.FormatterMenuItems()
+
+	exportView := uiCommon.NewSelectMenuWidget(asUI.GetMasterUI(), "exportCrashView", "Export Crash History", menuItems, asUI.exportCallback)
+
+	asUI.GetMasterUI().LayoutManager().Add(exportView)
+	asUI.GetMasterUI().SetCurrentViewOnTop(g)
+	return nil
+}
+
+func (asUI *AppCrashView) exportCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	records := asUI.crashRecords()
+	now := time.Now()
+	appName := unsafeFileNameChars.ReplaceAllString(asUI.getAppName(), "_")
+	fileName := fmt.Sprintf("top-crashes-%v-%v.%v", appName, now.Format("20060102-150405"), menuId)
+
+	rows := make([]uiCommon.IData, 0, len(records))
+	for _, record := range records {
+		rows = append(rows, record)
+	}
+	if err := uiCommon.WriteFormattedFile(fileName, menuId, rows, crashExportColumns()); err != nil {
+		toplog.Error("Crash history export error: " + err.Error())
+		return nil
+	}
+	toplog.Info("%v crash record(s) written to %v", len(records), fileName)
+	return nil
+}
+
+// All known crashes for the app, captured live and from CC event history
+func (asUI *AppCrashView) crashRecords() []*crashRecord {
+	var liveCrashInfo []*crashData.ContainerCrashInfo
+	appStats := asUI.GetDisplayedEventData().AppMap[asUI.appId]
+	if appStats != nil {
+		liveCrashInfo = appStats.ContainerCrashInfo
+	}
+	crashInfoList := crashData.MergeCrashInfo(liveCrashInfo, crashData.FindByApp(asUI.appId))
+
+	records := make([]*crashRecord, 0, len(crashInfoList))
+	for _, crashInfo := range crashInfoList {
+		records = append(records, &crashRecord{
+			CrashTime:       crashInfo.CrashTime.Local(),
+			ContainerIndex:  crashInfo.ContainerIndex,
+			ExitStatus:      crashInfo.ExitStatus,
+			ExitReason:      crashInfo.ExitReason,
+			ExitDescription: crashInfo.ExitDescription,
+			CellId:          crashInfo.CellId,
+			InstanceGuid:    crashInfo.InstanceGuid,
+		})
+	}
+	return records
+}
@@ -48,4 +48,14 @@ const HelpColumnsText = `
 `
 
 const HelpLocalViewKeybindings = `
+**Crash detail: **
+Press ENTER to show the full detail of the highlighted crash: exact
+crash time, container index, instance GUID, cell ID, exit status,
+exit reason and the complete exit description.  Some detail is only
+available for crashes seen live while top is running.
+
+**Export crash history: **
+Press 'e' to export all known crashes of this app (both seen live
+and from the foundation's event history, duplicates removed) to a CSV,
+JSON or aligned text file in the current directory.
 `
@@ -15,5 +15,5 @@
 
 package appCrashView
 
-const HelpTextTips = `**x**:exit view  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
+const HelpTextTips = `**x**:exit view  **ENTER**:crash detail  **e**:export  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
 **LEFT**/**RIGHT** arrow to scroll columns`
@@ -19,17 +19,27 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/atotto/clipboard"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/common"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appCrashView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appEventsView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appHttpView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
@@ -41,11 +51,24 @@ type AppDetailView struct {
 	requestsInfoWidget *RequestsInfoWidget
 	crashInfoWidget    *CrashInfoWidget
 	displayMenuId      string
+	// If non-empty only containers whose cell IP contains this are listed
+	cellIpFilter string
 
 	Crash10mCount int
 	Crash1hCount  int
 	Crash24hCount int
 	LastCrashInfo *crashData.ContainerCrashInfo
+	CrashLooping  bool
+
+	// Aggregated across all reporting containers
+	TotalUsedMemory     uint64
+	TotalReservedMemory uint64
+	TotalUsedDisk       uint64
+	TotalReservedDisk   uint64
+	TotalLogStdout      int64
+	TotalLogStderr      int64
+
+	rateTracker *ContainerRateTracker
 }
 
 func NewAppDetailView(masterUI masterUIInterface.MasterUIInterface,
@@ -54,7 +77,7 @@ func NewAppDetailView(masterUI masterUIInterface.MasterUIInterface,
 	eventProcessor *eventdata.EventProcessor,
 	appId string) *AppDetailView {
 
-	asUI := &AppDetailView{appId: appId}
+	asUI := &AppDetailView{appId: appId, rateTracker: NewContainerRateTracker()}
 	requestViewHeight := 5
 	defaultSortColumns := []*uiCommon.SortColumn{
 		uiCommon.NewSortColumn("CPU_PERCENT", true),
@@ -68,6 +91,7 @@ func NewAppDetailView(masterUI masterUIInterface.MasterUIInterface,
 
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_HttpStartStop, events.Envelope_LogMessage}
 	dataListView.RefreshDisplayCallback = asUI.refreshDisplay
 
 	dataListView.SetTitle("Container List")
@@ -86,22 +110,36 @@ func NewAppDetailView(masterUI masterUIInterface.MasterUIInterface,
 }
 
 func (asUI *AppDetailView) initializeCallback(g *gocui.Gui, viewName string) error {
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
 	/*
-		if err := g.SetKeybinding(viewName, 'i', gocui.ModNone, asUI.openInfoAction); err != nil {
+		if err := keybinding.Set(g, viewName, 'i', gocui.ModNone, asUI.openInfoAction, "app info"); err != nil {
 			log.Panicln(err)
 		}
 	*/
-	if err := g.SetKeybinding(viewName, 'd', gocui.ModNone, asUI.selectDisplayAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'd', gocui.ModNone, asUI.selectDisplayAction, "app detail display menu"); err != nil {
 		log.Panicln(err)
 	}
+	if err := keybinding.Set(g, viewName, 'j', gocui.ModNone, asUI.jumpToIndexAction, "jump to container index"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'l', gocui.ModNone, asUI.cellIpFilterAction, "filter containers by cell IP"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'b', gocui.ModNone, asUI.openAppsManagerAction, "open app in Apps Manager (browser)"); err != nil {
+		log.Panicln(err)
+	}
+	if !config.IsKioskMode() {
+		if err := keybinding.Set(g, viewName, 'R', gocui.ModNone, asUI.restartInstanceAction, "restart highlighted instance"); err != nil {
+			log.Panicln(err)
+		}
+	}
 	/*
-		if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+		if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "container detail menu"); err != nil {
 			log.Panicln(err)
 		}
 	*/
@@ -113,7 +151,9 @@ func (asUI *AppDetailView) selectDisplayAction(g *gocui.Gui, v *gocui.View) erro
 	menuItems := make([]*uiCommon.MenuItem, 0, 5)
 	menuItems = append(menuItems, uiCommon.NewMenuItem("infoView", "App Info"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("crashInfoView", "View CRASH List"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("appEventsView", "App Events"))
 	menuItems = append(menuItems, uiCommon.NewMenuItem("appHttpView", "HTTP Response Info"))
+	menuItems = append(menuItems, uiCommon.NewMenuItem("servicesView", "Services"))
 	//menuItems = append(menuItems, uiCommon.NewMenuItem("infoView", "View App Logs"))
 	//menuItems = append(menuItems, uiCommon.NewMenuItem("infoView", "Todo"))
 
@@ -126,6 +166,160 @@ func (asUI *AppDetailView) selectDisplayAction(g *gocui.Gui, v *gocui.View) erro
 	return nil
 }
 
+// Prompt for a container index and highlight that container's row
+func (asUI *AppDetailView) jumpToIndexAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Index:"
+	maxLength := 5
+	titleText := "Jump to container index"
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		index, err := strconv.Atoi(strings.TrimSpace(inputValue))
+		if err != nil {
+			return err
+		}
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		// Container row key format is from DisplayContainerStats.Id()
+		key := fmt.Sprintf("%v-%v", asUI.appId, index)
+		if !asUI.GetListWidget().SetHighlightKey(g, key) {
+			toplog.Warn("Container index %v not found (it may not be reporting or may be filtered)", index)
+		}
+		return nil
+	}
+
+	dialogWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"jumpToIndexWidget", 30, 6, labelText, maxLength, titleText, helpText,
+		"", applyCallbackFunc)
+
+	return dialogWidget.Init(g)
+}
+
+// Prompt for a cell IP (or part of one, e.g., "10.0.16.") and only list
+// the containers running on matching cells.  An empty value clears the filter.
+func (asUI *AppDetailView) cellIpFilterAction(g *gocui.Gui, v *gocui.View) error {
+
+	labelText := "Cell IP:"
+	maxLength := 15
+	titleText := "Filter containers by cell IP"
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		asUI.cellIpFilter = strings.TrimSpace(inputValue)
+		asUI.updateTitle()
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		return asUI.UpdateDisplay(g)
+	}
+
+	dialogWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"cellIpFilterWidget", 32, 6, labelText, maxLength, titleText, helpText,
+		asUI.cellIpFilter, applyCallbackFunc)
+
+	return dialogWidget.Init(g)
+}
+
+// Cell IP filter saved with presets
+func (asUI *AppDetailView) PresetState() map[string]string {
+	return map[string]string{"cell_ip": asUI.cellIpFilter}
+}
+
+func (asUI *AppDetailView) ApplyPresetState(state map[string]string) {
+	asUI.cellIpFilter = state["cell_ip"]
+	asUI.updateTitle()
+}
+
+// Title with the active cell IP filter
+func (asUI *AppDetailView) updateTitle() {
+	title := "Container List"
+	if asUI.cellIpFilter != "" {
+		title = fmt.Sprintf("%v (cell IP: %v)", title, asUI.cellIpFilter)
+	}
+	asUI.SetTitle(title)
+}
+
+// Open the app in Apps Manager using the OS default browser.  The Apps
+// Manager host is assumed to be apps.<system domain>.  If the url can not be
+// built or the browser can not be started the app GUID is copied to the
+// clipboard instead.
+func (asUI *AppDetailView) openAppsManagerAction(g *gocui.Gui, v *gocui.View) error {
+	appsManagerUrl := asUI.appsManagerUrl()
+	if appsManagerUrl != "" {
+		toplog.Info("Opening Apps Manager: %v", appsManagerUrl)
+		err := util.OpenBrowser(appsManagerUrl)
+		if err == nil {
+			return nil
+		}
+		toplog.Warn("Unable to open browser: %v", err)
+	} else {
+		toplog.Warn("Unable to determine Apps Manager url (system domain or app org/space unknown)")
+	}
+	if err := clipboard.WriteAll(asUI.appId); err != nil {
+		toplog.Error("Copy into Clipboard error: " + err.Error())
+		return nil
+	}
+	toplog.Info("App GUID %v copied to clipboard", asUI.appId)
+	return nil
+}
+
+func (asUI *AppDetailView) appsManagerUrl() string {
+	systemDomain := util.GetSystemDomain(asUI.GetEventProcessor().GetCliConnection())
+	spaceGuid := asUI.GetAppMdMgr().FindAppMetadata(asUI.appId).SpaceGuid
+	orgGuid := space.FindSpaceMetadata(spaceGuid).OrgGuid
+	if systemDomain == "" || spaceGuid == "" || orgGuid == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://apps.%v/organizations/%v/spaces/%v/applications/%v",
+		systemDomain, orgGuid, spaceGuid, asUI.appId)
+}
+
+// Restart the highlighted container by deleting its instance index.  CC will
+// start a replacement instance at the same index.
+func (asUI *AppDetailView) restartInstanceAction(g *gocui.Gui, v *gocui.View) error {
+
+	if !asUI.GetMasterUI().IsPrivileged() {
+		toplog.Warn("Restart of app instance requires privileged mode")
+		return nil
+	}
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	// Container row key format is from DisplayContainerStats.Id()
+	index, err := strconv.Atoi(strings.TrimPrefix(highlightKey, asUI.appId+"-"))
+	if highlightKey == "" || err != nil {
+		toplog.Warn("Highlight a container row to restart its instance")
+		return nil
+	}
+
+	appName := asUI.GetAppMdMgr().FindAppMetadata(asUI.appId).Name
+	windowTitle := fmt.Sprintf("Restart app %v instance %v?", appName, index)
+	confirmLabel := fmt.Sprintf("Yes - restart instance %v", index)
+	description := fmt.Sprintf("restart app %v (%v) instance %v", appName, asUI.appId, index)
+	return uiCommon.OpenConfirmDialog(asUI.GetMasterUI(), g, "restartInstanceView",
+		windowTitle, confirmLabel, description, func(g *gocui.Gui) {
+			go asUI.restartInstance(g, appName, index)
+		})
+}
+
+func (asUI *AppDetailView) restartInstance(g *gocui.Gui, appName string, index int) {
+	url := fmt.Sprintf("/v2/apps/%v/instances/%v", asUI.appId, index)
+	toplog.Info("Restarting app %v instance %v", appName, index)
+	output, err := common.CallDeleteAPI(asUI.GetEventProcessor().GetCliConnection(), url)
+	if err != nil {
+		toplog.Error("Restart of app %v instance %v failed: %v", appName, index, err)
+		return
+	}
+	if strings.Contains(output, "error_code") {
+		toplog.Error("Restart of app %v instance %v failed: %v", appName, index, output)
+		return
+	}
+	toplog.Info("Restart of app %v instance %v requested", appName, index)
+	g.Execute(func(g *gocui.Gui) error {
+		return asUI.UpdateDisplay(g)
+	})
+}
+
 func (asUI *AppDetailView) enterAction(g *gocui.Gui, v *gocui.View) error {
 
 	highlightKey := asUI.GetListWidget().HighlightKey()
@@ -158,17 +352,24 @@ func (asUI *AppDetailView) createAndOpenView(g *gocui.Gui, viewName string) erro
 	switch viewName {
 	case "infoView":
 		infoWidgetName := "appInfoWidget"
-		view = NewAppInfoWidget(asUI.GetMasterUI(), infoWidgetName, 70, 20, asUI)
+		view = NewAppInfoWidget(asUI.GetMasterUI(), infoWidgetName, 70, 28, asUI)
 	case "crashInfoView":
 		_, bottomMargin := asUI.GetMargins()
 		view = appCrashView.NewAppCrashView(asUI.GetMasterUI(), asUI, "crashInfoView", bottomMargin,
 			asUI.GetEventProcessor(),
 			asUI.appId)
+	case "appEventsView":
+		_, bottomMargin := asUI.GetMargins()
+		view = appEventsView.NewAppEventsView(asUI.GetMasterUI(), asUI, "appEventsView", bottomMargin,
+			asUI.GetEventProcessor(),
+			asUI.appId)
 	case "appHttpView":
 		_, bottomMargin := asUI.GetMargins()
 		view = appHttpView.NewAppHttpView(asUI.GetMasterUI(), asUI, "appHttpView", bottomMargin,
 			asUI.GetEventProcessor(),
 			asUI.appId)
+	case "servicesView":
+		view = NewServiceInfoWidget(asUI.GetMasterUI(), "appServiceInfoWidget", 90, 16, asUI)
 	default:
 		return errors.New("Unable to find view " + viewName)
 	}
@@ -177,7 +378,7 @@ func (asUI *AppDetailView) createAndOpenView(g *gocui.Gui, viewName string) erro
 
 func (asUI *AppDetailView) openInfoAction(g *gocui.Gui, v *gocui.View) error {
 	infoWidgetName := "appInfoWidget"
-	appInfoWidget := NewAppInfoWidget(asUI.GetMasterUI(), infoWidgetName, 70, 18, asUI)
+	appInfoWidget := NewAppInfoWidget(asUI.GetMasterUI(), infoWidgetName, 70, 26, asUI)
 	asUI.GetMasterUI().LayoutManager().Add(appInfoWidget)
 	asUI.GetMasterUI().SetCurrentViewOnTop(g)
 	asUI.GetMasterUI().AddCommonDataViewKeybindings(g, infoWidgetName)
@@ -191,16 +392,16 @@ func (asUI *AppDetailView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, ColumnMemoryUsed())
 	columns = append(columns, ColumnMemoryFree())
 	columns = append(columns, ColumnDiskUsed())
-	columns = append(columns, ColumnDiskFree())
+	columns = append(columns, ColumnDiskFree().SetPriority(1))
 	columns = append(columns, ColumnLogStdout())
 	columns = append(columns, ColumnLogStderr())
 
-	columns = append(columns, ColumnCellIp())
+	columns = append(columns, ColumnCellIp().SetPriority(2))
 	return columns
 }
 
 func (asUI *AppDetailView) GetListData() []uiCommon.IData {
-	displayDataList := asUI.postProcessData()
+	displayDataList := asUI.filterByCellIp(asUI.postProcessData())
 	listData := asUI.convertToListData(displayDataList)
 	return listData
 }
@@ -216,13 +417,27 @@ func (asUI *AppDetailView) postProcessData() []*DisplayContainerStats {
 	}
 
 	appMetadata := asUI.GetAppMdMgr().FindAppMetadata(appStats.AppId)
+	cellMap := asUI.GetDisplayedEventData().CellMap
+
+	totalUsedMemory := uint64(0)
+	totalReservedMemory := uint64(0)
+	totalUsedDisk := uint64(0)
+	totalReservedDisk := uint64(0)
+	totalLogStdout := int64(0)
+	totalLogStderr := int64(0)
 
 	for _, containerStats := range appStats.ContainerArray {
 		if containerStats != nil {
+			totalLogStdout = totalLogStdout + containerStats.OutCount
+			totalLogStderr = totalLogStderr + containerStats.ErrCount
+
 			displayContainerStats := NewDisplayContainerStats(containerStats, appStats)
 			displayContainerStats.AppName = appMetadata.Name
 			displayContainerStats.SpaceName = space.FindSpaceName(appMetadata.SpaceGuid)
 			displayContainerStats.OrgName = org.FindOrgNameBySpaceGuid(appMetadata.SpaceGuid)
+			if cellStats := cellMap[containerStats.Ip]; cellStats != nil {
+				displayContainerStats.CellNumOfCpus = cellStats.NumOfCpus
+			}
 
 			usedMemory := containerStats.ContainerMetric.GetMemoryBytes()
 			reservedMemory := uint64(appMetadata.MemoryMB) * util.MEGABYTE
@@ -236,14 +451,26 @@ func (asUI *AppDetailView) postProcessData() []*DisplayContainerStats {
 			displayContainerStats.ReservedDisk = reservedDisk
 			displayStatsArray = append(displayStatsArray, displayContainerStats)
 
+			totalUsedMemory = totalUsedMemory + usedMemory
+			totalReservedMemory = totalReservedMemory + reservedMemory
+			totalUsedDisk = totalUsedDisk + usedDisk
+			totalReservedDisk = totalReservedDisk + reservedDisk
 		}
 	}
+	asUI.rateTracker.Update(asUI.GetDisplayedEventData().StatsTime, displayStatsArray)
+	asUI.TotalUsedMemory = totalUsedMemory
+	asUI.TotalReservedMemory = totalReservedMemory
+	asUI.TotalUsedDisk = totalUsedDisk
+	asUI.TotalReservedDisk = totalReservedDisk
+	asUI.TotalLogStdout = totalLogStdout
+	asUI.TotalLogStderr = totalLogStderr
 
 	displayStatsMap := asUI.GetMasterUI().GetCommonData().GetDisplayAppStatsMap()
 	displayAppStats := displayStatsMap[asUI.appId]
 
 	asUI.Crash1hCount = displayAppStats.Crash1hCount
 	asUI.Crash24hCount = displayAppStats.Crash24hCount
+	asUI.CrashLooping = displayAppStats.CrashLooping
 
 	crash10mCount := crashData.FindCountSinceByApp(appStats.AppId, -10*time.Minute)
 	crash10mCount = crash10mCount + appStats.CrashCountSince(-10*time.Minute)
@@ -261,6 +488,21 @@ func (asUI *AppDetailView) postProcessData() []*DisplayContainerStats {
 	return displayStatsArray
 }
 
+// Totals (header and info widgets) are computed before this filter so they
+// still cover all containers of the app
+func (asUI *AppDetailView) filterByCellIp(containerStatsArray []*DisplayContainerStats) []*DisplayContainerStats {
+	if asUI.cellIpFilter == "" {
+		return containerStatsArray
+	}
+	filteredArray := make([]*DisplayContainerStats, 0, len(containerStatsArray))
+	for _, containerStats := range containerStatsArray {
+		if strings.Contains(containerStats.Ip, asUI.cellIpFilter) {
+			filteredArray = append(filteredArray, containerStats)
+		}
+	}
+	return filteredArray
+}
+
 func (asUI *AppDetailView) FindLastCrash(appStats *eventApp.AppStats) *crashData.ContainerCrashInfo {
 	if appStats.ContainerCrashInfo != nil && len(appStats.ContainerCrashInfo) > 0 {
 		last := len(appStats.ContainerCrashInfo) - 1
@@ -277,6 +519,15 @@ func (asUI *AppDetailView) convertToListData(containerStatsArray []*DisplayConta
 	return listData
 }
 
+func (asUI *AppDetailView) GetAppId() string {
+	return asUI.appId
+}
+
+// Close the detail view and its info widgets
+func (asUI *AppDetailView) Close(g *gocui.Gui) error {
+	return asUI.closeAppDetailView(g, nil)
+}
+
 func (asUI *AppDetailView) closeAppDetailView(g *gocui.Gui, v *gocui.View) error {
 	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
 		return err
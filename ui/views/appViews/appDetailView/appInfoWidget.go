@@ -19,17 +19,24 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
+const (
+	barFilled = string('\U00002588')
+	barEmpty  = string('\U00002591')
+)
+
 type AppInfoWidget struct {
 	masterUI   masterUIInterface.MasterUIInterface
 	name       string
@@ -57,10 +64,10 @@ func (w *AppInfoWidget) Layout(g *gocui.Gui) error {
 		}
 		v.Title = "App Information"
 		v.Frame = true
-		if err := g.SetKeybinding(w.name, 'x', gocui.ModNone, w.closeAppInfoWidget); err != nil {
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeAppInfoWidget, "close"); err != nil {
 			return err
 		}
-		if err := g.SetKeybinding(w.name, gocui.KeyEsc, gocui.ModNone, w.closeAppInfoWidget); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeAppInfoWidget, "close"); err != nil {
 			return err
 		}
 		if err := w.masterUI.SetCurrentViewOnTop(g); err != nil {
@@ -79,6 +86,49 @@ func (w *AppInfoWidget) closeAppInfoWidget(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// Horizontal bar of used vs reserved colored by utilization band, e.g.,
+//
+//	[██████░░░░░░░░░░]  38% 780MiB of 2.0GiB
+func utilizationBar(used, reserved uint64, barWidth int) string {
+	if reserved == 0 {
+		return "--"
+	}
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	percent := float64(used) * 100 / float64(reserved)
+	filled := int(percent*float64(barWidth)/100 + 0.5)
+	if filled > barWidth {
+		filled = barWidth
+	}
+
+	colorString := util.GREEN + util.BRIGHT
+	switch {
+	case percent >= 90:
+		colorString = util.BRIGHT_RED
+	case percent >= 75:
+		colorString = util.BRIGHT_YELLOW
+	}
+
+	bar := strings.Repeat(barFilled, filled) + strings.Repeat(barEmpty, barWidth-filled)
+	return fmt.Sprintf("[%v%v%v] %3.0f%% %v of %v", colorString, bar, util.CLEAR,
+		percent, util.FormatBytes(used), util.FormatBytes(reserved))
+}
+
+// Split text into lines of at most width characters so long values
+// (e.g., start command) can be shown in full
+func wrapText(text string, width int) []string {
+	if width < 10 {
+		width = 10
+	}
+	lines := make([]string, 0)
+	for len(text) > width {
+		lines = append(lines, text[:width])
+		text = text[width:]
+	}
+	return append(lines, text)
+}
+
 func (w *AppInfoWidget) UpdateDisplay(g *gocui.Gui) error {
 	return w.RefreshDisplay(g)
 }
@@ -113,6 +163,16 @@ func (w *AppInfoWidget) RefreshDisplay(g *gocui.Gui) error {
 		packageUpdated := appMetadata.PackageUpdatedAt
 		dockerImage := appMetadata.DockerImage
 
+		healthCheckType := appMetadata.HealthcheckType
+		if healthCheckType == "" {
+			// CC treats an unset health check type as port
+			healthCheckType = "port"
+		}
+		healthCheckDisplay := healthCheckType
+		if appMetadata.HealthcheckTimeout > 0 {
+			healthCheckDisplay = fmt.Sprintf("%v (timeout: %vs)", healthCheckType, appMetadata.HealthcheckTimeout)
+		}
+
 		appName := appMetadata.Name
 		orgName := org.FindOrgNameBySpaceGuid(appMetadata.SpaceGuid)
 
@@ -141,11 +201,34 @@ func (w *AppInfoWidget) RefreshDisplay(g *gocui.Gui) error {
 			fmt.Fprintf(v, " Buildpack:       %v\n", buildpack)
 		}
 		fmt.Fprintf(v, " Package Updated: %v\n", packageUpdated)
+		fmt.Fprintf(v, " Health Check:    %v\n", healthCheckDisplay)
+		if healthCheckType == "http" && appMetadata.HealthcheckHttpEndpoint != "" {
+			fmt.Fprintf(v, " HC Endpoint:     %v\n", appMetadata.HealthcheckHttpEndpoint)
+		}
+		startCmdLines := []string{"--"}
+		if appMetadata.DetectedStartCmd != "" {
+			viewX, _ := v.Size()
+			startCmdLines = wrapText(appMetadata.DetectedStartCmd, viewX-19)
+		}
+		for i, line := range startCmdLines {
+			if i == 0 {
+				fmt.Fprintf(v, " Start Command:   %v\n", line)
+			} else {
+				fmt.Fprintf(v, "                  %v\n", line)
+			}
+		}
 		fmt.Fprintf(v, "\n Reserved:\n")
 
 		fmt.Fprintf(v, "   Mem per (total):  %8v (%8v)\n", memoryDisplay, totalMemoryDisplay)
 		fmt.Fprintf(v, "   Disk per (total): %8v (%8v)\n", diskQuotaDisplay, totalDiskDisplay)
 
+		// Room left on the line after the label and the percent / values text
+		viewX, _ := v.Size()
+		barWidth := viewX - 40
+		fmt.Fprintf(v, "\n Used vs Reserved (reporting containers):\n")
+		fmt.Fprintf(v, "   Mem:  %v\n", utilizationBar(w.detailView.TotalUsedMemory, w.detailView.TotalReservedMemory, barWidth))
+		fmt.Fprintf(v, "   Disk: %v\n", utilizationBar(w.detailView.TotalUsedDisk, w.detailView.TotalReservedDisk, barWidth))
+
 	} else {
 		fmt.Fprintf(v, " \n Metadata not loaded yet...\n")
 	}
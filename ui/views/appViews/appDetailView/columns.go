@@ -18,6 +18,7 @@ package appDetailView
 import (
 	"fmt"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
@@ -25,7 +26,7 @@ import (
 func ColumnTotalCpuPercentage() *uiCommon.ListColumn {
 	defaultColSize := 6
 	sortFunc := func(c1, c2 util.Sortable) bool {
-		return (c1.(*DisplayContainerStats).ContainerMetric.GetCpuPercentage() < c2.(*DisplayContainerStats).ContainerMetric.GetCpuPercentage())
+		return (c1.(*DisplayContainerStats).DisplayCpuPercentage() < c2.(*DisplayContainerStats).DisplayCpuPercentage())
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
@@ -35,21 +36,14 @@ func ColumnTotalCpuPercentage() *uiCommon.ListColumn {
 		if stats.ContainerMetric.GetMemoryBytes() == 0 {
 			totalCpuInfo = fmt.Sprintf("%6v", "--")
 		} else {
-			cpuPercentage := stats.ContainerMetric.GetCpuPercentage()
-			if cpuPercentage >= 100.0 {
-				totalCpuInfo = fmt.Sprintf("%6.0f", cpuPercentage)
-			} else if cpuPercentage >= 10.0 {
-				totalCpuInfo = fmt.Sprintf("%6.1f", cpuPercentage)
-			} else {
-				totalCpuInfo = fmt.Sprintf("%6.2f", cpuPercentage)
-			}
+			totalCpuInfo = util.FormatCpuPercent(stats.DisplayCpuPercentage(), 6)
 		}
 		return fmt.Sprintf("%6v", totalCpuInfo)
 
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		stats := data.(*DisplayContainerStats)
-		return fmt.Sprintf("%v", stats.ContainerMetric.GetCpuPercentage())
+		return fmt.Sprintf("%v", stats.DisplayCpuPercentage())
 	}
 	c := uiCommon.NewListColumn("CPU_PERCENT", "CPU%", defaultColSize,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
@@ -130,21 +124,35 @@ func ColumnOrgName() *uiCommon.ListColumn {
 	return c
 }
 
+// Shows memory free instead of used when config.GetUsedFreeDisplay is
+// UsedFreeFreeOnly (MEM_FREE column is then not shown)
 func ColumnMemoryUsed() *uiCommon.ListColumn {
+	value := func(stats *DisplayContainerStats) uint64 {
+		if config.GetUsedFreeDisplay() == config.UsedFreeFreeOnly {
+			return stats.FreeMemory
+		}
+		return stats.ContainerMetric.GetMemoryBytes()
+	}
 	sortFunc := func(c1, c2 util.Sortable) bool {
-		return c1.(*DisplayContainerStats).ContainerMetric.GetMemoryBytes() < c2.(*DisplayContainerStats).ContainerMetric.GetMemoryBytes()
+		return value(c1.(*DisplayContainerStats)) < value(c2.(*DisplayContainerStats))
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
-		memInfo := fmt.Sprintf("%9v", util.ByteSize(stats.ContainerMetric.GetMemoryBytes()).StringWithPrecision(1))
+		memInfo := fmt.Sprintf("%9v", util.FormatBytes(value(stats)))
 		return fmt.Sprintf("%9v", memInfo)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		appStats := data.(*DisplayContainerStats)
-		return fmt.Sprintf("%v", appStats.ContainerMetric.GetMemoryBytes())
+		return fmt.Sprintf("%v", value(appStats))
 	}
 	c := uiCommon.NewListColumn("MEM_USED", "MEM_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetLabelFunc(func() string {
+		if config.GetUsedFreeDisplay() == config.UsedFreeFreeOnly {
+			return "MEM_FREE"
+		}
+		return "MEM_USED"
+	})
 	return c
 }
 
@@ -154,7 +162,7 @@ func ColumnMemoryFree() *uiCommon.ListColumn {
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
-		memInfo := fmt.Sprintf("%9v", util.ByteSize(stats.FreeMemory).StringWithPrecision(1))
+		memInfo := fmt.Sprintf("%9v", util.FormatBytes(stats.FreeMemory))
 		return fmt.Sprintf("%9v", memInfo)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
@@ -163,6 +171,7 @@ func ColumnMemoryFree() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("MEM_FREE", "MEM_FREE", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetShownFunc(usedFreeBothShown)
 	return c
 }
 
@@ -172,7 +181,7 @@ func ColumnMemoryReserved() *uiCommon.ListColumn {
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
-		memInfo := fmt.Sprintf("%9v", util.ByteSize(stats.ReservedMemory).StringWithPrecision(1))
+		memInfo := fmt.Sprintf("%9v", util.FormatBytes(stats.ReservedMemory))
 		return fmt.Sprintf("%9v", memInfo)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
@@ -184,21 +193,35 @@ func ColumnMemoryReserved() *uiCommon.ListColumn {
 	return c
 }
 
+// Shows disk free instead of used when config.GetUsedFreeDisplay is
+// UsedFreeFreeOnly (DISK_FREE column is then not shown)
 func ColumnDiskUsed() *uiCommon.ListColumn {
+	value := func(stats *DisplayContainerStats) uint64 {
+		if config.GetUsedFreeDisplay() == config.UsedFreeFreeOnly {
+			return stats.FreeDisk
+		}
+		return stats.ContainerMetric.GetDiskBytes()
+	}
 	sortFunc := func(c1, c2 util.Sortable) bool {
-		return c1.(*DisplayContainerStats).ContainerMetric.GetDiskBytes() < c2.(*DisplayContainerStats).ContainerMetric.GetDiskBytes()
+		return value(c1.(*DisplayContainerStats)) < value(c2.(*DisplayContainerStats))
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		appStats := data.(*DisplayContainerStats)
-		diskUsed := fmt.Sprintf("%9v", util.ByteSize(appStats.ContainerMetric.GetDiskBytes()).StringWithPrecision(1))
+		diskUsed := fmt.Sprintf("%9v", util.FormatBytes(value(appStats)))
 		return diskUsed
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		appStats := data.(*DisplayContainerStats)
-		return fmt.Sprintf("%v", appStats.ContainerMetric.GetDiskBytes())
+		return fmt.Sprintf("%v", value(appStats))
 	}
 	c := uiCommon.NewListColumn("DISK_USED", "DISK_USED", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetLabelFunc(func() string {
+		if config.GetUsedFreeDisplay() == config.UsedFreeFreeOnly {
+			return "DISK_FREE"
+		}
+		return "DISK_USED"
+	})
 	return c
 }
 
@@ -208,7 +231,7 @@ func ColumnDiskFree() *uiCommon.ListColumn {
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
-		memInfo := fmt.Sprintf("%9v", util.ByteSize(stats.FreeDisk).StringWithPrecision(1))
+		memInfo := fmt.Sprintf("%9v", util.FormatBytes(stats.FreeDisk))
 		return fmt.Sprintf("%9v", memInfo)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
@@ -217,16 +240,22 @@ func ColumnDiskFree() *uiCommon.ListColumn {
 	}
 	c := uiCommon.NewListColumn("DISK_FREE", "DISK_FREE", 9,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	c.SetShownFunc(usedFreeBothShown)
 	return c
 }
 
+// Separate free columns are only shown when both used and free are displayed
+func usedFreeBothShown() bool {
+	return config.GetUsedFreeDisplay() == config.UsedFreeBoth
+}
+
 func ColumnDiskReserved() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*DisplayContainerStats).ReservedDisk < c2.(*DisplayContainerStats).ReservedDisk
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
-		memInfo := fmt.Sprintf("%9v", util.ByteSize(stats.ReservedDisk).StringWithPrecision(1))
+		memInfo := fmt.Sprintf("%9v", util.FormatBytes(stats.ReservedDisk))
 		return fmt.Sprintf("%9v", memInfo)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
@@ -238,16 +267,26 @@ func ColumnDiskReserved() *uiCommon.ListColumn {
 	return c
 }
 
+// Log counts are shown as per second rates when config.IsPerSecondDisplay
 func ColumnLogStdout() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
+		if config.IsPerSecondDisplay() {
+			return c1.(*DisplayContainerStats).OutRate < c2.(*DisplayContainerStats).OutRate
+		}
 		return c1.(*DisplayContainerStats).OutCount < c2.(*DisplayContainerStats).OutCount
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
+		if config.IsPerSecondDisplay() {
+			return fmt.Sprintf("%9.1f/s", stats.OutRate)
+		}
 		return fmt.Sprintf("%11v", util.Format(stats.OutCount))
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		appStats := data.(*DisplayContainerStats)
+		if config.IsPerSecondDisplay() {
+			return fmt.Sprintf("%.2f", appStats.OutRate)
+		}
 		return fmt.Sprintf("%v", appStats.OutCount)
 	}
 	c := uiCommon.NewListColumn("LOG_OUT", "LOG_OUT", 11,
@@ -257,14 +296,23 @@ func ColumnLogStdout() *uiCommon.ListColumn {
 
 func ColumnLogStderr() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
+		if config.IsPerSecondDisplay() {
+			return c1.(*DisplayContainerStats).ErrRate < c2.(*DisplayContainerStats).ErrRate
+		}
 		return c1.(*DisplayContainerStats).ErrCount < c2.(*DisplayContainerStats).ErrCount
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		stats := data.(*DisplayContainerStats)
+		if config.IsPerSecondDisplay() {
+			return fmt.Sprintf("%9.1f/s", stats.ErrRate)
+		}
 		return fmt.Sprintf("%11v", util.Format(stats.ErrCount))
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		appStats := data.(*DisplayContainerStats)
+		if config.IsPerSecondDisplay() {
+			return fmt.Sprintf("%.2f", appStats.ErrRate)
+		}
 		return fmt.Sprintf("%v", appStats.ErrCount)
 	}
 	c := uiCommon.NewListColumn("LOG_ERR", "LOG_ERR", 11,
@@ -0,0 +1,71 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appDetailView
+
+import (
+	"time"
+)
+
+// Log counts of a container at the last display refresh
+type containerCounts struct {
+	statsTime time.Time
+	outCount  int64
+	errCount  int64
+	outRate   float64
+	errRate   float64
+}
+
+// Computes per second log rates of containers from the change in counts
+// between display refreshes (see config.IsPerSecondDisplay)
+type ContainerRateTracker struct {
+	// Key: DisplayContainerStats.Id()
+	countsMap map[string]*containerCounts
+}
+
+func NewContainerRateTracker() *ContainerRateTracker {
+	return &ContainerRateTracker{countsMap: make(map[string]*containerCounts)}
+}
+
+// Update sets the OutRate / ErrRate of each container.  Calls made against
+// the same snapshot of event data (same stats time) keep the previous rates
+// and a count lower than the last one (stats were cleared) resets the rate
+// to 0.  Containers not in the list are forgotten.
+func (t *ContainerRateTracker) Update(statsTime time.Time, containerStatsArray []*DisplayContainerStats) {
+	countsMap := make(map[string]*containerCounts)
+	for _, cs := range containerStatsArray {
+		counts := t.countsMap[cs.Id()]
+		if counts == nil {
+			counts = &containerCounts{statsTime: statsTime, outCount: cs.OutCount, errCount: cs.ErrCount}
+		} else if seconds := statsTime.Sub(counts.statsTime).Seconds(); seconds > 0 {
+			counts.outRate = countRate(cs.OutCount-counts.outCount, seconds)
+			counts.errRate = countRate(cs.ErrCount-counts.errCount, seconds)
+			counts.statsTime = statsTime
+			counts.outCount = cs.OutCount
+			counts.errCount = cs.ErrCount
+		}
+		cs.OutRate = counts.outRate
+		cs.ErrRate = counts.errRate
+		countsMap[cs.Id()] = counts
+	}
+	t.countsMap = countsMap
+}
+
+func countRate(delta int64, seconds float64) float64 {
+	if delta < 0 {
+		return 0
+	}
+	return float64(delta) / seconds
+}
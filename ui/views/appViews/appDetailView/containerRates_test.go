@@ -0,0 +1,92 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appDetailView
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func newContainer(index int, outCount, errCount int64) *DisplayContainerStats {
+	containerStats := eventApp.NewContainerStats(index)
+	containerStats.OutCount = outCount
+	containerStats.ErrCount = errCount
+	return NewDisplayContainerStats(containerStats, eventApp.NewAppStats("app-1"))
+}
+
+var _ = Describe("ContainerRateTracker", func() {
+	var (
+		tracker   *ContainerRateTracker
+		statsTime time.Time
+	)
+
+	BeforeEach(func() {
+		tracker = NewContainerRateTracker()
+		statsTime = time.Date(2017, 5, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("has no rate on the first sample", func() {
+		container := newContainer(0, 100, 10)
+		tracker.Update(statsTime, []*DisplayContainerStats{container})
+		Expect(container.OutRate).To(Equal(0.0))
+		Expect(container.ErrRate).To(Equal(0.0))
+	})
+
+	It("computes the rate from the change since the previous sample", func() {
+		tracker.Update(statsTime, []*DisplayContainerStats{newContainer(0, 100, 10)})
+		container := newContainer(0, 150, 14)
+		tracker.Update(statsTime.Add(10*time.Second), []*DisplayContainerStats{container})
+		Expect(container.OutRate).To(Equal(5.0))
+		Expect(container.ErrRate).To(Equal(0.4))
+	})
+
+	It("keeps the rates for the same stats time", func() {
+		tracker.Update(statsTime, []*DisplayContainerStats{newContainer(0, 100, 10)})
+		tracker.Update(statsTime.Add(10*time.Second), []*DisplayContainerStats{newContainer(0, 150, 10)})
+		container := newContainer(0, 150, 10)
+		tracker.Update(statsTime.Add(10*time.Second), []*DisplayContainerStats{container})
+		Expect(container.OutRate).To(Equal(5.0))
+	})
+
+	It("resets the rate to 0 when the counter is reset", func() {
+		tracker.Update(statsTime, []*DisplayContainerStats{newContainer(0, 100, 10)})
+		container := newContainer(0, 20, 2)
+		tracker.Update(statsTime.Add(10*time.Second), []*DisplayContainerStats{container})
+		Expect(container.OutRate).To(Equal(0.0))
+		Expect(container.ErrRate).To(Equal(0.0))
+
+		// The rate is computed from the reset count on the next sample
+		container = newContainer(0, 40, 2)
+		tracker.Update(statsTime.Add(20*time.Second), []*DisplayContainerStats{container})
+		Expect(container.OutRate).To(Equal(2.0))
+	})
+
+	It("tracks each container and forgets removed ones", func() {
+		tracker.Update(statsTime, []*DisplayContainerStats{newContainer(0, 100, 0), newContainer(1, 0, 0)})
+		container1 := newContainer(1, 30, 0)
+		tracker.Update(statsTime.Add(10*time.Second), []*DisplayContainerStats{container1})
+		Expect(container1.OutRate).To(Equal(3.0))
+
+		// Container 0 is a first sample again
+		container0 := newContainer(0, 200, 0)
+		tracker.Update(statsTime.Add(20*time.Second), []*DisplayContainerStats{container0})
+		Expect(container0.OutRate).To(Equal(0.0))
+	})
+
+})
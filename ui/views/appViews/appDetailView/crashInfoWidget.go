@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
@@ -112,10 +113,34 @@ func (w *CrashInfoWidget) refreshDisplay(g *gocui.Gui) error {
 	fmt.Fprintf(v, "%v\n", util.CLEAR)
 	fmt.Fprintf(v, "%11v", " Last crash:")
 	fmt.Fprintf(v, " %v", lastCrashTimeDisplay)
-	fmt.Fprintf(v, "%v", util.CLEAR)
+	if w.detailView.CrashLooping {
+		fmt.Fprintf(v, " %v%vCRASH LOOPING", util.CLEAR, util.BRIGHT_RED)
+	}
+	fmt.Fprintf(v, "%v\n", util.CLEAR)
+	w.writeLogSummary(v)
 	return nil
 }
 
+// Container stdout / stderr log event totals and the stderr share of all
+// log events.  A high share is a cheap hint something is wrong even
+// before containers start crashing.
+func (w *CrashInfoWidget) writeLogSummary(v *gocui.View) {
+	stdout := w.detailView.TotalLogStdout
+	stderr := w.detailView.TotalLogStderr
+	fmt.Fprintf(v, "%11v", "       Logs:")
+	fmt.Fprintf(v, " out %v err %v", util.Format(stdout), util.Format(stderr))
+	total := stdout + stderr
+	if total == 0 {
+		return
+	}
+	ratio := float64(stderr) / float64(total) * 100
+	color := util.DIM_WHITE
+	if ratio >= config.StderrRatioWarnPercent {
+		color = util.DIM_YELLOW
+	}
+	fmt.Fprintf(v, " %v(%.1f%% err)%v", color, ratio, util.CLEAR)
+}
+
 func (w *CrashInfoWidget) getCrashCount(crashCount int) string {
 	if crashCount > 0 || crashData.IsCacheLoaded() {
 		return fmt.Sprintf("%v", crashCount)
@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 )
 
 type DisplayContainerStats struct {
@@ -34,7 +35,12 @@ type DisplayContainerStats struct {
 	ReservedMemory uint64
 	FreeDisk       uint64
 	ReservedDisk   uint64
-	key            string
+	// Number of CPUs of the cell running the container, 0 if not known
+	CellNumOfCpus int
+	// Log events per second since the previous display refresh (see ContainerRateTracker)
+	OutRate float64
+	ErrRate float64
+	key     string
 }
 
 func NewDisplayContainerStats(containerStats *eventApp.ContainerStats, appStats *eventApp.AppStats) *DisplayContainerStats {
@@ -44,6 +50,11 @@ func NewDisplayContainerStats(containerStats *eventApp.ContainerStats, appStats
 	return stats
 }
 
+// Container CPU percent, normalized per cell CPU when enabled (see config.IsCpuPerCore)
+func (cs *DisplayContainerStats) DisplayCpuPercentage() float64 {
+	return util.CpuPerCore(cs.ContainerMetric.GetCpuPercentage(), cs.CellNumOfCpus)
+}
+
 func (cs *DisplayContainerStats) Id() string {
 	if cs.key == "" {
 		// NOTE: Must include AppId and Index because this view is used by Diego cell view as well as App Detail view
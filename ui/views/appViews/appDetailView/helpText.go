@@ -41,24 +41,59 @@ reflected in the rate and response time values.
 **Crash Info Section**
 Crash Info section shows how many application containers have crashed
 in the last 10 minutes, 1 hour, and 24 hours.  It also shows the last
-time a container crashed in the previous 24 hours.
+time a container crashed in the previous 24 hours.  "CRASH LOOPING"
+is shown after the last crash time when an instance keeps crashing
+shortly after each restart (see the LOOP column of the app list).
+
+The Logs line totals the stdout and stderr log events of all the app's
+containers and shows the percent that were stderr.  The percent is
+highlighted at 25%% or more as a noisy stderr is often the first sign
+of trouble, before any container crashes.
 `
 
 const HelpColumnsText = `
 **Container Columns:**
 
   IDX - Application container index
-  CPU%% - CPU percent consumed by container
-  MEM_USED - Memory used by the container
+  CPU%% - CPU percent consumed by container (see -cpu-precision
+         and -cpu-per-core options)
+  MEM_USED - Memory used by the container (free with 'u' toggle)
   MEM_FREE - Memory free in the container
-  DISK_USED - Disk used by container
+  DISK_USED - Disk used by container (free with 'u' toggle)
   DISK_FREE - Disk free in the container
-  LOG_OUT - Total number of log stdout events  
-  LOG_ERR - Total number of log stderr events 
+  LOG_OUT - Total number of log stdout events (per second with shift-U)
+  LOG_ERR - Total number of log stderr events (per second with shift-U)
   CELL_IP - IP address of the cell running the container
 `
 
 const HelpLocalViewKeybindings = `
 **Display: **
-Press 'd' to show app detail view menu.
+Press 'd' to show app detail view menu.  The Services entry lists the
+service instances bound to the app with their service, plan and type.
+Bindings are loaded the first time the list is shown and cached until
+the metadata cache is flushed.  The App Events entry lists the recent
+cloud controller events of the app (staging, updates, scaling, crashes,
+etc.), newest first.
+
+**Jump to container: **
+Press 'j' to enter a container index (IDX) and highlight that
+container's row.
+
+**Filter by cell: **
+Press 'l' to enter a cell IP, or the start of one (e.g., 10.0.16.), to
+only list this app's containers running on matching cells.  The active
+filter is shown in the title.  Enter an empty value to clear it.
+
+**Open in Apps Manager: **
+Press 'b' to open the app in Apps Manager using the default browser.
+The Apps Manager url is built from the system domain of the API
+endpoint (api.<system domain>).  If the url can not be determined the
+app GUID is copied to the clipboard instead.
+
+**Restart instance: **
+Press shift-R to restart the highlighted container's instance index.
+Only that instance is stopped and replaced, other instances are not
+affected.  A confirmation is shown first and every confirmed restart
+is logged with the user and time.  Requires privileged mode and is not
+available in kiosk mode.
 `
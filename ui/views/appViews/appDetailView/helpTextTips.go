@@ -15,5 +15,5 @@
 
 package appDetailView
 
-const HelpTextTips = `**x**:exit view  **d**:display  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
+const HelpTextTips = `**x**:exit view  **d**:display  **j**:jump to IDX  **R**:restart IDX  **o**:order  **f**:filter  **h**:help  **UP**/**DOWN** arrow to highlight row
 **LEFT**/**RIGHT** arrow to scroll columns`
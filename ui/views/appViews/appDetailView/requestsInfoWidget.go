@@ -70,7 +70,7 @@ func (w *RequestsInfoWidget) Layout(g *gocui.Gui) error {
 
 func (w *RequestsInfoWidget) getAppName() string {
 	appMetadata := w.appMdMgr.FindAppMetadata(w.detailView.appId)
-	appName := appMetadata.Name
+	appName := w.appMdMgr.DisplayName(appMetadata)
 	return appName
 }
 
@@ -0,0 +1,187 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appDetailView
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/serviceBinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+// Number of lines of the widget not used by the binding rows: top blank
+// line, column header, blank line and key help
+const serviceInfoFixedLines = 4
+
+// Lists the service instances bound to the app.  Bindings are loaded in
+// the background the first time and cached (see serviceBinding package).
+type ServiceInfoWidget struct {
+	masterUI   masterUIInterface.MasterUIInterface
+	name       string
+	width      int
+	height     int
+	detailView *AppDetailView
+	// Index of the first binding shown when the list is longer than the widget
+	listOffset   int
+	bindingCount int
+}
+
+func NewServiceInfoWidget(masterUI masterUIInterface.MasterUIInterface, name string, width, height int, detailView *AppDetailView) *ServiceInfoWidget {
+	return &ServiceInfoWidget{masterUI: masterUI, name: name, width: width, height: height, detailView: detailView}
+}
+
+func (w *ServiceInfoWidget) Name() string {
+	return w.name
+}
+
+func (w *ServiceInfoWidget) Layout(g *gocui.Gui) error {
+	maxX, maxY := g.Size()
+	v, err := g.SetView(w.name, maxX/2-(w.width/2), maxY/2-(w.height/2), maxX/2+(w.width/2), maxY/2+(w.height/2))
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return errors.New(w.name + " layout error:" + err.Error())
+		}
+		v.Title = "Bound Services"
+		v.Frame = true
+		if err := keybinding.Set(g, w.name, 'x', gocui.ModNone, w.closeServiceInfoWidget, "close"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyEsc, gocui.ModNone, w.closeServiceInfoWidget, "close"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowUp, gocui.ModNone, w.arrowUp, "scroll up"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyArrowDown, gocui.ModNone, w.arrowDown, "scroll down"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyPgup, gocui.ModNone, w.pageUp, "page up"); err != nil {
+			return err
+		}
+		if err := keybinding.Set(g, w.name, gocui.KeyPgdn, gocui.ModNone, w.pageDown, "page down"); err != nil {
+			return err
+		}
+		if err := w.masterUI.SetCurrentViewOnTop(g); err != nil {
+			log.Panicln(err)
+		}
+	}
+	w.RefreshDisplay(g)
+	return nil
+}
+
+func (w *ServiceInfoWidget) closeServiceInfoWidget(g *gocui.Gui, v *gocui.View) error {
+	return w.masterUI.CloseView(w)
+}
+
+// Number of binding rows that fit in the widget
+func (w *ServiceInfoWidget) listHeight() int {
+	listHeight := w.height - 1 - serviceInfoFixedLines
+	if listHeight < 1 {
+		listHeight = 1
+	}
+	return listHeight
+}
+
+func (w *ServiceInfoWidget) scroll(g *gocui.Gui, delta int) error {
+	w.listOffset = w.listOffset + delta
+	w.clampListOffset()
+	return w.RefreshDisplay(g)
+}
+
+func (w *ServiceInfoWidget) clampListOffset() {
+	maxOffset := w.bindingCount - w.listHeight()
+	if w.listOffset > maxOffset {
+		w.listOffset = maxOffset
+	}
+	if w.listOffset < 0 {
+		w.listOffset = 0
+	}
+}
+
+func (w *ServiceInfoWidget) arrowUp(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, -1)
+}
+
+func (w *ServiceInfoWidget) arrowDown(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, 1)
+}
+
+func (w *ServiceInfoWidget) pageUp(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, -w.listHeight())
+}
+
+func (w *ServiceInfoWidget) pageDown(g *gocui.Gui, v *gocui.View) error {
+	return w.scroll(g, w.listHeight())
+}
+
+func (w *ServiceInfoWidget) UpdateDisplay(g *gocui.Gui) error {
+	return w.RefreshDisplay(g)
+}
+
+func (w *ServiceInfoWidget) RefreshDisplay(g *gocui.Gui) error {
+
+	v, err := g.View(w.name)
+	if err != nil {
+		return err
+	}
+
+	v.Clear()
+
+	cliConnection := w.detailView.GetEventProcessor().GetCliConnection()
+	bindings, loaded, loadErr := serviceBinding.FindServiceBindingsForApp(cliConnection, w.detailView.appId)
+	w.bindingCount = len(bindings)
+	w.clampListOffset()
+
+	fmt.Fprintf(v, " \n")
+	switch {
+	case !loaded:
+		fmt.Fprintf(v, " Loading service bindings...\n")
+	case loadErr != nil && len(bindings) == 0:
+		fmt.Fprintf(v, " Unable to load service bindings, see log\n")
+	case len(bindings) == 0:
+		fmt.Fprintf(v, " No services bound to this app\n")
+	default:
+		fmt.Fprintf(v, " %v%-30v %-20v %-20v %v%v\n", util.BRIGHT_WHITE, "NAME", "SERVICE", "PLAN", "TYPE", util.CLEAR)
+		end := w.listOffset + w.listHeight()
+		if end > len(bindings) {
+			end = len(bindings)
+		}
+		for _, binding := range bindings[w.listOffset:end] {
+			serviceLabel := binding.ServiceLabel
+			planName := binding.PlanName
+			instanceType := "managed"
+			if binding.IsUserProvided() {
+				serviceLabel = "user-provided"
+				planName = "--"
+				instanceType = "user-provided"
+			}
+			fmt.Fprintf(v, " %-30v %-20v %-20v %v\n", binding.ServiceInstanceName, serviceLabel, planName, instanceType)
+		}
+	}
+
+	fmt.Fprintf(v, "\n")
+	fmt.Fprintf(v, "\n %vx%v:exit view", "\033[37;1m", "\033[0m")
+	if len(bindings) > w.listHeight() {
+		fmt.Fprintf(v, "  %vUP%v/%vDOWN%v/%vPGUP%v/%vPGDN%v:scroll  (%v-%v of %v)", "\033[37;1m", "\033[0m", "\033[37;1m", "\033[0m",
+			"\033[37;1m", "\033[0m", "\033[37;1m", "\033[0m", w.listOffset+1, w.listOffset+w.listHeight(), len(bindings))
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appEventsView
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/jroimartin/gocui"
+)
+
+// Lists the recent cloud controller events of an app (/v2/events).  Events
+// are loaded in the background and cached briefly (see crashData.FindAppEvents).
+type AppEventsView struct {
+	*dataView.DataListView
+	appId    string
+	appMdMgr *app.AppMetadataManager
+}
+
+func NewAppEventsView(masterUI masterUIInterface.MasterUIInterface,
+	parentView dataView.DataListViewInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor,
+	appId string) *AppEventsView {
+
+	appMdMgr := eventProcessor.GetMetadataManager().GetAppMdManager()
+
+	asUI := &AppEventsView{appId: appId, appMdMgr: appMdMgr}
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("EVENT_TIME", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, parentView,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	// Keep event time in view when scrolling
+	dataListView.GetListWidget().SetLockColumns(1)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.GetListData = asUI.GetListData
+
+	asUI.DataListView = dataListView
+	asUI.updateTitle(false, nil)
+
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = HelpTextTips
+
+	return asUI
+}
+
+func (asUI *AppEventsView) initializeCallback(g *gocui.Gui, viewName string) error {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppEventsView, "close view"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppEventsView, "close view"); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+func (asUI *AppEventsView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, ColumnEventTime())
+	columns = append(columns, ColumnEventType())
+	columns = append(columns, ColumnActor())
+	columns = append(columns, ColumnDetail())
+	return columns
+}
+
+func (asUI *AppEventsView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *AppEventsView) postProcessData() []*DisplayAppEvent {
+
+	cliConnection := asUI.GetEventProcessor().GetCliConnection()
+	appEvents, loaded, loadErr := crashData.FindAppEvents(cliConnection, asUI.appId)
+	asUI.updateTitle(loaded, loadErr)
+
+	displayAppEvents := make([]*DisplayAppEvent, 0, len(appEvents))
+	for _, appEvent := range appEvents {
+		displayAppEvent := NewDisplayAppEvent(appEvent)
+		displayAppEvent.EventTimeFormatted = appEvent.Timestamp.Local().Format("01-02-2006 15:04:05")
+		displayAppEvents = append(displayAppEvents, displayAppEvent)
+	}
+	return displayAppEvents
+}
+
+func (asUI *AppEventsView) updateTitle(loaded bool, loadErr error) {
+	title := fmt.Sprintf("App: %v - Recent App Events", asUI.getAppName())
+	switch {
+	case !loaded:
+		title = title + " (loading...)"
+	case loadErr != nil:
+		title = title + " (load failed, see log)"
+	}
+	asUI.SetTitle(title)
+}
+
+func (asUI *AppEventsView) convertToListData(displayAppEvents []*DisplayAppEvent) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayAppEvents))
+	for _, d := range displayAppEvents {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *AppEventsView) closeAppEventsView(g *gocui.Gui, v *gocui.View) error {
+	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (asUI *AppEventsView) getAppName() string {
+	appMetadata := asUI.appMdMgr.FindAppMetadata(asUI.appId)
+	appName := asUI.appMdMgr.DisplayName(appMetadata)
+	return appName
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appEventsView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func ColumnEventTime() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayAppEvent).Timestamp.Before(c2.(*DisplayAppEvent).Timestamp)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appEvent := data.(*DisplayAppEvent)
+		return fmt.Sprintf("%20v", appEvent.EventTimeFormatted)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appEvent := data.(*DisplayAppEvent)
+		return fmt.Sprintf("%v", appEvent.Timestamp.UnixNano())
+	}
+	c := uiCommon.NewListColumn("EVENT_TIME", "EVENT_TIME", defaultColSize,
+		uiCommon.TIMESTAMP, true, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnEventType() *uiCommon.ListColumn {
+	defaultColSize := 28
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayAppEvent).Type < c2.(*DisplayAppEvent).Type
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appEvent := data.(*DisplayAppEvent)
+		return fmt.Sprintf("%-28.28v", appEvent.Type)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appEvent := data.(*DisplayAppEvent)
+		return appEvent.Type
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appEvent := data.(*DisplayAppEvent)
+		if appEvent.Type == "app.crash" {
+			return uiCommon.ATTENTION_ALERT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("EVENT_TYPE", "EVENT_TYPE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func ColumnActor() *uiCommon.ListColumn {
+	defaultColSize := 20
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayAppEvent).Actor < c2.(*DisplayAppEvent).Actor
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appEvent := data.(*DisplayAppEvent)
+		return fmt.Sprintf("%-20.20v", appEvent.Actor)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appEvent := data.(*DisplayAppEvent)
+		return appEvent.Actor
+	}
+	c := uiCommon.NewListColumn("ACTOR", "ACTOR", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func ColumnDetail() *uiCommon.ListColumn {
+	defaultColSize := 60
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayAppEvent).Detail < c2.(*DisplayAppEvent).Detail
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appEvent := data.(*DisplayAppEvent)
+		if appEvent.Detail != "" {
+			return appEvent.Detail
+		} else {
+			return "--"
+		}
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appEvent := data.(*DisplayAppEvent)
+		return appEvent.Detail
+	}
+	c := uiCommon.NewListColumn("DETAIL", "DETAIL", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
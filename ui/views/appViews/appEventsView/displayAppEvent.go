@@ -0,0 +1,34 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appEventsView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+
+type DisplayAppEvent struct {
+	*crashData.AppEvent
+
+	EventTimeFormatted string
+}
+
+func NewDisplayAppEvent(appEvent *crashData.AppEvent) *DisplayAppEvent {
+	displayAppEvent := &DisplayAppEvent{}
+	displayAppEvent.AppEvent = appEvent
+	return displayAppEvent
+}
+
+func (ae *DisplayAppEvent) Id() string {
+	return ae.Guid
+}
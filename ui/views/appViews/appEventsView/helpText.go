@@ -0,0 +1,45 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appEventsView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText +
+	helpView.HelpHeaderText +
+	HelpColumnsText +
+	helpView.HelpChildLevelDataViewKeybindings +
+	helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**App Events View**
+
+App Events view shows the most recent events the cloud controller
+recorded for the application (/v2/events): staging, updates, scaling,
+starts, stops, restarts, route mapping and crashes.  Up to 100 of the
+most recent events are loaded the first time the view is opened and
+reloaded when older than 60 seconds.  Events are listed oldest first.
+Crash events are shown in red.
+`
+
+const HelpColumnsText = `
+**App Events Columns:**
+
+  EVENT_TIME - Date/time of the event (24 hour format in local timezone)
+  EVENT_TYPE - Event type, e.g., audit.app.update or app.crash
+  ACTOR - User (or system component) that caused the event
+  DETAIL - Event metadata as key=value pairs.  For update events only
+           the requested changes are shown, e.g., instances=3
+`
@@ -24,9 +24,11 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/app"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 )
 
@@ -35,6 +37,8 @@ type AppHttpView struct {
 	appId         string
 	displayMenuId string
 	appMdMgr      *app.AppMetadataManager
+
+	requestChartWidget *RequestChartWidget
 }
 
 func NewAppHttpView(masterUI masterUIInterface.MasterUIInterface,
@@ -51,13 +55,18 @@ func NewAppHttpView(masterUI masterUIInterface.MasterUIInterface,
 		uiCommon.NewSortColumn("CODE", false),
 	}
 
+	requestChartHeight := 3
 	dataListView := dataView.NewDataListView(masterUI, parentView,
-		name, 0, bottomMargin,
+		name, requestChartHeight+1, bottomMargin,
 		eventProcessor, asUI, asUI.columnDefinitions(),
 		defaultSortColumns)
 
+	// Keep method and status code in view when scrolling
+	dataListView.GetListWidget().SetLockColumns(2)
+
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_HttpStartStop}
 
 	dataListView.SetTitle(fmt.Sprintf("App: %v - HTTP Response Info", asUI.getAppName()))
 
@@ -66,14 +75,17 @@ func NewAppHttpView(masterUI masterUIInterface.MasterUIInterface,
 
 	asUI.DataListView = dataListView
 
+	asUI.requestChartWidget = NewRequestChartWidget(masterUI, "requestChartWidget", requestChartHeight, asUI)
+	masterUI.LayoutManager().Add(asUI.requestChartWidget)
+
 	return asUI
 }
 
 func (asUI *AppHttpView) initializeCallback(g *gocui.Gui, viewName string) error {
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppHttpView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppHttpView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppHttpView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppHttpView, "close view"); err != nil {
 		log.Panicln(err)
 	}
 	return nil
@@ -86,6 +98,8 @@ func (asUI *AppHttpView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, ColumnLastAcivity())
 	columns = append(columns, ColumnCount())
 	columns = append(columns, ColumnLastResponseTime())
+	columns = append(columns, ColumnResponseContentLength())
+	columns = append(columns, ColumnAvgResponseContentLength())
 
 	return columns
 }
@@ -124,6 +138,7 @@ func (asUI *AppHttpView) postProcessData() []*DisplayHttpInfo {
 						displayHttpInfoByStatusCodeMap[statusCode] = displayHttpInfo
 					}
 					displayHttpInfo.HttpCount += httpInfo.HttpCount
+					displayHttpInfo.ResponseContentLength += httpInfo.ResponseContentLength
 					if displayHttpInfo.LastAcivity == nil || displayHttpInfo.LastAcivity.Before(*httpInfo.LastAcivity) {
 						displayHttpInfo.LastAcivity = httpInfo.LastAcivity
 						displayHttpInfo.LastAcivityFormatted = httpInfo.LastAcivity.Local().Format("01-02-2006 15:04:05")
@@ -134,15 +149,33 @@ func (asUI *AppHttpView) postProcessData() []*DisplayHttpInfo {
 		}
 	}
 
+	totalCount := int64(0)
+	totalContentLength := int64(0)
 	for _, httpMethodMap := range displayHttpInfoMap {
 		for _, httpInfo := range httpMethodMap {
+			if httpInfo.HttpCount > 0 {
+				httpInfo.AvgResponseContentLength = httpInfo.ResponseContentLength / httpInfo.HttpCount
+			}
+			totalCount += httpInfo.HttpCount
+			totalContentLength += httpInfo.ResponseContentLength
 			displayInfoList = append(displayInfoList, httpInfo)
 		}
 	}
+	asUI.updateTitle(totalCount, totalContentLength)
 
 	return displayInfoList
 }
 
+// Title with the app total and average response size over all responses
+func (asUI *AppHttpView) updateTitle(totalCount, totalContentLength int64) {
+	title := fmt.Sprintf("App: %v - HTTP Response Info", asUI.getAppName())
+	if totalCount > 0 {
+		title = fmt.Sprintf("%v (response bytes: %v total, %v avg)", title,
+			util.FormatBytes(uint64(totalContentLength)), util.FormatBytes(uint64(totalContentLength/totalCount)))
+	}
+	asUI.SetTitle(title)
+}
+
 func (asUI *AppHttpView) FindLastCrash(appStats *eventApp.AppStats) *crashData.ContainerCrashInfo {
 	if appStats.ContainerCrashInfo != nil && len(appStats.ContainerCrashInfo) > 0 {
 		last := len(appStats.ContainerCrashInfo) - 1
@@ -163,11 +196,14 @@ func (asUI *AppHttpView) closeAppHttpView(g *gocui.Gui, v *gocui.View) error {
 	if err := asUI.GetMasterUI().CloseView(asUI); err != nil {
 		return err
 	}
+	if err := asUI.GetMasterUI().CloseView(asUI.requestChartWidget); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (asUI *AppHttpView) getAppName() string {
 	appMetadata := asUI.appMdMgr.FindAppMetadata(asUI.appId)
-	appName := appMetadata.Name
+	appName := asUI.appMdMgr.DisplayName(appMetadata)
 	return appName
 }
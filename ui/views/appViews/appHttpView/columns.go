@@ -122,3 +122,41 @@ func ColumnLastResponseTime() *uiCommon.ListColumn {
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
 	return c
 }
+
+// Total response body bytes for this METHOD+CODE combination
+func ColumnResponseContentLength() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return (c1.(*DisplayHttpInfo).ResponseContentLength < c2.(*DisplayHttpInfo).ResponseContentLength)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayHttpInfo)
+		return fmt.Sprintf("%9v", util.FormatBytes(uint64(stats.ResponseContentLength)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayHttpInfo)
+		return fmt.Sprintf("%v", stats.ResponseContentLength)
+	}
+	c := uiCommon.NewListColumn("RESP_BYTES", "RESP_BYTES", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+// Average response body bytes for this METHOD+CODE combination
+func ColumnAvgResponseContentLength() *uiCommon.ListColumn {
+	defaultColSize := 9
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return (c1.(*DisplayHttpInfo).AvgResponseContentLength < c2.(*DisplayHttpInfo).AvgResponseContentLength)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayHttpInfo)
+		return fmt.Sprintf("%9v", util.FormatBytes(uint64(stats.AvgResponseContentLength)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayHttpInfo)
+		return fmt.Sprintf("%v", stats.AvgResponseContentLength)
+	}
+	c := uiCommon.NewListColumn("AVG_BYTES", "AVG_BYTES", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
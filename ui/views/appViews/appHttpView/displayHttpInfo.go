@@ -32,6 +32,9 @@ type DisplayHttpInfo struct {
 	LastAcivity    *time.Time
 	// Last response time in nano-seconds
 	LastResponseTime int64
+	// Total and average per response of the response body bytes
+	ResponseContentLength    int64
+	AvgResponseContentLength int64
 
 	LastAcivityFormatted string
 	key                  string
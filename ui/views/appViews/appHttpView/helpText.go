@@ -29,6 +29,11 @@ const HelpOverviewText = `
 
 App HTTP response info view shows all the HTTP and HTTPS responses
 that have occured from the selected application. 
+
+The chart above the list shows the number of requests and 5xx
+responses in each 10 second period over the last 10 minutes (set with
+-request-chart-minutes), the most recent on the right.  When the window
+is wider than the screen only the most recent periods are shown.
 `
 
 const HelpColumnsText = `
@@ -40,6 +45,12 @@ const HelpColumnsText = `
   COUNT - Number of responses that have occured for this METHOD+CODE
           combination.
   L_RESP - Last response time in milliseconds.
+  RESP_BYTES - Total response body bytes for this METHOD+CODE
+               combination.
+  AVG_BYTES - Average response body bytes per response.
+
+The title shows the total and average response bytes of the app.
+Request sizes are not included in the firehose HTTP events.
 `
 
 const HelpLocalViewKeybindings = `
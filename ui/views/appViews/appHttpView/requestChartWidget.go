@@ -0,0 +1,110 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appHttpView
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+// Chart of the app's request and 5xx counts over config.RequestHistoryMinutes
+// shown above the HTTP response list
+type RequestChartWidget struct {
+	masterUI masterUIInterface.MasterUIInterface
+	name     string
+	height   int
+	httpView *AppHttpView
+}
+
+func NewRequestChartWidget(masterUI masterUIInterface.MasterUIInterface, name string, height int, httpView *AppHttpView) *RequestChartWidget {
+	return &RequestChartWidget{masterUI: masterUI, name: name, height: height, httpView: httpView}
+}
+
+func (w *RequestChartWidget) Name() string {
+	return w.name
+}
+
+func (w *RequestChartWidget) Layout(g *gocui.Gui) error {
+
+	topOffset := w.httpView.GetTopOffset()
+	if w.masterUI.IsHeaderMinimized() {
+		// This will hide this view by displaying it off-view (negative top)
+		topOffset = 0
+	}
+
+	maxX, _ := g.Size()
+	top := topOffset - w.height - 1
+
+	v, err := g.SetView(w.name, 0, top, maxX-1, top+w.height)
+	if err != nil {
+		if err != gocui.ErrUnknownView {
+			return errors.New(w.name + " layout error:" + err.Error())
+		}
+		v.Frame = true
+	}
+	v.Title = fmt.Sprintf("Requests per %v seconds - last %v minutes", config.RequestHistoryBucketSeconds, config.RequestHistoryMinutes())
+	w.refreshDisplay(g, maxX)
+	return nil
+}
+
+func (w *RequestChartWidget) refreshDisplay(g *gocui.Gui, maxX int) error {
+
+	v, err := g.View(w.name)
+	if err != nil {
+		return err
+	}
+
+	v.Clear()
+
+	eventData := w.httpView.GetDisplayedEventData()
+	appStats := eventData.AppMap[w.httpView.appId]
+	if appStats == nil || appStats.RequestHistory == nil {
+		fmt.Fprintln(v, "No HTTP(S) requests seen")
+		return nil
+	}
+
+	counts, counts5xx := appStats.RequestHistory.Series(eventData.StatsTime)
+
+	// Label and max value columns take 24 characters, when the window does
+	// not fit only the most recent buckets are shown
+	chartWidth := maxX - 26
+	if chartWidth < 1 {
+		return nil
+	}
+	if len(counts) > chartWidth {
+		counts = counts[len(counts)-chartWidth:]
+		counts5xx = counts5xx[len(counts5xx)-chartWidth:]
+	}
+
+	fmt.Fprintf(v, "%9v %v %v\n", "HTTP(S):", util.Sparkline(counts), w.maxDisplay(counts))
+	fmt.Fprintf(v, "%9v %v%v%v %v\n", "5xx:", util.BRIGHT_RED, util.Sparkline(counts5xx), util.CLEAR, w.maxDisplay(counts5xx))
+	return nil
+}
+
+func (w *RequestChartWidget) maxDisplay(values []int64) string {
+	max := int64(0)
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+	return fmt.Sprintf("max %v", util.Format(max))
+}
@@ -18,18 +18,27 @@ package appView
 import (
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
 	"github.com/atotto/clipboard"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/snapshot"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/interfaces/managerUI"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
 	"github.com/jroimartin/gocui"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appCompareView"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
 )
 
@@ -40,6 +49,41 @@ type AppListView struct {
 	// If this is non-empty, we filter the app list by the supplied spaceId
 	// Used to support apps-by-space view.
 	spaceIdFilter string
+	// Only show apps that crashed within config.CrashFilterMinutes
+	crashFilter bool
+	// Hide apps that are not STARTED
+	hideStopped bool
+	// Only show apps with SSH enabled
+	sshFilter bool
+	// Number of apps hidden by hideStopped at the last refresh
+	hiddenStoppedCount int
+	// Sort order in effect before the crash filter was turned on
+	preCrashFilterSortColumns []*uiCommon.SortColumn
+	title                     string
+	// One line per app with CPU / memory mini-bars instead of the full table
+	miniBarMode bool
+	// Columns shown before mini-bar mode was turned on
+	preMiniBarColumnIds []string
+	// First app (A) marked for side by side compare, empty if none
+	compareAppId string
+}
+
+// Columns shown in mini-bar mode
+var miniBarColumnIds = []string{"APPLICATION", "SPACE", "ORG", "STATUS", "CPU_BAR", "MEM_BAR"}
+
+type crashSortWindow struct {
+	columnId string
+	label    string
+}
+
+// Windows cycled through by the sort by crash count action.  The first is
+// the crash filter window of the CRASH_RECENT column.
+func crashSortWindows() []crashSortWindow {
+	return []crashSortWindow{
+		{"CRASH_RECENT", fmt.Sprintf("%v minutes", config.CrashFilterMinutes())},
+		{"CRASH_1H", "1 hour"},
+		{"CRH", "24 hours"},
+	}
 }
 
 func NewAppListView(masterUI masterUIInterface.MasterUIInterface,
@@ -74,16 +118,12 @@ func NewAppListView(masterUI masterUIInterface.MasterUIInterface,
 	// TODO: Add additional header rows such as "active apps"
 	//dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_HttpStartStop, events.Envelope_LogMessage}
+	dataListView.PreRowDisplayCallback = asUI.preRowDisplay
+	dataListView.FooterNoteCallback = asUI.footerNote
 
-	title := "App List"
-
-	if asUI.spaceIdFilter != "" {
-		spaceMd := space.FindSpaceMetadata(asUI.spaceIdFilter)
-		orgMd := org.FindOrgMetadata(spaceMd.OrgGuid)
-		title = fmt.Sprintf("%v in Space %v Org %v", title, spaceMd.Name, orgMd.Name)
-	}
-
-	dataListView.SetTitle(title)
+	asUI.title = appListTitle(asUI.spaceIdFilter)
+	dataListView.SetTitle(asUI.title)
 
 	dataListView.HelpText = HelpText
 	if asUI.spaceIdFilter == "" {
@@ -98,23 +138,68 @@ func NewAppListView(masterUI masterUIInterface.MasterUIInterface,
 
 }
 
+func appListTitle(spaceIdFilter string) string {
+	title := "App List"
+	if spaceIdFilter != "" {
+		spaceMd := space.FindSpaceMetadata(spaceIdFilter)
+		orgMd := org.FindOrgMetadata(spaceMd.OrgGuid)
+		title = fmt.Sprintf("%v in Space %v Org %v", title, spaceMd.Name, orgMd.Name)
+	}
+	return title
+}
+
 func (asUI *AppListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, 'c', gocui.ModNone, asUI.copyAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'c', gocui.ModNone, asUI.copyAction, "copy to clipboard"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'S', gocui.ModNone, asUI.exportSnapshotAction, "export app stats snapshot to file"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'I', gocui.ModNone, asUI.exportInventoryAction, "export app metadata inventory to file"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'X', gocui.ModNone, asUI.toggleCrashFilterAction, "toggle show only recently crashed apps"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'A', gocui.ModNone, asUI.toggleHideStoppedAction, "toggle hide apps that are not started"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'L', gocui.ModNone, asUI.toggleSshFilterAction, "toggle show only apps with SSH enabled"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'N', gocui.ModNone, asUI.toggleQualifyDuplicateNamesAction, "toggle showing the space of app names used in more than one space"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'K', gocui.ModNone, asUI.crashSortAction, "sort by crash count (again to change window)"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'n', gocui.ModNone, asUI.editNoteAction, "add / edit note of highlighted app"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'm', gocui.ModNone, asUI.markCompareAction, "mark app to compare side by side"); err != nil {
+		log.Panicln(err)
+	}
+	if err := keybinding.Set(g, viewName, 'M', gocui.ModNone, asUI.toggleMiniBarAction, "toggle one line per app with CPU/memory bars"); err != nil {
 		log.Panicln(err)
 	}
 	if asUI.spaceIdFilter != "" {
-		if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.CloseDetailView); err != nil {
+		if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.CloseDetailView, "close view"); err != nil {
 			log.Panicln(err)
 		}
-		if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.CloseDetailView); err != nil {
+		if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.CloseDetailView, "close view"); err != nil {
 			log.Panicln(err)
 		}
 	}
 
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
+	if asUI.spaceIdFilter == "" {
+		if err := keybinding.Set(g, viewName, 'F', gocui.ModNone, asUI.toggleFollowAction, "toggle follow worst app mode"); err != nil {
+			log.Panicln(err)
+		}
+	}
 
 	return nil
 }
@@ -122,18 +207,23 @@ func (asUI *AppListView) initializeCallback(g *gocui.Gui, viewName string) error
 func (asUI *AppListView) enterAction(g *gocui.Gui, v *gocui.View) error {
 	highlightKey := asUI.GetListWidget().HighlightKey()
 	if highlightKey != "" {
-		_, bottomMargin := asUI.GetMargins()
-
-		detailView := appDetailView.NewAppDetailView(asUI.GetMasterUI(), asUI, "appDetailView",
-			bottomMargin,
-			asUI.GetEventProcessor(),
-			highlightKey)
-		asUI.SetDetailView(detailView)
-		asUI.GetMasterUI().OpenView(g, detailView)
+		asUI.openDetailView(g, highlightKey)
 	}
 	return nil
 }
 
+func (asUI *AppListView) openDetailView(g *gocui.Gui, appId string) *appDetailView.AppDetailView {
+	_, bottomMargin := asUI.GetMargins()
+
+	detailView := appDetailView.NewAppDetailView(asUI.GetMasterUI(), asUI, "appDetailView",
+		bottomMargin,
+		asUI.GetEventProcessor(),
+		appId)
+	asUI.SetDetailView(detailView)
+	asUI.GetMasterUI().OpenView(g, detailView)
+	return detailView
+}
+
 func (asUI *AppListView) columnDefinitions() []*uiCommon.ListColumn {
 	columns := make([]*uiCommon.ListColumn, 0)
 	columns = append(columns, columnAppName())
@@ -143,31 +233,50 @@ func (asUI *AppListView) columnDefinitions() []*uiCommon.ListColumn {
 		columns = append(columns, columnOrgName())
 	}
 
+	columns = append(columns, columnNote().SetPriority(1))
+	columns = append(columns, columnStatus().SetHidden(true))
+	columns = append(columns, columnCpuBar().SetHidden(true))
+	columns = append(columns, columnMemoryBar().SetHidden(true))
+
 	columns = append(columns, columnDesiredInstances())
 	columns = append(columns, columnReportingContainers())
 
 	columns = append(columns, columnTotalCpu())
+	columns = append(columns, columnCpuTrend())
 	columns = append(columns, columnCrashCount())
+	columns = append(columns, columnCrashLoop())
+	columns = append(columns, columnCrashRecentCount().SetPriority(1))
+	columns = append(columns, columnCrash1hCount().SetHidden(true))
 
 	columns = append(columns, columnTotalMemoryUsed())
+	columns = append(columns, columnMaxContainerMemoryPercent().SetPriority(1))
+	columns = append(columns, columnMemoryP95().SetPriority(1))
+	columns = append(columns, columnMemoryTrend().SetPriority(1))
 	columns = append(columns, columnTotalDiskUsed())
 
 	columns = append(columns, columnAvgResponseTimeL60Info())
 	columns = append(columns, columnLogStdout())
 	columns = append(columns, columnLogStderr())
+	columns = append(columns, columnLogStdoutRate().SetPriority(1))
+	columns = append(columns, columnLogStderrRate())
 
 	columns = append(columns, columnReq1())
 	columns = append(columns, columnReq10())
 	columns = append(columns, columnReq60())
+	columns = append(columns, columnLastSeen())
 
 	columns = append(columns, columnTotalReq())
-	columns = append(columns, column2XX())
-	columns = append(columns, column3XX())
-	columns = append(columns, column4XX())
+	columns = append(columns, column2XX().SetPriority(1))
+	columns = append(columns, column3XX().SetPriority(1))
+	columns = append(columns, column4XX().SetPriority(1))
 	columns = append(columns, column5XX())
 
-	columns = append(columns, columnIsolationSegmentName())
-	columns = append(columns, columnStackName())
+	columns = append(columns, columnRouteCount().SetPriority(1))
+	columns = append(columns, columnIsolationSegmentName().SetPriority(2))
+	columns = append(columns, columnStackName().SetPriority(2))
+	columns = append(columns, columnSshEnabled().SetHidden(true))
+	columns = append(columns, columnStartCommand().SetPriority(3))
+	columns = append(columns, columnAppGuid().SetHidden(true))
 
 	return columns
 }
@@ -192,6 +301,20 @@ func (asUI *AppListView) copyAction(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
+// Write the current per-app stats to a JSON snapshot file in the current
+// directory.  Two snapshots can be compared with 'cf top -diff-snapshots'
+func (asUI *AppListView) exportSnapshotAction(g *gocui.Gui, v *gocui.View) error {
+	masterUI := asUI.GetMasterUI()
+	s := snapshot.NewSnapshot(masterUI.GetCommonData(), masterUI.GetTargetDisplay())
+	fileName := snapshot.DefaultFileName(s.TakenAt)
+	if err := s.Write(fileName); err != nil {
+		toplog.Error("Snapshot export error: " + err.Error())
+		return nil
+	}
+	toplog.Info("Snapshot of %v apps written to %v", len(s.Apps), fileName)
+	return nil
+}
+
 func (asUI *AppListView) clipboardCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
 
 	clipboardValue := ""
@@ -225,20 +348,260 @@ func (asUI *AppListView) clipboardCallback(g *gocui.Gui, v *gocui.View, menuId s
 	return nil
 }
 
+// Toggle showing only apps that crashed within config.CrashFilterMinutes.
+// While on, the list is sorted by the recent crash count.
+func (asUI *AppListView) toggleCrashFilterAction(g *gocui.Gui, v *gocui.View) error {
+	listWidget := asUI.GetListWidget()
+	asUI.crashFilter = !asUI.crashFilter
+	if asUI.crashFilter {
+		asUI.preCrashFilterSortColumns = listWidget.GetSortColumns()
+		listWidget.SetSortColumns([]*uiCommon.SortColumn{
+			uiCommon.NewSortColumn("CRASH_RECENT", true),
+			uiCommon.NewSortColumn("CRH", true),
+			uiCommon.NewSortColumn("APPLICATION", false),
+		})
+		toplog.Info("Showing only apps that crashed in the last %v minutes", config.CrashFilterMinutes())
+	} else {
+		if asUI.preCrashFilterSortColumns != nil {
+			listWidget.SetSortColumns(asUI.preCrashFilterSortColumns)
+		}
+		toplog.Info("Crashed apps filter off")
+	}
+	asUI.updateTitle()
+	return asUI.UpdateDisplay(g)
+}
+
+// Prompt for a short note / tag to attach to the highlighted app for the
+// rest of the session.  An empty value removes the note.
+func (asUI *AppListView) editNoteAction(g *gocui.Gui, v *gocui.View) error {
+	appId := asUI.GetListWidget().HighlightKey()
+	if appId == "" {
+		return nil
+	}
+	commonData := asUI.GetMasterUI().GetCommonData()
+
+	labelText := "Note:"
+	maxLength := 30
+	titleText := "Note for " + asUI.GetAppMdMgr().FindAppMetadata(appId).Name
+	helpText := "no help"
+
+	applyCallbackFunc := func(g *gocui.Gui, v *gocui.View, w managerUI.Manager, inputValue string) error {
+		commonData.SetAppNote(appId, strings.TrimSpace(inputValue))
+		if err := w.(*uiCommon.InputDialogWidget).CloseWidget(g, v); err != nil {
+			return err
+		}
+		return asUI.UpdateDisplay(g)
+	}
+
+	dialogWidget := uiCommon.NewInputDialogWidget(asUI.GetMasterUI(),
+		"appNoteWidget", maxLength+len(labelText)+8, 6, labelText, maxLength, titleText, helpText,
+		commonData.AppNote(appId), applyCallbackFunc)
+
+	return dialogWidget.Init(g)
+}
+
+// The first press marks the highlighted app as app A, pressing again on
+// another app opens the compare view of A and the highlighted app (B).
+// Pressing again on app A clears the mark.
+func (asUI *AppListView) markCompareAction(g *gocui.Gui, v *gocui.View) error {
+	appId := asUI.GetListWidget().HighlightKey()
+	if appId == "" {
+		return nil
+	}
+	switch asUI.compareAppId {
+	case "":
+		asUI.compareAppId = appId
+		toplog.Info("App %v marked as A, press 'm' on another app to compare", asUI.GetAppMdMgr().FindAppMetadata(appId).Name)
+	case appId:
+		asUI.compareAppId = ""
+		toplog.Info("Compare mark cleared")
+	default:
+		appIdA := asUI.compareAppId
+		asUI.compareAppId = ""
+		asUI.updateTitle()
+		_, bottomMargin := asUI.GetMargins()
+		compareView := appCompareView.NewAppCompareView(asUI.GetMasterUI(), asUI, "appCompareView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			appIdA, appId)
+		asUI.SetDetailView(compareView)
+		return asUI.GetMasterUI().OpenView(g, compareView)
+	}
+	asUI.updateTitle()
+	return asUI.UpdateDisplay(g)
+}
+
+// Toggle hiding apps whose state is not STARTED.  The hidden count is shown
+// in the footer so stopped apps are not forgotten.
+func (asUI *AppListView) toggleHideStoppedAction(g *gocui.Gui, v *gocui.View) error {
+	asUI.hideStopped = !asUI.hideStopped
+	if asUI.hideStopped {
+		toplog.Info("Hiding apps that are not started")
+	} else {
+		toplog.Info("Showing all apps")
+	}
+	asUI.updateTitle()
+	return asUI.UpdateDisplay(g)
+}
+
+// Toggle adding the space name to app names that are used in more than
+// one space.  Applies to all app views.
+func (asUI *AppListView) toggleQualifyDuplicateNamesAction(g *gocui.Gui, v *gocui.View) error {
+	config.SetQualifyDuplicateAppNames(!config.QualifyDuplicateAppNames())
+	if config.QualifyDuplicateAppNames() {
+		toplog.Info("Showing space of app names used in more than one space")
+	} else {
+		toplog.Info("Showing app names only")
+	}
+	return asUI.UpdateDisplay(g)
+}
+
+// Toggle showing only apps with SSH enabled (or not known to be disabled).
+// The SSH column is shown when the filter is turned on.
+func (asUI *AppListView) toggleSshFilterAction(g *gocui.Gui, v *gocui.View) error {
+	asUI.sshFilter = !asUI.sshFilter
+	if asUI.sshFilter {
+		asUI.GetListWidget().ShowColumn(g, "SSH")
+		toplog.Info("Showing only apps with SSH enabled")
+	} else {
+		toplog.Info("SSH enabled apps filter off")
+	}
+	asUI.updateTitle()
+	return asUI.UpdateDisplay(g)
+}
+
+// Filter toggles saved with presets.  The space is only saved by the apps
+// by space view.
+func (asUI *AppListView) PresetState() map[string]string {
+	state := map[string]string{
+		"crash_filter": strconv.FormatBool(asUI.crashFilter),
+		"hide_stopped": strconv.FormatBool(asUI.hideStopped),
+		"ssh_filter":   strconv.FormatBool(asUI.sshFilter),
+	}
+	if asUI.spaceIdFilter != "" {
+		state["space_id"] = asUI.spaceIdFilter
+	}
+	return state
+}
+
+// Restore the filter toggles of a preset.  The preset sort is applied after
+// this so the sort in effect before the crash filter is not kept.
+func (asUI *AppListView) ApplyPresetState(state map[string]string) {
+	asUI.crashFilter, _ = strconv.ParseBool(state["crash_filter"])
+	asUI.hideStopped, _ = strconv.ParseBool(state["hide_stopped"])
+	asUI.sshFilter, _ = strconv.ParseBool(state["ssh_filter"])
+	asUI.preCrashFilterSortColumns = nil
+	if spaceId := state["space_id"]; asUI.spaceIdFilter != "" && spaceId != "" {
+		asUI.spaceIdFilter = spaceId
+		asUI.title = appListTitle(spaceId)
+	}
+	asUI.updateTitle()
+}
+
+// Title with the active app list filters
+func (asUI *AppListView) updateTitle() {
+	title := asUI.title
+	if asUI.crashFilter {
+		title = fmt.Sprintf("%v (crashed in last %v minutes)", title, config.CrashFilterMinutes())
+	}
+	if asUI.hideStopped {
+		title = fmt.Sprintf("%v (not started hidden)", title)
+	}
+	if asUI.sshFilter {
+		title = fmt.Sprintf("%v (SSH enabled or unknown)", title)
+	}
+	if asUI.compareAppId != "" {
+		title = fmt.Sprintf("%v (compare A: %v)", title, asUI.GetAppMdMgr().FindAppMetadata(asUI.compareAppId).Name)
+	}
+	asUI.SetTitle(title)
+}
+
+// Number of apps hidden by hideStopped, counted by getAppStatsMap
+func (asUI *AppListView) footerNote() string {
+	if !asUI.hideStopped {
+		return ""
+	}
+	return fmt.Sprintf("%v not started hidden", asUI.hiddenStoppedCount)
+}
+
+func (asUI *AppListView) toggleMiniBarAction(g *gocui.Gui, v *gocui.View) error {
+	listWidget := asUI.GetListWidget()
+	asUI.miniBarMode = !asUI.miniBarMode
+	if asUI.miniBarMode {
+		asUI.preMiniBarColumnIds = listWidget.VisibleColumnIds()
+		listWidget.SetVisibleColumns(g, miniBarColumnIds)
+		toplog.Info("Mini-bar mode on")
+	} else {
+		listWidget.SetVisibleColumns(g, asUI.preMiniBarColumnIds)
+		toplog.Info("Mini-bar mode off")
+	}
+	return asUI.UpdateDisplay(g)
+}
+
+// Sort the list by crash count.  The first press sorts by crashes in the
+// crash filter window, each additional press moves to the next window.
+func (asUI *AppListView) crashSortAction(g *gocui.Gui, v *gocui.View) error {
+	listWidget := asUI.GetListWidget()
+	crashSortWindows := crashSortWindows()
+	windowIndex := 0
+	sortColumns := listWidget.GetSortColumns()
+	if len(sortColumns) > 0 {
+		for i, window := range crashSortWindows {
+			if sortColumns[0].Id == window.columnId {
+				windowIndex = (i + 1) % len(crashSortWindows)
+				break
+			}
+		}
+	}
+	window := crashSortWindows[windowIndex]
+
+	// Ties break on app name so the order is stable between refreshes
+	newSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn(window.columnId, true),
+		uiCommon.NewSortColumn("APPLICATION", false),
+	}
+	if asUI.spaceIdFilter == "" {
+		newSortColumns = append(newSortColumns,
+			uiCommon.NewSortColumn("SPACE", false),
+			uiCommon.NewSortColumn("ORG", false))
+	}
+	listWidget.SetSortColumns(newSortColumns)
+	listWidget.ShowColumn(g, window.columnId)
+	toplog.Info("Sorting by crash count in last %v", window.label)
+	return asUI.UpdateDisplay(g)
+}
+
 func (asUI *AppListView) getAppStatsMap() map[string]*dataCommon.DisplayAppStats {
 	displayStatsMap := asUI.GetMasterUI().GetCommonData().GetDisplayAppStatsMap()
-	if asUI.spaceIdFilter != "" {
+	asUI.hiddenStoppedCount = 0
+	if asUI.spaceIdFilter != "" || asUI.crashFilter || asUI.hideStopped || asUI.sshFilter {
 		filteredMap := make(map[string]*dataCommon.DisplayAppStats)
 		for appId, appStats := range displayStatsMap {
-			if appStats.SpaceId == asUI.spaceIdFilter {
-				filteredMap[appId] = appStats
+			if asUI.spaceIdFilter != "" && appStats.SpaceId != asUI.spaceIdFilter {
+				continue
+			}
+			if asUI.crashFilter && appStats.CrashRecentCount == 0 {
+				continue
 			}
+			if asUI.sshFilter && !appStats.SshEnabled && !appStats.SshUnknown {
+				continue
+			}
+			if asUI.hideStopped && isStopped(asUI.GetAppMdMgr().FindAppMetadata(appId).State) {
+				asUI.hiddenStoppedCount++
+				continue
+			}
+			filteredMap[appId] = appStats
 		}
 		return filteredMap
 	}
 	return displayStatsMap
 }
 
+// Apps whose metadata is not loaded yet (no state) are not considered stopped
+func isStopped(state string) bool {
+	return state != "" && state != "STARTED"
+}
+
 func (asUI *AppListView) GetListData() []uiCommon.IData {
 	displayDataList := asUI.postProcessData()
 	listData := asUI.convertToListData(displayDataList)
@@ -247,27 +610,30 @@ func (asUI *AppListView) GetListData() []uiCommon.IData {
 
 func (asUI *AppListView) postProcessData() map[string]*dataCommon.DisplayAppStats {
 
+	// Aggregate log counts and rates are computed by CommonData.PostProcessData
 	displayStatsMap := asUI.getAppStatsMap()
 
-	for appId, appStats := range displayStatsMap {
-		logStdoutCount := int64(0)
-		logStderrCount := int64(0)
-		for _, cs := range appStats.ContainerArray {
-			if cs != nil {
-				logStdoutCount = logStdoutCount + cs.OutCount
-				logStderrCount = logStderrCount + cs.ErrCount
-			}
-		}
-		displayAppStats := displayStatsMap[appId]
-		displayAppStats.TotalLogStdout = logStdoutCount + appStats.NonContainerStdout
-		displayAppStats.TotalLogStderr = logStderrCount + appStats.NonContainerStderr
-	}
-
 	asUI.displayAppStatsMap = displayStatsMap
 	asUI.isWarmupComplete = asUI.GetMasterUI().IsWarmupComplete()
 	return displayStatsMap
 }
 
+// Tint the row based on the app's health score, healthy rows keep the
+// default color
+func (asUI *AppListView) preRowDisplay(data uiCommon.IData, isSelected bool) string {
+	appStats := data.(*dataCommon.DisplayAppStats)
+	if isSelected || !appStats.Monitored {
+		return ""
+	}
+	switch appStats.HealthLevel() {
+	case dataCommon.HEALTH_BAD:
+		return util.DIM_RED
+	case dataCommon.HEALTH_WARN:
+		return util.DIM_YELLOW
+	}
+	return ""
+}
+
 func (asUI *AppListView) convertToListData(statsMap map[string]*dataCommon.DisplayAppStats) []uiCommon.IData {
 	listData := make([]uiCommon.IData, 0, len(statsMap))
 	for _, d := range statsMap {
@@ -17,8 +17,11 @@ package appView
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
@@ -33,7 +36,16 @@ func columnAppName() *uiCommon.ListColumn {
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		appStats := data.(*dataCommon.DisplayAppStats)
-		return util.FormatDisplayData(appStats.AppName, defaultColSize)
+		appName := appStats.AppName
+		appMdMgr := columnOwner.(*AppListView).GetAppMdMgr()
+		if appMdMgr.IsDuplicateName(appName) {
+			appName = appMdMgr.DisplayName(appMdMgr.FindAppMetadata(appStats.AppId))
+		}
+		if appStats.RecentlyDeployed {
+			// Marker is not part of the raw value so sort and filter are unchanged
+			return uiCommon.Star + " " + util.FormatDisplayData(appName, defaultColSize-2)
+		}
+		return util.FormatDisplayData(appName, defaultColSize)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		appStats := data.(*dataCommon.DisplayAppStats)
@@ -91,6 +103,105 @@ func notMonitoredAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumn
 	return uiCommon.ATTENTION_NORMAL
 }
 
+// Single character app status used in mini-bar mode
+func columnStatus() *uiCommon.ListColumn {
+	status := func(appStats *dataCommon.DisplayAppStats) string {
+		switch {
+		case !appStats.Monitored:
+			return "?"
+		case appStats.CrashLooping:
+			return "L"
+		case appStats.TotalReportingContainers == 0:
+			return "-"
+		case appStats.HealthLevel() == dataCommon.HEALTH_BAD:
+			return "X"
+		case appStats.HealthLevel() == dataCommon.HEALTH_WARN:
+			return "!"
+		}
+		return "+"
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).HealthScore < c2.(*dataCommon.DisplayAppStats).HealthScore
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%2v", status(data.(*dataCommon.DisplayAppStats)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return status(data.(*dataCommon.DisplayAppStats))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		switch {
+		case !appStats.Monitored:
+			return uiCommon.ATTENTION_NOT_MONITORED
+		case appStats.CrashLooping:
+			return uiCommon.ATTENTION_ALERT
+		case appStats.TotalReportingContainers == 0:
+			return uiCommon.ATTENTION_NORMAL
+		case appStats.HealthLevel() == dataCommon.HEALTH_BAD:
+			return uiCommon.ATTENTION_HOT
+		case appStats.HealthLevel() == dataCommon.HEALTH_WARN:
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("STATUS", "ST", 2,
+		uiCommon.ALPHANUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+// Average container CPU percent as a mini-bar
+func columnCpuBar() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).AvgContainerCpuPercentage() < c2.(*dataCommon.DisplayAppStats).AvgContainerCpuPercentage()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.TotalReportingContainers == 0 {
+			return fmt.Sprintf("%-10v", "--")
+		}
+		return util.MiniBar(appStats.AvgContainerCpuPercentage(), 10)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%.2f", appStats.AvgContainerCpuPercentage())
+	}
+	c := uiCommon.NewListColumn("CPU_BAR", "CPU", 10,
+		uiCommon.NUMERIC, true, sortFunc, true, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
+// Highest container percent of reserved memory as a mini-bar
+func columnMemoryBar() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).MaxContainerMemoryPercent < c2.(*dataCommon.DisplayAppStats).MaxContainerMemoryPercent
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.TotalReportingContainers == 0 || appStats.MaxContainerMemoryPercent == 0 {
+			return fmt.Sprintf("%-10v", "--")
+		}
+		return util.MiniBar(appStats.MaxContainerMemoryPercent, 10)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%.2f", appStats.MaxContainerMemoryPercent)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		if appStats.MemoryRisk {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("MEM_BAR", "MEM", 10,
+		uiCommon.NUMERIC, true, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
 func columnSpaceName() *uiCommon.ListColumn {
 	defaultColSize := 10
 	sortFunc := func(c1, c2 util.Sortable) bool {
@@ -113,6 +224,62 @@ func columnSpaceName() *uiCommon.ListColumn {
 	return c
 }
 
+// Session note / tag attached to the app with 'n'
+func columnNote() *uiCommon.ListColumn {
+	defaultColSize := 12
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*dataCommon.DisplayAppStats).Note, c2.(*dataCommon.DisplayAppStats).Note)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return util.FormatDisplayData(appStats.Note, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return appStats.Note
+	}
+	c := uiCommon.NewListColumn("NOTE", "NOTE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
+// If SSH access to the app's containers is enabled, "?" if not known
+func columnSshEnabled() *uiCommon.ListColumn {
+	defaultColSize := 3
+	// no < unknown < yes
+	sshRank := func(appStats *dataCommon.DisplayAppStats) int {
+		switch {
+		case appStats.SshUnknown:
+			return 1
+		case appStats.SshEnabled:
+			return 2
+		}
+		return 0
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return sshRank(c1.(*dataCommon.DisplayAppStats)) < sshRank(c2.(*dataCommon.DisplayAppStats))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return util.FormatDisplayData(sshEnabledText(data.(*dataCommon.DisplayAppStats)), defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return sshEnabledText(data.(*dataCommon.DisplayAppStats))
+	}
+	c := uiCommon.NewListColumn("SSH", "SSH", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, true, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
+func sshEnabledText(appStats *dataCommon.DisplayAppStats) string {
+	switch {
+	case appStats.SshUnknown:
+		return "?"
+	case appStats.SshEnabled:
+		return "yes"
+	}
+	return "no"
+}
+
 func columnOrgName() *uiCommon.ListColumn {
 	defaultColSize := 10
 	sortFunc := func(c1, c2 util.Sortable) bool {
@@ -177,7 +344,7 @@ func columnDesiredInstances() *uiCommon.ListColumn {
 
 func columnTotalCpu() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
-		return c1.(*dataCommon.DisplayAppStats).TotalCpuPercentage < c2.(*dataCommon.DisplayAppStats).TotalCpuPercentage
+		return c1.(*dataCommon.DisplayAppStats).DisplayCpuPercentage < c2.(*dataCommon.DisplayAppStats).DisplayCpuPercentage
 	}
 	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
 		appStats := data.(*dataCommon.DisplayAppStats)
@@ -185,19 +352,13 @@ func columnTotalCpu() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalCpuInfo = fmt.Sprintf("%6v", "--")
 		} else {
-			if appStats.TotalCpuPercentage >= 100.0 {
-				totalCpuInfo = fmt.Sprintf("%6.0f", appStats.TotalCpuPercentage)
-			} else if appStats.TotalCpuPercentage >= 10.0 {
-				totalCpuInfo = fmt.Sprintf("%6.1f", appStats.TotalCpuPercentage)
-			} else {
-				totalCpuInfo = fmt.Sprintf("%6.2f", appStats.TotalCpuPercentage)
-			}
+			totalCpuInfo = util.FormatCpuPercent(appStats.DisplayCpuPercentage, 6)
 		}
 		return fmt.Sprintf("%6v", totalCpuInfo)
 	}
 	rawValueFunc := func(data uiCommon.IData) string {
 		appStats := data.(*dataCommon.DisplayAppStats)
-		return fmt.Sprintf("%.2f", appStats.TotalCpuPercentage)
+		return fmt.Sprintf("%.2f", appStats.DisplayCpuPercentage)
 	}
 	c := uiCommon.NewListColumn("CPU_PER", "CPU%", 6,
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
@@ -214,7 +375,7 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalMemoryUsed).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalMemoryUsed)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -227,6 +388,92 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 	return c
 }
 
+// Highest percent of reserved memory used by any container of the app.
+// Highlighted when at or over config.MemoryRiskPercent (OOM risk).
+func columnMaxContainerMemoryPercent() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).MaxContainerMemoryPercent < c2.(*dataCommon.DisplayAppStats).MaxContainerMemoryPercent
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.TotalReportingContainers == 0 || appStats.MaxContainerMemoryPercent == 0 {
+			return fmt.Sprintf("%7v", "--")
+		}
+		return fmt.Sprintf("%7.1f", appStats.MaxContainerMemoryPercent)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%.2f", appStats.MaxContainerMemoryPercent)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		if appStats.MemoryRisk {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("MEM_MAX", "MEM_MAX%", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+// 95th percentile of container memory over the recent history, compare
+// to reserved memory to spot over-provisioned apps
+func columnMemoryP95() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).MemoryP95 < c2.(*dataCommon.DisplayAppStats).MemoryP95
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.MemoryP95 == 0 {
+			return fmt.Sprintf("%9v", "--")
+		}
+		return fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.MemoryP95)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.MemoryP95)
+	}
+	c := uiCommon.NewListColumn("MEM_P95", "MEM_P95", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
+// Slope of total memory used per hour over the longer memory history.
+// Highlighted when the app is a potential memory leak.
+func columnMemoryTrend() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).MemorySlope < c2.(*dataCommon.DisplayAppStats).MemorySlope
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.MemorySlopeKnown {
+			return fmt.Sprintf("%9v", "--")
+		}
+		return fmt.Sprintf("%+9.1f", appStats.MemorySlope/util.MEGABYTE)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%.0f", appStats.MemorySlope)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		if appStats.MemoryLeakSuspect {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("MEM_TREND", "MEM_MB/H", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
 func columnTotalDiskUsed() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*dataCommon.DisplayAppStats).TotalDiskUsed < c2.(*dataCommon.DisplayAppStats).TotalDiskUsed
@@ -237,7 +484,7 @@ func columnTotalDiskUsed() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalDiskInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalDiskInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalDiskUsed).StringWithPrecision(1))
+			totalDiskInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalDiskUsed)))
 		}
 		return fmt.Sprintf("%9v", totalDiskInfo)
 	}
@@ -312,6 +559,47 @@ func columnLogStderr() *uiCommon.ListColumn {
 	return c
 }
 
+func columnLogStdoutRate() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).LogStdoutRate < c2.(*dataCommon.DisplayAppStats).LogStdoutRate
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%8v", util.Format(appStats.LogStdoutRate))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.LogStdoutRate)
+	}
+	c := uiCommon.NewListColumn("OUT_RATE", "OUT_RATE", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
+func columnLogStderrRate() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).LogStderrRate < c2.(*dataCommon.DisplayAppStats).LogStderrRate
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%8v", util.Format(appStats.LogStderrRate))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.LogStderrRate)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.Monitored && appStats.LogStderrRate > 0 {
+			return uiCommon.ATTENTION_WARN
+		}
+		return notMonitoredAttentionFunc(data, columnOwner)
+	}
+	c := uiCommon.NewListColumn("ERR_RATE", "ERR_RATE", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
 func columnReq1() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*dataCommon.DisplayAppStats).TotalTraffic.EventL1Rate < c2.(*dataCommon.DisplayAppStats).TotalTraffic.EventL1Rate
@@ -487,6 +775,45 @@ func columnStackName() *uiCommon.ListColumn {
 	return c
 }
 
+// Full app GUID.  Hidden by default as it is wide
+func columnAppGuid() *uiCommon.ListColumn {
+	defaultColSize := 36
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).AppId < c2.(*dataCommon.DisplayAppStats).AppId
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return util.FormatDisplayData(appStats.AppId, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return appStats.AppId
+	}
+	c := uiCommon.NewListColumn("APP_GUID", "APP_GUID", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
+// Detected start command, long commands are truncated.  The full command
+// is shown in the app detail info view
+func columnStartCommand() *uiCommon.ListColumn {
+	defaultColSize := 30
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*dataCommon.DisplayAppStats).StartCommand, c2.(*dataCommon.DisplayAppStats).StartCommand)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return util.FormatDisplayData(appStats.StartCommand, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return appStats.StartCommand
+	}
+	c := uiCommon.NewListColumn("START_CMD", "START_CMD", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, notMonitoredAttentionFunc)
+	return c
+}
+
 func columnIsolationSegmentName() *uiCommon.ListColumn {
 	defaultColSize := 15
 	sortFunc := func(c1, c2 util.Sortable) bool {
@@ -509,6 +836,107 @@ func columnIsolationSegmentName() *uiCommon.ListColumn {
 	return c
 }
 
+// Number of routes mapped to the app.  Started apps without a route are
+// highlighted as they cannot receive HTTP traffic through the go-router.
+func columnRouteCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).RouteCount < c2.(*dataCommon.DisplayAppStats).RouteCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.RouteCount < 0 {
+			return fmt.Sprintf("%6v", "--")
+		}
+		return fmt.Sprintf("%6v", util.Format(int64(appStats.RouteCount)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.RouteCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		// DesiredContainers is only set for started apps
+		if appStats.RouteCount == 0 && appStats.DesiredContainers > 0 {
+			return uiCommon.ATTENTION_WARN
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("ROUTES", "ROUTES", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+// Crash count within the crash filter window (see config.CrashFilterMinutes)
+func columnCrashRecentCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).CrashRecentCount < c2.(*dataCommon.DisplayAppStats).CrashRecentCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*dataCommon.DisplayAppStats)
+		crashCount := stats.CrashRecentCount
+		if crashCount > 0 || crashData.IsCacheLoaded() {
+			return fmt.Sprintf("%6v", util.Format(int64(crashCount)))
+		}
+		return fmt.Sprintf("%6v", "--")
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.CrashRecentCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		if appStats.CrashRecentCount > 0 {
+			return uiCommon.ATTENTION_HOT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	label := fmt.Sprintf("CRH%vM", config.CrashFilterMinutes())
+	c := uiCommon.NewListColumn("CRASH_RECENT", label, 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+// Crash count within a fixed window, used for sorting the list by crashes
+func columnCrashWindowCount(id, label string, countFunc func(*dataCommon.DisplayAppStats) int) *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return countFunc(c1.(*dataCommon.DisplayAppStats)) < countFunc(c2.(*dataCommon.DisplayAppStats))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		crashCount := countFunc(data.(*dataCommon.DisplayAppStats))
+		if crashCount > 0 || crashData.IsCacheLoaded() {
+			return fmt.Sprintf("%7v", util.Format(int64(crashCount)))
+		}
+		return fmt.Sprintf("%7v", "--")
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%v", countFunc(data.(*dataCommon.DisplayAppStats)))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		if countFunc(appStats) > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn(id, label, 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnCrash1hCount() *uiCommon.ListColumn {
+	return columnCrashWindowCount("CRASH_1H", "CRH_1H",
+		func(appStats *dataCommon.DisplayAppStats) int { return appStats.Crash1hCount })
+}
+
 func columnCrashCount() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*dataCommon.DisplayAppStats).Crash24hCount < c2.(*dataCommon.DisplayAppStats).Crash24hCount
@@ -542,3 +970,113 @@ func columnCrashCount() *uiCommon.ListColumn {
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
 	return c
 }
+
+// Shows LOOP when an instance of the app is crash looping
+func columnCrashLoop() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return !c1.(*dataCommon.DisplayAppStats).CrashLooping && c2.(*dataCommon.DisplayAppStats).CrashLooping
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.CrashLooping {
+			return fmt.Sprintf("%4v", "LOOP")
+		}
+		return fmt.Sprintf("%4v", "")
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", appStats.CrashLooping)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		if appStats.CrashLooping {
+			return uiCommon.ATTENTION_ALERT
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("CRASH_LOOP", "LOOP", 4,
+		uiCommon.ALPHANUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnLastSeen() *uiCommon.ListColumn {
+	// Apps that have never reported an event sort as the oldest
+	lastSeenAge := func(appStats *dataCommon.DisplayAppStats) time.Duration {
+		if appStats.LastSeenAge < 0 {
+			return time.Duration(math.MaxInt64)
+		}
+		return appStats.LastSeenAge
+	}
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return lastSeenAge(c1.(*dataCommon.DisplayAppStats)) < lastSeenAge(c2.(*dataCommon.DisplayAppStats))
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if appStats.LastSeenAge < 0 {
+			return fmt.Sprintf("%5v", "--")
+		}
+		return fmt.Sprintf("%5v", util.FormatDuration(appStats.LastSeenAge))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return fmt.Sprintf("%v", int64(appStats.LastSeenAge/time.Second))
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		attentionType := uiCommon.ATTENTION_NORMAL
+		switch {
+		case appStats.LastSeenAge < 0:
+		case appStats.LastSeenAge > time.Second*config.AppLastSeenHotSeconds:
+			attentionType = uiCommon.ATTENTION_HOT
+		case appStats.LastSeenAge > time.Second*config.AppLastSeenWarmSeconds:
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn("LAST_SEEN", "LAST", 5,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnCpuTrend() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*dataCommon.DisplayAppStats).CpuTrend < c2.(*dataCommon.DisplayAppStats).CpuTrend
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		trendDisplay := "-"
+		switch {
+		case appStats.TotalReportingContainers == 0:
+			trendDisplay = "--"
+		case appStats.CpuTrend > 0:
+			trendDisplay = uiCommon.UpArrow
+		case appStats.CpuTrend < 0:
+			trendDisplay = uiCommon.DownArrow
+		}
+		return fmt.Sprintf("%4v", trendDisplay)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		return strconv.Itoa(appStats.CpuTrend)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		appStats := data.(*dataCommon.DisplayAppStats)
+		if !appStats.Monitored {
+			return uiCommon.ATTENTION_NOT_MONITORED
+		}
+		attentionType := uiCommon.ATTENTION_NORMAL
+		if appStats.CpuTrend > 0 {
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+		return attentionType
+	}
+	c := uiCommon.NewListColumn("CPU_TREND", "TRND", 4,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/jroimartin/gocui"
 )
@@ -49,7 +50,7 @@ func (w *FilterWidget) Layout(g *gocui.Gui) error {
 		v.Title = "Filter (press ENTER to close)"
 		v.Frame = true
 		fmt.Fprintln(v, "Future home of filter screen")
-		if err := g.SetKeybinding(w.name, gocui.KeyEnter, gocui.ModNone, w.closeFilterWidget); err != nil {
+		if err := keybinding.Set(g, w.name, gocui.KeyEnter, gocui.ModNone, w.closeFilterWidget, "close"); err != nil {
 			return err
 		}
 
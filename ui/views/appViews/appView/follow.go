@@ -0,0 +1,113 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appView
+
+import (
+	"log"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
+	"github.com/jroimartin/gocui"
+)
+
+// Follow mode keeps the app detail view open on the app with the worst
+// health score, switching apps as the worst app changes.
+
+func (asUI *AppListView) toggleFollowAction(g *gocui.Gui, v *gocui.View) error {
+	masterUI := asUI.GetMasterUI()
+	if masterUI.IsFollowMode() {
+		masterUI.SetFollowCallback(nil)
+		toplog.Info("Follow mode off")
+		return nil
+	}
+	masterUI.SetFollowCallback(asUI.followWorstApp)
+	toplog.Info("Follow mode on: app detail view will follow the app with the worst health score")
+	return asUI.followWorstApp(g)
+}
+
+// Open (or switch) the app detail view to the current worst app.  Nothing is
+// done if another view is on top (e.g., user switched to a different view
+// or opened a menu) or if all apps are healthy.
+func (asUI *AppListView) followWorstApp(g *gocui.Gui) error {
+	masterUI := asUI.GetMasterUI()
+
+	var currentDetailView *appDetailView.AppDetailView
+	if detailView, ok := asUI.GetDetailView().(*appDetailView.AppDetailView); ok {
+		currentDetailView = detailView
+	}
+
+	top := masterUI.LayoutManager().Top()
+	switch {
+	case top == asUI:
+		// Detail view is not open (or was closed)
+		currentDetailView = nil
+	case currentDetailView != nil && top == currentDetailView:
+	default:
+		return nil
+	}
+
+	worstApp := worstApp(asUI.getAppStatsMap())
+	if worstApp == nil {
+		return nil
+	}
+	if currentDetailView != nil {
+		if currentDetailView.GetAppId() == worstApp.AppId {
+			return nil
+		}
+		if err := currentDetailView.Close(g); err != nil {
+			return err
+		}
+	}
+
+	toplog.Info("Follow mode: showing app %v (health score: %v)", worstApp.AppName, worstApp.HealthScore)
+	asUI.GetListWidget().SetHighlightKey(g, worstApp.AppId)
+	detailView := asUI.openDetailView(g, worstApp.AppId)
+	if err := keybinding.Set(g, detailView.Name(), 'F', gocui.ModNone, asUI.toggleFollowAction, "toggle follow worst app mode"); err != nil {
+		log.Panicln(err)
+	}
+	return nil
+}
+
+// Monitored app with the lowest health score.  Ties are broken by most
+// crashes in the last hour then most 5xx responses.  Returns nil if all
+// apps are healthy.
+func worstApp(statsMap map[string]*dataCommon.DisplayAppStats) *dataCommon.DisplayAppStats {
+	var worst *dataCommon.DisplayAppStats
+	for _, appStats := range statsMap {
+		if !appStats.Monitored || appStats.HealthScore >= 100 {
+			continue
+		}
+		if worst == nil || isWorse(appStats, worst) {
+			worst = appStats
+		}
+	}
+	return worst
+}
+
+func isWorse(a, b *dataCommon.DisplayAppStats) bool {
+	switch {
+	case a.HealthScore != b.HealthScore:
+		return a.HealthScore < b.HealthScore
+	case a.Crash1hCount != b.Crash1hCount:
+		return a.Crash1hCount > b.Crash1hCount
+	case a.Http5xxCount != b.Http5xxCount:
+		return a.Http5xxCount > b.Http5xxCount
+	}
+	// Keep the choice stable between refreshes
+	return a.AppId < b.AppId
+}
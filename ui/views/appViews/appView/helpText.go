@@ -28,33 +28,89 @@ period is complete.  After the warm-up period, if any applications are
 found not to be in the desired state (e.g., instances set to 4 but
 only 3 are running) an alert will be displayed and the application will
 be colored red.
+
+Each row is tinted by a health score (0-100) computed from crashes in
+the last hour, desired instances not reporting and the percent of HTTP
+responses that are 5xx: yellow below 80 and red below 50 (see
+-health-thresholds and -health-penalties), healthy rows are not tinted.
+
+Apps updated (pushed, scaled, restaged, etc.) within the last 30 minutes
+(see -recent-deploy-minutes) are marked with a star before the name.
 `
 
 const HelpColumnsText = `
 **Application Columns:**
 
-  APPLICATION - Application name
+  APPLICATION - Application name (starred if recently deployed).  With
+                shift-N names used in more than one space are
+                followed by the space name
   SPACE - Space name
   ORG - Organization name
+  NOTE - Note / tag attached to the app with 'n' (this session only)
   DCR - Desired containers (instances)
   RCR - Total reporting containers (ideally should match DCR)
-  CPU%% - Total CPU percent consumed by all containers
+  CPU%% - Total CPU percent consumed by all containers.  Precision is
+         set with -cpu-precision.  With -cpu-per-core each container's
+         value is divided by the number of CPUs of its cell
+  ST - Status shown in mini-bar mode: + healthy, ! health warning,
+       X health bad, L crash looping, - no reporting containers,
+       ? not monitored
+  CPU - Average container CPU%% as a bar (mini-bar mode)
+  MEM - Highest container percent of reserved memory as a bar
+        (mini-bar mode)
+  TRND - Trend of total CPU%% over the last few container metric
+         updates (up arrow, down arrow or - for flat)
   CRH - Crashed container count in last 24 hours
+  LOOP - Shown (red) when an instance is crash looping: it crashed
+         at least 3 times in a row with the first restart lasting
+         under 2 minutes and each later one at most twice as long
+         as the one before (restart backoff).  Cleared once the
+         instance runs longer than that without crashing
+  CRH10M - Crashed container count in last 10 minutes (window set
+           with -crash-filter-minutes)
+  CRH_1H - Crashed container count in last 1 hour (hidden until used
+           by shift-K sort)
   MEM_USED - Total memory used by all containers
+  MEM_MAX%% - Highest percent of reserved memory used by any one
+             container.  Red when at or over -memory-risk-percent
+             (default: 90) as the container is at risk of being
+             OOM killed
+  MEM_P95 - 95th percentile of memory used by a container of the app
+            over the recent samples of all running containers.  Far
+            below reserved memory suggests the app is over-provisioned
+  MEM_MB/H - Growth of total memory used in MB per hour over the last
+             60 memory samples (least squares slope).  Red when memory
+             is persistently growing at 10%% or more of reserved memory
+             per hour as the app may have a memory leak
   DSK_USED - Total disk used by all containers
   RESP - Avg response time in milliseconds over last 60 seconds
   LOG_OUT - Total number of stdout log events for all instance of app
   LOG_ERR - Total number of stderr log events for all instance of app
+  OUT_RATE - Number of stdout log events for all instances of app
+             since the previous display refresh
+  ERR_RATE - Number of stderr log events for all instances of app
+             since the previous display refresh.  Highlighted when non-zero
   REQ/1 - Number of HTTP(S) request/responses in last 1 second
   REQ/10 - Number of HTTP(S) request/responses in last 10 seconds
   REQ/60 - Number of HTTP(S) request/responses in last 60 seconds
+  LAST - Time since any event (metric, log, HTTP) was last seen
+         for app.  Yellow after 1 minute, red after 5 minutes.
   TOT_REQ - Count of all of the HTTP(S) request/responses
   2XX - Count of HTTP(S) responses with status code 200-299
   3XX - Count of HTTP(S) responses with status code 300-399
   4XX - Count of HTTP(S) responses with status code 400-499
   5XX - Count of HTTP(S) responses with status code 500-599
+  ROUTES - Number of routes mapped to app.  Highlighted when a
+           started app has no routes.  Shows '--' until route
+           metadata is loaded (see quiet start)
   ISO_SEG - Isolation Segment assigned to space
   STACK - The Cloud Foundry stack used by this app 
+  SSH - If SSH access to the app's containers is enabled (hidden by
+        default, shown by shift-L).  Only reported by the v2 API,
+        apps loaded with the v3 API show ? (unknown)
+  START_CMD - Detected start command (truncated, full command is
+              shown in the app detail info view)
+  APP_GUID - Application GUID (hidden by default, press 'k' to show)
 
 NOTE: The HTTP counters are based on traffic through the 
 go-router.  Applications that talk directly container-to-
@@ -76,4 +132,65 @@ const HelpLocalViewKeybindings = `
 Press 'c' when a row is selected to open the clipboard menu.
 This will copy to clipboard a command you can paste in 
 terminal window later.
+
+**Export snapshot: **
+Press 'S' to export the current per-app stats to a JSON snapshot file
+in the current directory.  Compare two snapshots (e.g., before and
+after a load test) with: cf top -diff-snapshots before.json,after.json
+
+**Export inventory: **
+Press shift-I to export the metadata of every app on the foundation
+(org, space, state, instances, memory / disk quota, buildpack and
+stack) to a CSV, JSON or aligned text file in the current directory.  Names that are
+not known are written as the GUID.
+
+**Crashed apps only: **
+Press shift-X to toggle showing only apps that crashed in the last
+10 minutes (window set with -crash-filter-minutes).  While on, the
+list is sorted by the recent crash count.
+
+**Hide stopped apps: **
+Press shift-A to hide apps that are not started.  The number of apps
+hidden is shown in the footer.  Press shift-A again to show all apps.
+
+**Duplicate app names: **
+App names used by apps in more than one space are logged when app
+metadata is loaded.  Press shift-N to show the space name after those
+names in the app list and the app detail, HTTP, crash and compare
+views.  Press shift-N again to show names only.
+
+**SSH enabled apps only: **
+Press shift-L to toggle showing only apps with SSH enabled (e.g., for
+a security review).  Apps whose SSH state is unknown (v3 API) are also
+shown.  The SSH column is shown while the filter is on.
+
+**Notes: **
+Press 'n' to attach a short note or tag (e.g., "checked" or "suspect")
+to the highlighted app.  Notes are kept in memory for the rest of the
+session only; clear the value to remove a note.  Use the filter ('f')
+on the NOTE column to show only apps with a given tag.
+
+**Compare apps: **
+Press 'm' on an app to mark it as app A (shown in the title), then
+press 'm' on a second app to open a view with the stats of both apps
+side by side and the change from A to B.  Press 'm' on app A again to
+clear the mark.
+
+**Mini-bar mode: **
+Press shift-M to show each app on one line with a status, a CPU bar and
+a memory bar instead of the full table.  Press shift-M again to return
+to the columns shown before.
+
+**Sort by crashes: **
+Press shift-K to sort by crash count in the crash filter window
+(CRH10M column, -crash-filter-minutes).  Press again to switch the
+window to 1 hour, then 24 hours.  Apps with the same count are sorted
+by name.
+
+**Follow mode: **
+Press shift-F to toggle follow mode.  While on, the app detail view
+is automatically opened on the app with the worst health score and
+switches to another app when it becomes the worst.  Nothing changes
+while all apps are healthy or while another view or menu is open.
+Press shift-F again (app list or detail view) to turn it off.
 `
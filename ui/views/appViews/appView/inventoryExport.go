@@ -0,0 +1,83 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package appView
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/inventory"
+	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/jroimartin/gocui"
+)
+
+func (asUI *AppListView) exportInventoryAction(g *gocui.Gui, v *gocui.View) error {
+	menuItems := uiCommon.FormatterMenuItems()
+
+	exportView := uiCommon.NewSelectMenuWidget(asUI.GetMasterUI(), "exportInventoryView", "Export App Inventory", menuItems, asUI.exportInventoryCallback)
+
+	asUI.GetMasterUI().LayoutManager().Add(exportView)
+	asUI.GetMasterUI().SetCurrentViewOnTop(g)
+	return nil
+}
+
+// Write every app in the app metadata cache, not only the apps in the list
+func (asUI *AppListView) exportInventoryCallback(g *gocui.Gui, v *gocui.View, menuId string) error {
+	appMdMgr := asUI.GetEventProcessor().GetMetadataManager().GetAppMdManager()
+	apps := inventory.Build(appMdMgr.AllApps())
+	fileName := inventory.DefaultFileName(time.Now(), menuId)
+
+	rows := make([]uiCommon.IData, 0, len(apps))
+	for _, app := range apps {
+		rows = append(rows, app)
+	}
+	if err := uiCommon.WriteFormattedFile(fileName, menuId, rows, inventoryExportColumns()); err != nil {
+		toplog.Error("Inventory export error: " + err.Error())
+		return nil
+	}
+	toplog.Info("Inventory of %v apps written to %v", len(apps), fileName)
+	return nil
+}
+
+// Column id is the JSON key and label the CSV header
+func inventoryExportColumns() []*uiCommon.ListColumn {
+	text := func(id, label string, value func(a *inventory.App) string) *uiCommon.ListColumn {
+		return uiCommon.NewExportColumn(id, label, uiCommon.ALPHANUMERIC, func(data uiCommon.IData) string {
+			return value(data.(*inventory.App))
+		})
+	}
+	number := func(id, label string, value func(a *inventory.App) int) *uiCommon.ListColumn {
+		return uiCommon.NewExportColumn(id, label, uiCommon.NUMERIC, func(data uiCommon.IData) string {
+			return strconv.Itoa(value(data.(*inventory.App)))
+		})
+	}
+	return []*uiCommon.ListColumn{
+		text("org", "ORG", func(a *inventory.App) string { return a.OrgName }),
+		text("space", "SPACE", func(a *inventory.App) string { return a.SpaceName }),
+		text("name", "APPLICATION", func(a *inventory.App) string { return a.Name }),
+		text("state", "STATE", func(a *inventory.App) string { return a.State }),
+		number("instances", "INSTANCES", func(a *inventory.App) int { return a.Instances }),
+		number("memory_mb", "MEMORY_MB", func(a *inventory.App) int { return a.MemoryMB }),
+		number("disk_quota_mb", "DISK_QUOTA_MB", func(a *inventory.App) int { return a.DiskQuotaMB }),
+		text("buildpack", "BUILDPACK", func(a *inventory.App) string { return a.Buildpack }),
+		text("stack", "STACK", func(a *inventory.App) string { return a.StackName }),
+		text("guid", "APP_GUID", func(a *inventory.App) string { return a.Guid }),
+		text("space_guid", "SPACE_GUID", func(a *inventory.App) string { return a.SpaceGuid }),
+		text("org_guid", "ORG_GUID", func(a *inventory.App) string { return a.OrgGuid }),
+		text("stack_guid", "STACK_GUID", func(a *inventory.App) string { return a.StackGuid }),
+	}
+}
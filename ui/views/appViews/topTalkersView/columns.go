@@ -0,0 +1,193 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topTalkersView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func columnRank() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTopTalker).Rank < c2.(*DisplayTopTalker).Rank
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%4v", talker.Rank)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%v", talker.Rank)
+	}
+	c := uiCommon.NewListColumn("RANK", "RANK", 4,
+		uiCommon.NUMERIC, false, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnAppName() *uiCommon.ListColumn {
+	defaultColSize := 50
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTopTalker).AppName, c2.(*DisplayTopTalker).AppName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return util.FormatDisplayData(talker.AppName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return talker.AppName
+	}
+	c := uiCommon.NewListColumn("APPLICATION", "APPLICATION", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnSpaceName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTopTalker).SpaceName, c2.(*DisplayTopTalker).SpaceName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return util.FormatDisplayData(talker.SpaceName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return talker.SpaceName
+	}
+	c := uiCommon.NewListColumn("SPACE", "SPACE", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnOrgName() *uiCommon.ListColumn {
+	defaultColSize := 10
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayTopTalker).OrgName, c2.(*DisplayTopTalker).OrgName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return util.FormatDisplayData(talker.OrgName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return talker.OrgName
+	}
+	c := uiCommon.NewListColumn("ORG", "ORG", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnRequestCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTopTalker).RequestCount < c2.(*DisplayTopTalker).RequestCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%9v", util.Format(talker.RequestCount))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%v", talker.RequestCount)
+	}
+	c := uiCommon.NewListColumn("REQUESTS", "REQUESTS", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnRequestRate() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTopTalker).RequestRate() < c2.(*DisplayTopTalker).RequestRate()
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		return fmt.Sprintf("%8.1f", data.(*DisplayTopTalker).RequestRate())
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		return fmt.Sprintf("%.1f", data.(*DisplayTopTalker).RequestRate())
+	}
+	c := uiCommon.NewListColumn("REQ_RATE", "REQ/SEC", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnRequestShare() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTopTalker).RequestShare < c2.(*DisplayTopTalker).RequestShare
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%6.1f", talker.RequestShare)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%.1f", talker.RequestShare)
+	}
+	c := uiCommon.NewListColumn("REQ_SHARE", "SHARE%", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnResponse5xx() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTopTalker).Response5xx < c2.(*DisplayTopTalker).Response5xx
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%7v", util.Format(talker.Response5xx))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%v", talker.Response5xx)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayTopTalker).Response5xx > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("5XX", "5XX", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnPercent5xx() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayTopTalker).Percent5xx < c2.(*DisplayTopTalker).Percent5xx
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%6.1f", talker.Percent5xx)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		talker := data.(*DisplayTopTalker)
+		return fmt.Sprintf("%.1f", talker.Percent5xx)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		talker := data.(*DisplayTopTalker)
+		switch {
+		case talker.Percent5xx >= 10:
+			return uiCommon.ATTENTION_HOT
+		case talker.Percent5xx > 0:
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("5XX_PERCENT", "5XX%", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
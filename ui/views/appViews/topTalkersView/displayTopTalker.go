@@ -0,0 +1,56 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topTalkersView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+
+// DisplayTopTalker is one row of the top talkers view: an app's request and
+// 5xx counts over config.TopTalkersWindowSeconds and its rank
+type DisplayTopTalker struct {
+	*dataCommon.DisplayAppStats
+
+	// 1 is the app with the most requests (or highest 5xx rate)
+	Rank         int
+	RequestCount int64
+	Response5xx  int64
+	// Time covered by the counts, up to config.TopTalkersWindowSeconds
+	WindowSeconds float64
+	// Percent of all requests in the window sent to this app
+	RequestShare float64
+	// Percent of this app's requests that were 5xx
+	Percent5xx float64
+}
+
+func NewDisplayTopTalker(appStats *dataCommon.DisplayAppStats, requestCount int64, response5xx int64, windowSeconds float64) *DisplayTopTalker {
+	talker := &DisplayTopTalker{DisplayAppStats: appStats, RequestCount: requestCount, Response5xx: response5xx,
+		WindowSeconds: windowSeconds}
+	if requestCount > 0 {
+		talker.Percent5xx = float64(response5xx) / float64(requestCount) * 100
+	}
+	return talker
+}
+
+// Average requests per second over the time covered by the counts
+func (talker *DisplayTopTalker) RequestRate() float64 {
+	return perSecond(talker.RequestCount, talker.WindowSeconds)
+}
+
+func perSecond(count int64, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(count) / seconds
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topTalkersView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + HelpKeybindingsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Top Talkers View**
+
+Top talkers ranks the apps of the foundation (or current org / space
+scope) by the HTTP requests they received in the last 60 seconds, or
+with 't' by their 5xx rate, and shows the top 20 (see
+-top-talkers-count).  Counts come from the per-app request history in
+10 second buckets so the newest bucket is still being filled and the
+window covers 50 to 60 seconds.  Rates are per second of the time
+actually covered.  The header shows the totals of all apps in the
+window.
+`
+
+const HelpColumnsText = `
+**Top Talkers Columns:**
+
+  RANK - Position in the ranking (by requests or 5xx rate)
+  APPLICATION - Application name
+  REQUESTS - Requests in the last 60 seconds
+  REQ/SEC - Average requests per second over the time covered by
+            the window
+  SHARE%% - Percent of all requests in the window sent to this app
+  5XX - 5xx responses in the last 60 seconds
+  5XX%% - Percent of this app's requests that were 5xx.  Red at 10%%
+         or more
+  SPACE - Space name
+  ORG - Organization name
+`
+
+const HelpKeybindingsText = `
+**Rank by:**
+Press 't' to toggle ranking apps by requests or by 5xx rate (percent
+of requests that were 5xx).  Apps with the same rate are ranked by
+5xx count, then requests.  Ranking by 5xx rate only includes apps with
+a 5xx response in the window.  Apps with fewer than 20 requests in the
+window are ranked after the others by 5xx count.
+
+**App detail:**
+Press ENTER to show the detail of the highlighted application.
+`
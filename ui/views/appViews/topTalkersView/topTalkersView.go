@@ -0,0 +1,228 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topTalkersView
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appDetailView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	"github.com/jroimartin/gocui"
+)
+
+// When ranking by 5xx rate, apps with fewer requests in the window are
+// ranked after the others so a single failed request does not top the list
+const MIN_5XX_RATE_REQUESTS = 20
+
+type TopTalkersView struct {
+	*dataView.DataListView
+
+	// Rank by 5xx rate instead of requests
+	rankBy5xx bool
+
+	// Foundation totals over the window of the last data refresh shown in header
+	totalRequestCount int64
+	totalResponse5xx  int64
+	activeAppCount    int
+	// Time covered by the totals (see RequestHistory.CountsSince)
+	windowSeconds float64
+}
+
+func NewTopTalkersView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *TopTalkersView {
+
+	asUI := &TopTalkersView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("RANK", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.UpdateHeaderCallback = asUI.updateHeader
+	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_HttpStartStop}
+
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = appView.HelpTextTips
+
+	asUI.DataListView = dataListView
+	asUI.updateTitle()
+
+	return asUI
+}
+
+func (asUI *TopTalkersView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnRank())
+	columns = append(columns, columnAppName())
+	columns = append(columns, columnRequestCount())
+	columns = append(columns, columnRequestRate())
+	columns = append(columns, columnRequestShare())
+	columns = append(columns, columnResponse5xx())
+	columns = append(columns, columnPercent5xx())
+	columns = append(columns, columnSpaceName().SetPriority(1))
+	columns = append(columns, columnOrgName().SetPriority(1))
+	return columns
+}
+
+func (asUI *TopTalkersView) initializeCallback(g *gocui.Gui, viewName string) error {
+
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted app"); err != nil {
+		log.Panicln(err)
+	}
+
+	if err := keybinding.Set(g, viewName, 't', gocui.ModNone, asUI.toggleRankAction, "toggle rank by requests / 5xx rate"); err != nil {
+		log.Panicln(err)
+	}
+
+	return nil
+}
+
+func (asUI *TopTalkersView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	if highlightKey != "" {
+		_, bottomMargin := asUI.GetMargins()
+
+		detailView := appDetailView.NewAppDetailView(asUI.GetMasterUI(), asUI, "appDetailView",
+			bottomMargin,
+			asUI.GetEventProcessor(),
+			highlightKey)
+		asUI.SetDetailView(detailView)
+		asUI.GetMasterUI().OpenView(g, detailView)
+	}
+	return nil
+}
+
+func (asUI *TopTalkersView) toggleRankAction(g *gocui.Gui, v *gocui.View) error {
+	asUI.rankBy5xx = !asUI.rankBy5xx
+	asUI.updateTitle()
+	asUI.GetListWidget().SetSortColumns([]*uiCommon.SortColumn{uiCommon.NewSortColumn("RANK", false)})
+	return asUI.UpdateDisplay(g)
+}
+
+func (asUI *TopTalkersView) updateTitle() {
+	rankBy := "requests"
+	if asUI.rankBy5xx {
+		rankBy = "5xx rate"
+	}
+	asUI.SetTitle(fmt.Sprintf("Top Talkers - top %v apps by %v in last %v seconds",
+		config.TopTalkersCount(), rankBy, config.TopTalkersWindowSeconds))
+}
+
+func (asUI *TopTalkersView) updateHeader(g *gocui.Gui, v *gocui.View) (int, error) {
+	fmt.Fprintf(v, "\nLast %v seconds: %v requests (%.1f/sec)   %v 5xx",
+		config.TopTalkersWindowSeconds, util.Format(asUI.totalRequestCount),
+		perSecond(asUI.totalRequestCount, asUI.windowSeconds), util.Format(asUI.totalResponse5xx))
+	if asUI.totalRequestCount > 0 {
+		fmt.Fprintf(v, " (%.1f%%)", float64(asUI.totalResponse5xx)/float64(asUI.totalRequestCount)*100)
+	}
+	fmt.Fprintf(v, "   Apps with requests: %v", asUI.activeAppCount)
+	return 3, nil
+}
+
+// Rank all apps with requests in the window and keep the top
+// config.TopTalkersCount.  Ties are broken by the counts, then name.
+func (asUI *TopTalkersView) GetListData() []uiCommon.IData {
+	statsTime := asUI.GetDisplayedEventData().StatsTime
+	displayStatsMap := asUI.GetMasterUI().GetCommonData().GetDisplayAppStatsMap()
+
+	talkers := make([]*DisplayTopTalker, 0)
+	totalRequestCount := int64(0)
+	totalResponse5xx := int64(0)
+	activeAppCount := 0
+	windowSeconds := float64(0)
+	for _, appStats := range displayStatsMap {
+		if appStats.RequestHistory == nil {
+			continue
+		}
+		requestCount, response5xx, span := appStats.RequestHistory.CountsSince(statsTime, config.TopTalkersWindowSeconds)
+		// All histories use the same buckets so cover the same span
+		windowSeconds = span.Seconds()
+		if requestCount == 0 {
+			continue
+		}
+		activeAppCount++
+		totalRequestCount += requestCount
+		totalResponse5xx += response5xx
+		if asUI.rankBy5xx && response5xx == 0 {
+			continue
+		}
+		talkers = append(talkers, NewDisplayTopTalker(appStats, requestCount, response5xx, windowSeconds))
+	}
+	asUI.totalRequestCount = totalRequestCount
+	asUI.totalResponse5xx = totalResponse5xx
+	asUI.activeAppCount = activeAppCount
+	asUI.windowSeconds = windowSeconds
+
+	sort.Sort(talkersByRank{talkers: talkers, rankBy5xx: asUI.rankBy5xx})
+	if len(talkers) > config.TopTalkersCount() {
+		talkers = talkers[:config.TopTalkersCount()]
+	}
+
+	listData := make([]uiCommon.IData, 0, len(talkers))
+	for i, talker := range talkers {
+		talker.Rank = i + 1
+		talker.RequestShare = float64(talker.RequestCount) / float64(totalRequestCount) * 100
+		listData = append(listData, talker)
+	}
+	return listData
+}
+
+type talkersByRank struct {
+	talkers   []*DisplayTopTalker
+	rankBy5xx bool
+}
+
+func (t talkersByRank) Len() int      { return len(t.talkers) }
+func (t talkersByRank) Swap(i, j int) { t.talkers[i], t.talkers[j] = t.talkers[j], t.talkers[i] }
+func (t talkersByRank) Less(i, j int) bool {
+	a, b := t.talkers[i], t.talkers[j]
+	if t.rankBy5xx {
+		aRated, bRated := a.RequestCount >= MIN_5XX_RATE_REQUESTS, b.RequestCount >= MIN_5XX_RATE_REQUESTS
+		if aRated != bRated {
+			return aRated
+		}
+		if aRated && a.Percent5xx != b.Percent5xx {
+			return a.Percent5xx > b.Percent5xx
+		}
+	}
+	first, second := [2]int64{a.RequestCount, a.Response5xx}, [2]int64{b.RequestCount, b.Response5xx}
+	if t.rankBy5xx {
+		first, second = [2]int64{a.Response5xx, a.RequestCount}, [2]int64{b.Response5xx, b.RequestCount}
+	}
+	for k := 0; k < 2; k++ {
+		if first[k] != second[k] {
+			return first[k] > second[k]
+		}
+	}
+	return util.CaseInsensitiveLess(a.AppName, b.AppName)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topTalkersView
+
+import (
+	"sort"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func newTalker(appName string, requestCount, response5xx int64) *DisplayTopTalker {
+	appStats := dataCommon.NewDisplayAppStats(eventApp.NewAppStats(appName + "-guid"))
+	appStats.AppName = appName
+	return NewDisplayTopTalker(appStats, requestCount, response5xx, 60)
+}
+
+func rankedNames(talkers []*DisplayTopTalker, rankBy5xx bool) []string {
+	sort.Sort(talkersByRank{talkers: talkers, rankBy5xx: rankBy5xx})
+	names := make([]string, 0, len(talkers))
+	for _, talker := range talkers {
+		names = append(names, talker.AppName)
+	}
+	return names
+}
+
+var _ = Describe("talkersByRank", func() {
+
+	It("ranks by requests, then 5xx count, then name", func() {
+		talkers := []*DisplayTopTalker{
+			newTalker("web", 100, 0),
+			newTalker("api", 500, 1),
+			newTalker("worker", 100, 3),
+			newTalker("Admin", 100, 0),
+		}
+		Expect(rankedNames(talkers, false)).To(Equal([]string{"api", "worker", "Admin", "web"}))
+	})
+
+	It("ranks by 5xx rate, ties by 5xx count then requests", func() {
+		talkers := []*DisplayTopTalker{
+			newTalker("web", 100, 10),
+			newTalker("api", 200, 20),
+			newTalker("worker", 100, 50),
+		}
+		Expect(rankedNames(talkers, true)).To(Equal([]string{"worker", "api", "web"}))
+	})
+
+	It("ranks apps with few requests after the others by 5xx count", func() {
+		talkers := []*DisplayTopTalker{
+			newTalker("once", 1, 1),
+			newTalker("busy", 10000, 2500),
+			newTalker("few", MIN_5XX_RATE_REQUESTS-1, 5),
+			newTalker("mostly-ok", 5000, 50),
+		}
+		Expect(rankedNames(talkers, true)).To(Equal([]string{"busy", "mostly-ok", "few", "once"}))
+	})
+
+})
@@ -18,8 +18,10 @@ package capacityPlanView
 import (
 	"log"
 
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -56,6 +58,7 @@ func NewCapacityPlanView(masterUI masterUIInterface.MasterUIInterface,
 	dataListView.InitializeCallback = asUI.initializeCallback
 	//dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ValueMetric}
 	//dataListView.PreRowDisplayCallback = asUI.preRowDisplay
 
 	dataListView.SetTitle("Capacity Plan (memory)")
@@ -94,7 +97,7 @@ func (asUI *CapacityPlanView) columnDefinitions() []*uiCommon.ListColumn {
 
 func (asUI *CapacityPlanView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
 
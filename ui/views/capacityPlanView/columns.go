@@ -84,7 +84,7 @@ func columnCapacityMemoryTotal() *uiCommon.ListColumn {
 		if CellStats.CapacityMemoryTotal == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.CapacityMemoryTotal).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(uint64(CellStats.CapacityMemoryTotal)))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -107,7 +107,7 @@ func columnCapacityMemoryRemaining() *uiCommon.ListColumn {
 		if CellStats.CapacityMemoryRemaining == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.CapacityMemoryRemaining).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(uint64(CellStats.CapacityMemoryRemaining)))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -178,7 +178,7 @@ func columnTotalContainerMemoryReserved() *uiCommon.ListColumn {
 		if CellStats.TotalContainerMemoryReserved == 0 {
 			display = fmt.Sprintf("%10v", "--")
 		} else {
-			display = fmt.Sprintf("%10v", util.ByteSize(CellStats.TotalContainerMemoryReserved).StringWithPrecision(1))
+			display = fmt.Sprintf("%10v", util.FormatBytes(CellStats.TotalContainerMemoryReserved))
 		}
 		return fmt.Sprintf("%10v", display)
 	}
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventApp"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -33,7 +35,8 @@ import (
 
 type CellDetailView struct {
 	*dataView.DataListView
-	cellIp string
+	cellIp      string
+	rateTracker *appDetailView.ContainerRateTracker
 }
 
 func NewCellDetailView(masterUI masterUIInterface.MasterUIInterface,
@@ -42,7 +45,8 @@ func NewCellDetailView(masterUI masterUIInterface.MasterUIInterface,
 	eventProcessor *eventdata.EventProcessor, cellIp string) *CellDetailView {
 
 	asUI := &CellDetailView{
-		cellIp: cellIp,
+		cellIp:      cellIp,
+		rateTracker: appDetailView.NewContainerRateTracker(),
 	}
 
 	defaultSortColumns := []*uiCommon.SortColumn{
@@ -58,9 +62,13 @@ func NewCellDetailView(masterUI masterUIInterface.MasterUIInterface,
 		eventProcessor, asUI, asUI.columnDefinitions(),
 		defaultSortColumns)
 
+	// Keep app name and container index in view when scrolling
+	dataListView.GetListWidget().SetLockColumns(2)
+
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric}
 
 	dataListView.SetTitle(fmt.Sprintf("Cell IP:%v Detail - Container List", cellIp))
 	dataListView.HelpText = HelpText
@@ -96,10 +104,10 @@ func (asUI *CellDetailView) columnDefinitions() []*uiCommon.ListColumn {
 
 func (asUI *CellDetailView) initializeCallback(g *gocui.Gui, viewName string) error {
 	// TODO: This needs to be handled in dataListView someplace for child (detailed) views as all of them will need a back action
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
 	return nil
@@ -125,6 +133,10 @@ func (asUI *CellDetailView) postProcessData() []*appDetailView.DisplayContainerS
 
 	appMap := asUI.GetDisplayedEventData().AppMap
 	appStatsArray := eventApp.ConvertFromMap(appMap, asUI.GetAppMdMgr())
+	numOfCpus := 0
+	if cellStats := asUI.GetDisplayedEventData().CellMap[asUI.cellIp]; cellStats != nil {
+		numOfCpus = cellStats.NumOfCpus
+	}
 	for _, appStats := range appStatsArray {
 		appMetadata := asUI.GetAppMdMgr().FindAppMetadata(appStats.AppId)
 		for _, containerStats := range appStats.ContainerArray {
@@ -135,6 +147,7 @@ func (asUI *CellDetailView) postProcessData() []*appDetailView.DisplayContainerS
 					displayContainerStats.AppName = appMetadata.Name
 					displayContainerStats.SpaceName = space.FindSpaceName(appMetadata.SpaceGuid)
 					displayContainerStats.OrgName = org.FindOrgNameBySpaceGuid(appMetadata.SpaceGuid)
+					displayContainerStats.CellNumOfCpus = numOfCpus
 
 					usedMemory := containerStats.ContainerMetric.GetMemoryBytes()
 					reservedMemory := uint64(appMetadata.MemoryMB) * util.MEGABYTE
@@ -154,6 +167,7 @@ func (asUI *CellDetailView) postProcessData() []*appDetailView.DisplayContainerS
 		}
 	}
 
+	asUI.rateTracker.Update(asUI.GetDisplayedEventData().StatsTime, containerStatsArray)
 	return containerStatsArray
 }
 
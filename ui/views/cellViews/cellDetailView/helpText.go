@@ -40,11 +40,11 @@ const HelpColumnsText = `
   ORG - Organization name
   CPU%% - Total CPU percent consumed by all containers on cell
   MEM_RSVD - Total memory reserved by all containers on cell
-  MEM_USED - Total memory actually in use by all containers
+  MEM_USED - Total memory actually in use by all containers (free with 'u' toggle)
   MEM_FREE - Total memory actually in use by all containers
   DISK_RSVD - Total disk reserved by all containers on cell
-  DISK_USED - Total disk actually in use by all containers
+  DISK_USED - Total disk actually in use by all containers (free with 'u' toggle)
   DISK_FREE - Free Disk space in cell VM available for containers
-  LOG_OUT - Number of stdout log events
-  LOG_ERR - Number of stderr log events
+  LOG_OUT - Number of stdout log events (per second with shift-U)
+  LOG_ERR - Number of stderr log events (per second with shift-U)
 `
@@ -0,0 +1,250 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cellHealthView
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/crashData"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/appViews/appView"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/views/cellViews/cellDetailView"
+	"github.com/jroimartin/gocui"
+)
+
+type CellHealthView struct {
+	*dataView.DataListView
+
+	// Summary of the last data refresh shown in header
+	flaggedCellCount    int
+	avgCrash24hCount    float64
+	unmatchedCrashCount int
+}
+
+func NewCellHealthView(masterUI masterUIInterface.MasterUIInterface,
+	name string, bottomMargin int,
+	eventProcessor *eventdata.EventProcessor) *CellHealthView {
+
+	asUI := &CellHealthView{}
+
+	defaultSortColumns := []*uiCommon.SortColumn{
+		uiCommon.NewSortColumn("CRASH_24H", true),
+		uiCommon.NewSortColumn("CRASH_1H", true),
+		uiCommon.NewSortColumn("CELL_IP", false),
+	}
+
+	dataListView := dataView.NewDataListView(masterUI, nil,
+		name, 0, bottomMargin,
+		eventProcessor, asUI, asUI.columnDefinitions(),
+		defaultSortColumns)
+
+	dataListView.InitializeCallback = asUI.initializeCallback
+	dataListView.UpdateHeaderCallback = asUI.updateHeader
+	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ValueMetric}
+
+	dataListView.SetTitle("Cell Health")
+	dataListView.HelpText = HelpText
+	dataListView.HelpTextTips = appView.HelpTextTips
+
+	asUI.DataListView = dataListView
+
+	return asUI
+
+}
+
+func (asUI *CellHealthView) columnDefinitions() []*uiCommon.ListColumn {
+	columns := make([]*uiCommon.ListColumn, 0)
+	columns = append(columns, columnCellIp())
+
+	columns = append(columns, columnTotalCpuPercentage())
+	columns = append(columns, columnTotalReportingContainers())
+	columns = append(columns, columnTotalApps())
+	columns = append(columns, columnTotalContainerMemoryUsed())
+
+	columns = append(columns, columnCrash1hCount())
+	columns = append(columns, columnCrash24hCount())
+	columns = append(columns, columnCrashedAppCount())
+
+	columns = append(columns, columnJobName())
+	columns = append(columns, columnJobIndex())
+
+	return columns
+}
+
+func (asUI *CellHealthView) initializeCallback(g *gocui.Gui, viewName string) error {
+
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
+		log.Panicln(err)
+	}
+
+	return nil
+}
+
+func (asUI *CellHealthView) enterAction(g *gocui.Gui, v *gocui.View) error {
+	highlightKey := asUI.GetListWidget().HighlightKey()
+	if asUI.GetListWidget().HighlightKey() != "" {
+		topMargin, bottomMargin := asUI.GetMargins()
+
+		detailView := cellDetailView.NewCellDetailView(asUI.GetMasterUI(), asUI,
+			"cellDetailView",
+			topMargin, bottomMargin,
+			asUI.GetEventProcessor(),
+			highlightKey)
+
+		asUI.SetDetailView(detailView)
+		asUI.GetMasterUI().OpenView(g, detailView)
+	}
+
+	return nil
+}
+
+func (asUI *CellHealthView) GetListData() []uiCommon.IData {
+	displayDataList := asUI.postProcessData()
+	listData := asUI.convertToListData(displayDataList)
+	return listData
+}
+
+func (asUI *CellHealthView) postProcessData() map[string]*DisplayCellHealth {
+	eventData := asUI.GetDisplayedEventData()
+
+	displayCellMap := make(map[string]*DisplayCellHealth)
+	// Crashes from CC event history only know the cell id (BOSH job index)
+	cellIpByJobIndex := make(map[string]string)
+	for ip, cellStats := range eventData.CellMap {
+		cellHealth := NewDisplayCellHealth(ip)
+		cellHealth.JobName = cellStats.JobName
+		cellHealth.JobIndex = cellStats.JobIndex
+		displayCellMap[ip] = cellHealth
+		if cellStats.JobIndex != "" {
+			cellIpByJobIndex[cellStats.JobIndex] = ip
+		}
+	}
+
+	findCell := func(ip string) *DisplayCellHealth {
+		cellHealth := displayCellMap[ip]
+		if cellHealth == nil {
+			// Cell has not sent any value metrics (or user is not privileged)
+			cellHealth = NewDisplayCellHealth(ip)
+			displayCellMap[ip] = cellHealth
+		}
+		return cellHealth
+	}
+
+	for _, appStats := range eventData.AppMap {
+		for _, containerStats := range appStats.ContainerArray {
+			if containerStats == nil || containerStats.Ip == "" || containerStats.ContainerMetric == nil {
+				continue
+			}
+			cellHealth := findCell(containerStats.Ip)
+			cellHealth.TotalReportingContainers++
+			cellHealth.appIds[appStats.AppId] = true
+			cellHealth.TotalContainerCpuPercentage += containerStats.ContainerMetric.GetCpuPercentage()
+			cellHealth.TotalContainerMemoryUsed += containerStats.ContainerMetric.GetMemoryBytes()
+		}
+	}
+
+	appIds := make(map[string]bool)
+	for appId := range eventData.AppMap {
+		appIds[appId] = true
+	}
+	for _, appId := range crashData.AppIds() {
+		appIds[appId] = true
+	}
+
+	unmatchedCrashCount := 0
+	oneHourAgo := eventData.StatsTime.Add(-1 * time.Hour)
+	for appId := range appIds {
+		var liveCrashInfo []*crashData.ContainerCrashInfo
+		if appStats := eventData.AppMap[appId]; appStats != nil {
+			liveCrashInfo = appStats.CrashSince(-24 * time.Hour)
+		}
+		crashInfoList := crashData.MergeCrashInfo(liveCrashInfo, crashData.FindSinceByApp(appId, -24*time.Hour))
+		for _, crashInfo := range crashInfoList {
+			ip := crashInfo.CellIp
+			if ip == "" {
+				ip = cellIpByJobIndex[crashInfo.CellId]
+			}
+			if ip == "" {
+				unmatchedCrashCount++
+				continue
+			}
+			cellHealth := findCell(ip)
+			cellHealth.Crash24hCount++
+			if crashInfo.CrashTime.After(oneHourAgo) {
+				cellHealth.Crash1hCount++
+			}
+			cellHealth.crashedAppIds[appId] = true
+		}
+	}
+
+	totalCrash24hCount := 0
+	for _, cellHealth := range displayCellMap {
+		cellHealth.TotalApps = len(cellHealth.appIds)
+		cellHealth.CrashedAppCount = len(cellHealth.crashedAppIds)
+		totalCrash24hCount += cellHealth.Crash24hCount
+	}
+
+	flaggedCellCount := 0
+	cellCount := len(displayCellMap)
+	for _, cellHealth := range displayCellMap {
+		if cellCount < 2 || cellHealth.Crash24hCount < config.CellCrashAnomalyMinCount {
+			continue
+		}
+		// Compare against the other cells so a single bad cell does not
+		// raise the average it is measured against
+		otherAvg := float64(totalCrash24hCount-cellHealth.Crash24hCount) / float64(cellCount-1)
+		if float64(cellHealth.Crash24hCount) >= otherAvg*config.CellCrashAnomalyFactor {
+			cellHealth.CrashAnomaly = true
+			flaggedCellCount++
+		}
+	}
+
+	asUI.flaggedCellCount = flaggedCellCount
+	asUI.unmatchedCrashCount = unmatchedCrashCount
+	asUI.avgCrash24hCount = 0
+	if cellCount > 0 {
+		asUI.avgCrash24hCount = float64(totalCrash24hCount) / float64(cellCount)
+	}
+
+	return displayCellMap
+}
+
+func (asUI *CellHealthView) convertToListData(displayCellMap map[string]*DisplayCellHealth) []uiCommon.IData {
+	listData := make([]uiCommon.IData, 0, len(displayCellMap))
+	for _, d := range displayCellMap {
+		listData = append(listData, d)
+	}
+	return listData
+}
+
+func (asUI *CellHealthView) PreRowDisplay(data uiCommon.IData, isSelected bool) string {
+	return ""
+}
+
+func (asUI *CellHealthView) updateHeader(g *gocui.Gui, v *gocui.View) (int, error) {
+	fmt.Fprintf(v, "\nFlagged cells: %v   Avg crashes per cell (24h): %.1f   Crashes not matched to a cell: %v",
+		asUI.flaggedCellCount, asUI.avgCrash24hCount, asUI.unmatchedCrashCount)
+	return 3, nil
+}
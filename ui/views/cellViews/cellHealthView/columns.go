@@ -0,0 +1,234 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cellHealthView
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+)
+
+func columnCellIp() *uiCommon.ListColumn {
+	defaultColSize := 16
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.Ip2long(c1.(*DisplayCellHealth).Ip) < util.Ip2long(c2.(*DisplayCellHealth).Ip)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return util.FormatDisplayData(cellHealth.Ip, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return cellHealth.Ip
+	}
+	c := uiCommon.NewListColumn("CELL_IP", "CELL_IP", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, crashAnomalyAttention)
+	return c
+}
+
+func columnTotalCpuPercentage() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).TotalContainerCpuPercentage < c2.(*DisplayCellHealth).TotalContainerCpuPercentage
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		if cellHealth.TotalReportingContainers == 0 {
+			return fmt.Sprintf("%6v", "--")
+		}
+		cpuPercentage := cellHealth.TotalContainerCpuPercentage
+		switch {
+		case cpuPercentage >= 100.0:
+			return fmt.Sprintf("%6.0f", cpuPercentage)
+		case cpuPercentage >= 10.0:
+			return fmt.Sprintf("%6.1f", cpuPercentage)
+		}
+		return fmt.Sprintf("%6.2f", cpuPercentage)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.TotalContainerCpuPercentage)
+	}
+	c := uiCommon.NewListColumn("CPU_PERCENT", "CPU%", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTotalReportingContainers() *uiCommon.ListColumn {
+	defaultColSize := 4
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).TotalReportingContainers < c2.(*DisplayCellHealth).TotalReportingContainers
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		if cellHealth.TotalReportingContainers == 0 {
+			return fmt.Sprintf("%4v", "--")
+		}
+		return fmt.Sprintf("%4v", cellHealth.TotalReportingContainers)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.TotalReportingContainers)
+	}
+	c := uiCommon.NewListColumn("RCR", "RCR", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTotalApps() *uiCommon.ListColumn {
+	defaultColSize := 4
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).TotalApps < c2.(*DisplayCellHealth).TotalApps
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		if cellHealth.TotalApps == 0 {
+			return fmt.Sprintf("%4v", "--")
+		}
+		return fmt.Sprintf("%4v", cellHealth.TotalApps)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.TotalApps)
+	}
+	c := uiCommon.NewListColumn("APPS", "APPS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTotalContainerMemoryUsed() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).TotalContainerMemoryUsed < c2.(*DisplayCellHealth).TotalContainerMemoryUsed
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		if cellHealth.TotalContainerMemoryUsed == 0 {
+			return fmt.Sprintf("%9v", "--")
+		}
+		return fmt.Sprintf("%9v", util.FormatBytes(cellHealth.TotalContainerMemoryUsed))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.TotalContainerMemoryUsed)
+	}
+	c := uiCommon.NewListColumn("C_MEM_USD", "C_MEM_USD", 9,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnCrash1hCount() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).Crash1hCount < c2.(*DisplayCellHealth).Crash1hCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%6v", cellHealth.Crash1hCount)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.Crash1hCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayCellHealth).Crash1hCount > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("CRASH_1H", "CR_1H", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnCrash24hCount() *uiCommon.ListColumn {
+	defaultColSize := 6
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).Crash24hCount < c2.(*DisplayCellHealth).Crash24hCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%6v", cellHealth.Crash24hCount)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.Crash24hCount)
+	}
+	c := uiCommon.NewListColumn("CRASH_24H", "CR_24H", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, crashAnomalyAttention)
+	return c
+}
+
+func columnCrashedAppCount() *uiCommon.ListColumn {
+	defaultColSize := 7
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).CrashedAppCount < c2.(*DisplayCellHealth).CrashedAppCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%7v", cellHealth.CrashedAppCount)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return fmt.Sprintf("%v", cellHealth.CrashedAppCount)
+	}
+	c := uiCommon.NewListColumn("CRASHED_APPS", "CR_APPS", defaultColSize,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnJobName() *uiCommon.ListColumn {
+	defaultColSize := 45
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return util.CaseInsensitiveLess(c1.(*DisplayCellHealth).JobName, c2.(*DisplayCellHealth).JobName)
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return util.FormatDisplayData(cellHealth.JobName, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return cellHealth.JobName
+	}
+	c := uiCommon.NewListColumn("JOB_NAME", "JOB_NAME", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnJobIndex() *uiCommon.ListColumn {
+	defaultColSize := 36
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayCellHealth).JobIndex < c2.(*DisplayCellHealth).JobIndex
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return util.FormatDisplayData(cellHealth.JobIndex, defaultColSize)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		cellHealth := data.(*DisplayCellHealth)
+		return cellHealth.JobIndex
+	}
+	c := uiCommon.NewListColumn("JOB_IDX", "JOB_IDX", defaultColSize,
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func crashAnomalyAttention(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	if data.(*DisplayCellHealth).CrashAnomaly {
+		return uiCommon.ATTENTION_HOT
+	}
+	return uiCommon.ATTENTION_NORMAL
+}
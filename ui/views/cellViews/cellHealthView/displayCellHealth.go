@@ -0,0 +1,50 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cellHealthView
+
+// DisplayCellHealth is one row of the cell health view: container and crash
+// totals for all apps that run (or crashed) on a cell
+type DisplayCellHealth struct {
+	Ip       string
+	JobName  string
+	JobIndex string
+
+	TotalReportingContainers    int
+	TotalApps                   int
+	TotalContainerCpuPercentage float64
+	TotalContainerMemoryUsed    uint64
+
+	Crash1hCount  int
+	Crash24hCount int
+	// Number of distinct apps with a crash on this cell in last 24 hours
+	CrashedAppCount int
+	// Crash count is well above the average of the other cells
+	CrashAnomaly bool
+
+	appIds        map[string]bool
+	crashedAppIds map[string]bool
+}
+
+func NewDisplayCellHealth(ip string) *DisplayCellHealth {
+	stats := &DisplayCellHealth{Ip: ip}
+	stats.appIds = make(map[string]bool)
+	stats.crashedAppIds = make(map[string]bool)
+	return stats
+}
+
+func (ch *DisplayCellHealth) Id() string {
+	return ch.Ip
+}
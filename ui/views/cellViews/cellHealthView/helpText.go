@@ -0,0 +1,49 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cellHealthView
+
+import "github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/helpView"
+
+const HelpText = HelpOverviewText + helpView.HelpHeaderText + HelpColumnsText + helpView.HelpTopLevelDataViewKeybindings + helpView.HelpCommonDataViewKeybindings
+
+const HelpOverviewText = `
+**Cell Health View**
+
+Cell health view combines app crashes and container totals by the diego
+cell they occurred on.  Crashes captured live are placed on the cell IP
+the container was last seen on.  Crashes loaded from the cloud controller
+event history are matched to a cell by BOSH job index (cell id), which
+requires the cell to be reporting its own metrics.  Crashes that can not
+be matched to a cell are counted in the header.
+
+A cell is flagged (red) when its 24 hour crash count is at least 3 and
+3 times the average of the other cells.
+`
+
+const HelpColumnsText = `
+**Cell Health Columns:**
+
+  CELL_IP - IP address of Cloud Foundry diego cell
+  CPU%% - CPU percent consumed by all containers on cell
+  RCR - Reporting containers
+  APPS - Number of apps with a reporting container on cell
+  C_MEM_USD - Memory actually in use by all containers
+  CR_1H - Container crashes on cell in last 1 hour
+  CR_24H - Container crashes on cell in last 24 hours
+  CR_APPS - Number of apps that crashed on cell in last 24 hours
+  JOB_NAME - BOSH job name
+  JOB_IDX - BOSH job index
+`
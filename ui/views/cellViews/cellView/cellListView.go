@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata/eventCell"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/stack"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -54,6 +57,7 @@ func NewCellListView(masterUI masterUIInterface.MasterUIInterface,
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_ValueMetric}
 
 	dataListView.SetTitle("Cell List")
 	dataListView.HelpText = HelpText
@@ -99,7 +103,7 @@ func (asUI *CellListView) columnDefinitions() []*uiCommon.ListColumn {
 
 func (asUI *CellListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
 
@@ -142,8 +146,14 @@ func (asUI *CellListView) postProcessData() map[string]*DisplayCellStats {
 	appMap := asUI.GetDisplayedEventData().AppMap
 	for _, appStats := range appMap {
 		for _, containerStats := range appStats.ContainerArray {
-			if containerStats != nil {
+			if containerStats != nil && containerStats.Ip != "" {
 				displayCellStat := displayCellMap[containerStats.Ip]
+				if displayCellStat == nil {
+					// Cell has not sent any value metrics (or user is not privileged)
+					// but we still want to bucket its containers by cell IP
+					displayCellStat = NewDisplayCellStats(eventCell.NewCellStats(containerStats.Ip))
+					displayCellMap[containerStats.Ip] = displayCellStat
+				}
 
 				if displayCellStat != nil {
 					logOutCount := containerStats.OutCount
@@ -147,7 +147,7 @@ func columnCapacityMemoryTotal() *uiCommon.ListColumn {
 		if CellStats.CapacityMemoryTotal == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.CapacityMemoryTotal).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(uint64(CellStats.CapacityMemoryTotal)))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -170,7 +170,7 @@ func columnCapacityMemoryRemaining() *uiCommon.ListColumn {
 		if cellStats.CapacityMemoryRemaining == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(cellStats.CapacityMemoryRemaining).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(uint64(cellStats.CapacityMemoryRemaining)))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -207,7 +207,7 @@ func columnCapacityDiskTotal() *uiCommon.ListColumn {
 		if CellStats.CapacityDiskTotal == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.CapacityDiskTotal).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(uint64(CellStats.CapacityDiskTotal)))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -230,7 +230,7 @@ func columnCapacityDiskRemaining() *uiCommon.ListColumn {
 		if CellStats.CapacityDiskRemaining == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.CapacityDiskRemaining).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(uint64(CellStats.CapacityDiskRemaining)))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -315,7 +315,7 @@ func columnTotalContainerMemoryReserved() *uiCommon.ListColumn {
 		if CellStats.TotalContainerMemoryReserved == 0 {
 			display = fmt.Sprintf("%10v", "--")
 		} else {
-			display = fmt.Sprintf("%10v", util.ByteSize(CellStats.TotalContainerMemoryReserved).StringWithPrecision(1))
+			display = fmt.Sprintf("%10v", util.FormatBytes(CellStats.TotalContainerMemoryReserved))
 		}
 		return fmt.Sprintf("%10v", display)
 	}
@@ -338,7 +338,7 @@ func columnTotalContainerMemoryUsed() *uiCommon.ListColumn {
 		if CellStats.TotalContainerMemoryUsed == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.TotalContainerMemoryUsed).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(CellStats.TotalContainerMemoryUsed))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -361,7 +361,7 @@ func columnTotalContainerDiskReserved() *uiCommon.ListColumn {
 		if CellStats.TotalContainerDiskReserved == 0 {
 			display = fmt.Sprintf("%10v", "--")
 		} else {
-			display = fmt.Sprintf("%10v", util.ByteSize(CellStats.TotalContainerDiskReserved).StringWithPrecision(1))
+			display = fmt.Sprintf("%10v", util.FormatBytes(CellStats.TotalContainerDiskReserved))
 		}
 		return fmt.Sprintf("%10v", display)
 	}
@@ -384,7 +384,7 @@ func columnTotalContainerDiskUsed() *uiCommon.ListColumn {
 		if CellStats.TotalContainerDiskUsed == 0 {
 			display = fmt.Sprintf("%9v", "--")
 		} else {
-			display = fmt.Sprintf("%9v", util.ByteSize(CellStats.TotalContainerDiskUsed).StringWithPrecision(1))
+			display = fmt.Sprintf("%9v", util.FormatBytes(CellStats.TotalContainerDiskUsed))
 		}
 		return fmt.Sprintf("%9v", display)
 	}
@@ -24,6 +24,11 @@ const HelpOverviewText = `
 
 Cell list view shows a list of all diego cells in the foundation.  This
 list may not be complete until the warm-up period is complete.
+
+Container totals (CPU%%, RCR, C_MEM_*, C_DSK_*) are aggregated from all
+monitored app containers by the cell IP they run on.  Cells that do not
+report their own metrics (or when running without doppler.firehose access)
+are still listed but the capacity columns will show "--".
 `
 
 const HelpColumnsText = `
@@ -18,7 +18,10 @@ package eventRateHistoryView
 import (
 	"log"
 
+	"github.com/cloudfoundry/sonde-go/events"
+
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -48,6 +51,8 @@ func NewEventRateHistoryView(masterUI masterUIInterface.MasterUIInterface,
 
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.GetListData = asUI.GetListData
+	// Event rates are counted before events are filtered by type
+	dataListView.EventTypes = []events.Envelope_EventType{}
 
 	dataListView.SetTitle("Event Rate Peak History")
 	dataListView.HelpText = HelpText
@@ -97,11 +102,11 @@ func (asUI *EventRateHistoryView) initializeCallback(g *gocui.Gui, viewName stri
 
 	keys := [...]gocui.Key{gocui.KeyArrowUp, gocui.KeyArrowDown, gocui.KeyPgdn, gocui.KeyPgup, gocui.KeyArrowRight, gocui.KeyArrowLeft}
 	for _, key := range keys {
-		if err := g.SetKeybinding(viewName, key, gocui.ModNone, asUI.highlightNavigationAction); err != nil {
+		if err := keybinding.Set(g, viewName, key, gocui.ModNone, asUI.highlightNavigationAction, "move highlight / scroll"); err != nil {
 			log.Panicln(err)
 		}
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.highlightNavigationEscAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.highlightNavigationEscAction, "clear highlight"); err != nil {
 		log.Panicln(err)
 	}
 	return nil
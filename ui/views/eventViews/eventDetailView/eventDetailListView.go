@@ -21,6 +21,7 @@ import (
 
 	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -88,10 +89,10 @@ func (asUI *EventDetailListView) columnDefinitions() []*uiCommon.ListColumn {
 func (asUI *EventDetailListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
 	// TODO: This needs to be handled in dataListView someplace for child (detailed) views as all of them will need a back action
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
 
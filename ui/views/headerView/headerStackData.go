@@ -193,6 +193,9 @@ func (asUI *HeaderWidget) updateHeaderStack(g *gocui.Gui, v *gocui.View) (int, e
 
 	appMdMgr := processor.GetMetadataManager().GetAppMdManager()
 	for _, app := range appMdMgr.AllApps() {
+		if !asUI.commonData.IsSpaceInScope(app.SpaceGuid) {
+			continue
+		}
 		spaceMetadata := space.FindSpaceMetadata(app.SpaceGuid)
 		isolationSegGuid := spaceMetadata.IsolationSegmentGuid
 		if isolationSegGuid == isolationSegment.DefaultIsolationSegmentGuid && isolationSegment.SharedIsolationSegment != nil {
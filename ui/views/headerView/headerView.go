@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 	"github.com/ecsteam/cloudfoundry-top-plugin/config"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventrouting"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
@@ -34,6 +35,11 @@ type HeaderWidget struct {
 	commonData *dataCommon.CommonData
 	name       string
 
+	// Used to compute the foundation wide 5xx rate between refreshes
+	last5xxCount int64
+	last5xxTime  time.Time
+	http5xxRate  float64
+
 	HeaderSize int
 }
 
@@ -90,18 +96,55 @@ func (w *HeaderWidget) UpdateDisplay(g *gocui.Gui) error {
 		fmt.Fprintf(v, "Duration: %-10v ", runtimeSeconds)
 	}
 
-	fmt.Fprintf(v, "   %v\n", statsTime.Format("01-02-2006 15:04:05"))
+	fmt.Fprintf(v, "   %v", statsTime.Format("01-02-2006 15:04:05"))
+	droppedCount := router.GetDroppedCount()
+	if droppedCount > 0 {
+		fmt.Fprintf(v, "   %vDropped: %v%v", util.BRIGHT_RED, util.FormatUint64(droppedCount), util.CLEAR)
+	}
+	lastEventTime := processor.GetLastEventTime()
+	if !lastEventTime.IsZero() {
+		lastEventAge := clock.Since(lastEventTime)
+		lastEventColor := util.DIM_WHITE
+		if lastEventAge > time.Second*config.LastEventWarnSeconds {
+			lastEventColor = util.BRIGHT_YELLOW
+		}
+		fmt.Fprintf(v, "   %vLast event: %v ago%v", lastEventColor, util.FormatDuration(lastEventAge), util.CLEAR)
+	}
+	if config.IsKioskMode() {
+		fmt.Fprintf(v, "   %vKIOSK%v", util.REVERSE_WHITE, util.CLEAR)
+	}
+	if config.IsPerSecondDisplay() {
+		fmt.Fprintf(v, "   %vPER-SEC%v", util.REVERSE_GREEN, util.CLEAR)
+	}
+	if w.masterUI.IsRecording() {
+		fmt.Fprintf(v, "   %vREC%v", util.REVERSE_RED, util.CLEAR)
+	}
+	if processor.GetMetadataManager().IsLoadMetadataInProgress() {
+		fmt.Fprintf(v, "   %vLoading metadata...%v", util.BRIGHT_YELLOW, util.CLEAR)
+	}
+	fmt.Fprintf(v, "\n")
 
-	if w.masterUI.GetDisplayPaused() {
+	if w.masterUI.IsIdlePaused() {
+		fmt.Fprintf(v, util.REVERSE_YELLOW)
+		fmt.Fprintf(v, " IDLE - paused (press any key to resume) \n")
+		fmt.Fprintf(v, util.CLEAR)
+	} else if w.masterUI.GetDisplayPaused() {
 		fmt.Fprintf(v, util.REVERSE_GREEN)
 		fmt.Fprintf(v, " Display update paused \n")
 		fmt.Fprintf(v, util.CLEAR)
 	} else {
-		fmt.Fprintf(v, "Target: %-78.78v\n", w.masterUI.GetTargetDisplay())
+		fmt.Fprintf(v, "Target: %-50.50v", w.masterUI.GetTargetDisplay())
+		if w.commonData.IsScoped() {
+			fmt.Fprintf(v, " %vScope: %v%v", util.REVERSE_YELLOW, w.commonData.ScopeDisplay(), util.CLEAR)
+		}
+		w.updateMetadataAge(v)
+		fmt.Fprintf(v, "\n")
 	}
 
-	// Base header is 2 rows plus 1 for border
-	headerLines := 3
+	w.updateFoundationSummary(v, statsTime, currentEventRate)
+
+	// Base header is 3 rows plus 1 for border
+	headerLines := 4
 
 	if !w.masterUI.IsHeaderMinimized() {
 		headerStackLines, err := w.updateHeaderStack(g, v)
@@ -115,6 +158,86 @@ func (w *HeaderWidget) UpdateDisplay(g *gocui.Gui) error {
 	return nil
 }
 
+// Age of each loaded metadata cache, e.g., "Metadata age: app:2m org:5m".
+// Caches older than the warn threshold (config.MetadataWarnMinutes) are
+// highlighted.
+func (w *HeaderWidget) updateMetadataAge(v *gocui.View) {
+	cacheTimes := w.router.GetProcessor().GetMetadataManager().CacheTimes()
+	if len(cacheTimes) == 0 {
+		return
+	}
+	warnAge := time.Duration(config.MetadataWarnMinutes()) * time.Minute
+	fmt.Fprintf(v, " %vMetadata age:", util.DIM_WHITE)
+	for _, cacheTime := range cacheTimes {
+		age := clock.Since(cacheTime.Time)
+		if age > warnAge {
+			fmt.Fprintf(v, " %v%v:%v%v", util.BRIGHT_YELLOW, cacheTime.Name, util.FormatDuration(age), util.DIM_WHITE)
+		} else {
+			fmt.Fprintf(v, " %v:%v", cacheTime.Name, util.FormatDuration(age))
+		}
+	}
+	fmt.Fprint(v, util.CLEAR)
+}
+
+// Single line summary of the whole foundation, e.g.,
+//
+// Foundation: Apps: 312 (280 started)  Mem: 40.2GiB Used / 96.0GiB Rsrvd  Events: 1200/sec  5xx: 0.4/sec
+func (w *HeaderWidget) updateFoundationSummary(v *gocui.View, statsTime time.Time, currentEventRate int) {
+
+	appMdMgr := w.router.GetProcessor().GetMetadataManager().GetAppMdManager()
+	totalApps := 0
+	startedApps := 0
+	scopedReservedMem := float64(0)
+	for _, app := range appMdMgr.AllApps() {
+		if !w.commonData.IsSpaceInScope(app.SpaceGuid) {
+			continue
+		}
+		totalApps++
+		if app.State == "STARTED" {
+			startedApps++
+			scopedReservedMem = scopedReservedMem + ((app.MemoryMB * util.MEGABYTE) * app.Instances)
+		}
+	}
+
+	totalMemoryUsed := int64(0)
+	total5xxCount := int64(0)
+	for _, appStats := range w.commonData.GetDisplayAppStatsMap() {
+		totalMemoryUsed = totalMemoryUsed + appStats.TotalMemoryUsed
+		total5xxCount = total5xxCount + appStats.Http5xxCount
+	}
+
+	if !w.last5xxTime.IsZero() && statsTime.After(w.last5xxTime) {
+		delta := total5xxCount - w.last5xxCount
+		if delta < 0 {
+			// Stats have been cleared
+			delta = 0
+		}
+		w.http5xxRate = float64(delta) / statsTime.Sub(w.last5xxTime).Seconds()
+	}
+	if !statsTime.Equal(w.last5xxTime) {
+		w.last5xxCount = total5xxCount
+		w.last5xxTime = statsTime
+	}
+
+	usedMemDisplay := "--"
+	if totalMemoryUsed > 0 {
+		usedMemDisplay = util.FormatBytes(uint64(totalMemoryUsed))
+	}
+	reservedMemDisplay := "--"
+	reservedMem := appMdMgr.GetTotalMemoryAllStartedApps()
+	label := "Foundation"
+	if w.commonData.IsScoped() {
+		reservedMem = scopedReservedMem
+		label = "Scope"
+	}
+	if reservedMem > 0 {
+		reservedMemDisplay = util.FormatBytes(uint64(reservedMem))
+	}
+
+	fmt.Fprintf(v, "%v: Apps: %v (%v started)  Mem: %v Used / %v Rsrvd  Events: %v/sec  5xx: %.1f/sec\n",
+		label, totalApps, startedApps, usedMemDisplay, reservedMemDisplay, currentEventRate, w.http5xxRate)
+}
+
 func Round(d, r time.Duration) time.Duration {
 	if r <= 0 {
 		return d
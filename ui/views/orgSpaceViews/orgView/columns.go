@@ -114,6 +114,23 @@ func columnNumberOfApps() *uiCommon.ListColumn {
 	return c
 }
 
+func columnNumberOfStartedApps() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayOrg).NumberOfStartedApps < c2.(*DisplayOrg).NumberOfStartedApps
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayOrg)
+		return fmt.Sprintf("%7v", stats.NumberOfStartedApps)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayOrg)
+		return strconv.Itoa(stats.NumberOfStartedApps)
+	}
+	c := uiCommon.NewListColumn("STARTED", "STARTED", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
 func columnDesiredContainers() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*DisplayOrg).DesiredContainers < c2.(*DisplayOrg).DesiredContainers
@@ -197,7 +214,7 @@ func columnMemoryLimit() *uiCommon.ListColumn {
 		if appStats.MemoryLimitInBytes == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.MemoryLimitInBytes).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.MemoryLimitInBytes)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -220,7 +237,7 @@ func columnTotalMemoryReserved() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalMemoryReserved).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalMemoryReserved)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -281,7 +298,7 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalMemoryUsed).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalMemoryUsed)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -304,7 +321,7 @@ func columnTotalDiskReserved() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalDiskInfo = fmt.Sprintf("%10v", "--")
 		} else {
-			totalDiskInfo = fmt.Sprintf("%10v", util.ByteSize(appStats.TotalDiskReserved).StringWithPrecision(1))
+			totalDiskInfo = fmt.Sprintf("%10v", util.FormatBytes(uint64(appStats.TotalDiskReserved)))
 		}
 		return fmt.Sprintf("%10v", totalDiskInfo)
 	}
@@ -327,7 +344,7 @@ func columnTotalDiskUsed() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalDiskInfo = fmt.Sprintf("%10v", "--")
 		} else {
-			totalDiskInfo = fmt.Sprintf("%10v", util.ByteSize(appStats.TotalDiskUsed).StringWithPrecision(1))
+			totalDiskInfo = fmt.Sprintf("%10v", util.FormatBytes(uint64(appStats.TotalDiskUsed)))
 		}
 		return fmt.Sprintf("%10v", totalDiskInfo)
 	}
@@ -390,3 +407,43 @@ func columnTotalReq() *uiCommon.ListColumn {
 		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
 	return c
 }
+
+func columnCrash1hCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayOrg).Crash1hCount < c2.(*DisplayOrg).Crash1hCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayOrg)
+		return fmt.Sprintf("%6v", stats.Crash1hCount)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayOrg)
+		return strconv.Itoa(stats.Crash1hCount)
+	}
+	attentionFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+		if data.(*DisplayOrg).Crash1hCount > 0 {
+			return uiCommon.ATTENTION_WARM
+		}
+		return uiCommon.ATTENTION_NORMAL
+	}
+	c := uiCommon.NewListColumn("CRASH_1H", "CR_1H", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, attentionFunc)
+	return c
+}
+
+func columnCrash24hCount() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplayOrg).Crash24hCount < c2.(*DisplayOrg).Crash24hCount
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		stats := data.(*DisplayOrg)
+		return fmt.Sprintf("%6v", stats.Crash24hCount)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		stats := data.(*DisplayOrg)
+		return strconv.Itoa(stats.Crash24hCount)
+	}
+	c := uiCommon.NewListColumn("CRASH_24H", "CR_24H", 6,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
@@ -23,8 +23,9 @@ type DisplayOrg struct {
 	QuotaName          string
 	MemoryLimitInBytes int64
 
-	NumberOfSpaces int
-	NumberOfApps   int
+	NumberOfSpaces      int
+	NumberOfApps        int
+	NumberOfStartedApps int
 
 	TotalCpuPercentage float64
 
@@ -41,6 +42,9 @@ type DisplayOrg struct {
 	TotalLogStderr           int64
 
 	HttpAllCount int64
+
+	Crash1hCount  int
+	Crash24hCount int
 }
 
 func NewDisplayOrg(Org *org.Org) *DisplayOrg {
@@ -27,7 +27,10 @@ const HelpText = HelpOverviewText +
 const HelpOverviewText = `
 **Organization View**
 
-Organization view shows a list of all organizations on the foundation.
+Organization view shows a list of all organizations on the foundation
+with the apps, containers, memory, disk and crashes of each org totaled.
+Sort by any column (e.g., MEM_RSVD) for a chargeback or capacity view
+org by org.
 `
 
 const HelpColumnsText = `
@@ -38,6 +41,7 @@ const HelpColumnsText = `
   QUOTA_NAME - Space quota name if one is assigned
   SPACES - Number of spaces defined within this org
   APPS - Number of apps within all spaces of this org
+  STARTED - Number of those apps in the STARTED state
   DCR - Number of desired containers (app instances)
   RCR - Number of reporting containers which are the the actual number of app
       instances running.  Normally DCR and RCR are equal.
@@ -51,6 +55,8 @@ const HelpColumnsText = `
   LOG_OUT - Total number of stdout log events for all instance of app
   LOG_ERR - Total number of stderr log events for all instance of app
   TOT_REQ - Count of all of the HTTP(S) request/responses
+  CR_1H - Number of container crashes of all apps in the last hour
+  CR_24H - Number of container crashes of all apps in the last 24 hours
 
 `
 
@@ -20,11 +20,13 @@ import (
 	"log"
 
 	"github.com/atotto/clipboard"
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -59,6 +61,7 @@ func NewOrgListView(masterUI masterUIInterface.MasterUIInterface,
 
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_HttpStartStop}
 
 	dataListView.SetTitle("Org List")
 	dataListView.HelpText = HelpText
@@ -72,11 +75,11 @@ func NewOrgListView(masterUI masterUIInterface.MasterUIInterface,
 
 func (asUI *OrgListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, 'c', gocui.ModNone, asUI.copyAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'c', gocui.ModNone, asUI.copyAction, "copy to clipboard"); err != nil {
 		log.Panicln(err)
 	}
 
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
 
@@ -107,6 +110,7 @@ func (asUI *OrgListView) columnDefinitions() []*uiCommon.ListColumn {
 
 	columns = append(columns, columnNumberOfSpaces())
 	columns = append(columns, columnNumberOfApps())
+	columns = append(columns, columnNumberOfStartedApps())
 
 	columns = append(columns, columnDesiredContainers())
 	columns = append(columns, columnReportingContainers())
@@ -126,6 +130,9 @@ func (asUI *OrgListView) columnDefinitions() []*uiCommon.ListColumn {
 
 	columns = append(columns, columnTotalReq())
 
+	columns = append(columns, columnCrash1hCount())
+	columns = append(columns, columnCrash24hCount())
+
 	return columns
 }
 
@@ -237,8 +244,13 @@ func (asUI *OrgListView) postProcessData() map[string]*DisplayOrg {
 			displayOrg.TotalDiskUsed += appStats.TotalDiskUsed
 			displayOrg.TotalReportingContainers += appStats.TotalReportingContainers
 			displayOrg.DesiredContainers += appStats.DesiredContainers
+			displayOrg.Crash1hCount += appStats.Crash1hCount
+			displayOrg.Crash24hCount += appStats.Crash24hCount
 
 			appMetadata := appMdMgr.FindAppMetadata(appStats.AppId)
+			if appMetadata.State == "STARTED" {
+				displayOrg.NumberOfStartedApps++
+			}
 			displayOrg.TotalMemoryReserved += (int64(appMetadata.MemoryMB) * util.MEGABYTE) * int64(appMetadata.Instances)
 			displayOrg.TotalDiskReserved += (int64(appMetadata.DiskQuotaMB) * util.MEGABYTE) * int64(appMetadata.Instances)
 
@@ -60,7 +60,7 @@ func columnQuotaName() *uiCommon.ListColumn {
 		return stats.QuotaName
 	}
 	c := uiCommon.NewListColumn("QUOTA_NAME", "QUOTA_NAME", defaultColSize,
-		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, nil)
+		uiCommon.ALPHANUMERIC, true, sortFunc, false, displayFunc, rawValueFunc, closeToAnySpaceQuotaAttentionFunc)
 	return c
 }
 
@@ -164,7 +164,7 @@ func columnMemoryLimit() *uiCommon.ListColumn {
 		if appStats.MemoryLimitInBytes == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.MemoryLimitInBytes).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.MemoryLimitInBytes)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -187,7 +187,7 @@ func columnTotalMemoryReserved() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalMemoryReserved).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalMemoryReserved)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -303,7 +303,7 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalMemInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalMemInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalMemoryUsed).StringWithPrecision(1))
+			totalMemInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalMemoryUsed)))
 		}
 		return fmt.Sprintf("%9v", totalMemInfo)
 	}
@@ -316,6 +316,75 @@ func columnTotalMemoryUsed() *uiCommon.ListColumn {
 	return c
 }
 
+func columnInstanceLimit() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplaySpace).InstanceLimit < c2.(*DisplaySpace).InstanceLimit
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*DisplaySpace)
+		if appStats.InstanceLimit <= 0 {
+			return fmt.Sprintf("%8v", "--")
+		}
+		return fmt.Sprintf("%8v", util.Format(int64(appStats.InstanceLimit)))
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*DisplaySpace)
+		return fmt.Sprintf("%v", appStats.InstanceLimit)
+	}
+	c := uiCommon.NewListColumn("INST_MAX", "INST_MAX", 8,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, nil)
+	return c
+}
+
+func columnTotalInstancesReservedPercentOfSpaceQuota() *uiCommon.ListColumn {
+	sortFunc := func(c1, c2 util.Sortable) bool {
+		return c1.(*DisplaySpace).TotalInstancesReservedPercentOfSpaceQuota < c2.(*DisplaySpace).TotalInstancesReservedPercentOfSpaceQuota
+	}
+	displayFunc := func(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) string {
+		appStats := data.(*DisplaySpace)
+		if appStats.InstanceLimit <= 0 {
+			return fmt.Sprintf("%7v", "--")
+		}
+		return fmt.Sprintf("%7.1f", appStats.TotalInstancesReservedPercentOfSpaceQuota)
+	}
+	rawValueFunc := func(data uiCommon.IData) string {
+		appStats := data.(*DisplaySpace)
+		return fmt.Sprintf("%v", appStats.TotalInstancesReservedPercentOfSpaceQuota)
+	}
+	c := uiCommon.NewListColumn("S_INST_PER", "S_INST%", 7,
+		uiCommon.NUMERIC, false, sortFunc, true, displayFunc, rawValueFunc, closeToInstanceSpaceQuotaAttentionFunc)
+	return c
+}
+
+func closeToInstanceSpaceQuotaAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	stats := data.(*DisplaySpace)
+	attentionType := uiCommon.ATTENTION_NORMAL
+	if stats.InstanceLimit > 0 {
+		percentOfQuota := stats.TotalInstancesReservedPercentOfSpaceQuota
+		switch {
+		case percentOfQuota >= ATTENTION_HOT_PERCENT:
+			attentionType = uiCommon.ATTENTION_HOT
+		case percentOfQuota >= ATTENTION_WARM_PERCENT:
+			attentionType = uiCommon.ATTENTION_WARM
+		}
+	}
+	return attentionType
+}
+
+// closeToAnySpaceQuotaAttentionFunc flags a space that is close to either
+// its memory or its app instance space quota limit
+func closeToAnySpaceQuotaAttentionFunc(data uiCommon.IData, columnOwner uiCommon.IColumnOwner) uiCommon.AttentionType {
+	memoryAttention := closeToMemorySpaceQuotaAttentionFunc(data, columnOwner)
+	instanceAttention := closeToInstanceSpaceQuotaAttentionFunc(data, columnOwner)
+	if memoryAttention == uiCommon.ATTENTION_HOT || instanceAttention == uiCommon.ATTENTION_HOT {
+		return uiCommon.ATTENTION_HOT
+	}
+	if memoryAttention == uiCommon.ATTENTION_WARM || instanceAttention == uiCommon.ATTENTION_WARM {
+		return uiCommon.ATTENTION_WARM
+	}
+	return uiCommon.ATTENTION_NORMAL
+}
+
 func columnTotalDiskReserved() *uiCommon.ListColumn {
 	sortFunc := func(c1, c2 util.Sortable) bool {
 		return c1.(*DisplaySpace).TotalDiskReserved < c2.(*DisplaySpace).TotalDiskReserved
@@ -326,7 +395,7 @@ func columnTotalDiskReserved() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalDiskInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalDiskInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalDiskReserved).StringWithPrecision(1))
+			totalDiskInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalDiskReserved)))
 		}
 		return fmt.Sprintf("%9v", totalDiskInfo)
 	}
@@ -349,7 +418,7 @@ func columnTotalDiskUsed() *uiCommon.ListColumn {
 		if appStats.TotalReportingContainers == 0 {
 			totalDiskInfo = fmt.Sprintf("%9v", "--")
 		} else {
-			totalDiskInfo = fmt.Sprintf("%9v", util.ByteSize(appStats.TotalDiskUsed).StringWithPrecision(1))
+			totalDiskInfo = fmt.Sprintf("%9v", util.FormatBytes(uint64(appStats.TotalDiskUsed)))
 		}
 		return fmt.Sprintf("%9v", totalDiskInfo)
 	}
@@ -22,6 +22,8 @@ type DisplaySpace struct {
 
 	QuotaName          string
 	MemoryLimitInBytes int64
+	// InstanceLimit is the app instance limit of the space quota, -1 if unlimited
+	InstanceLimit int
 
 	NumberOfSpaces int
 	NumberOfApps   int
@@ -33,6 +35,9 @@ type DisplaySpace struct {
 	TotalMemoryReservedPercentOfSpaceQuota float64
 	TotalMemoryReservedPercentOfOrgQuota   float64
 
+	TotalInstancesReserved                    int
+	TotalInstancesReservedPercentOfSpaceQuota float64
+
 	TotalDiskReserved int64
 	TotalDiskUsed     int64
 
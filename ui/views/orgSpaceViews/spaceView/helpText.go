@@ -34,7 +34,8 @@ const HelpColumnsText = `
 **Space Columns:**
 
   SPACE - Space name
-  QUOTA_NAME - Space quota name if one is assigned
+  QUOTA_NAME - Space quota name if one is assigned.  Highlighted when the
+      space is close to either its memory or app instance quota limit
   APPS - Number of apps within the space
   DCR - Number of desired containers (app instances)
   RCR - Number of reporting containers which are the the actual number of app
@@ -45,6 +46,8 @@ const HelpColumnsText = `
   S_MEM%% - Percent of space quota consumed
   O_MEM%% - Percent of org quota consumed
   MEM_USED - Memory actually in use by all containers
+  INST_MAX - Maximum app instances space can run based on quota limits
+  S_INST%% - Percent of space quota app instance limit consumed
   DSK_RSVD - Disk reserved by all containers on cell
   DSK_USED - Disk actually in use by all containers
   LOG_OUT - Total number of stdout log events for all instance of app
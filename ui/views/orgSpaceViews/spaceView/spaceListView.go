@@ -20,12 +20,14 @@ import (
 	"log"
 
 	"github.com/atotto/clipboard"
+	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/isolationSegment"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
 	"github.com/ecsteam/cloudfoundry-top-plugin/toplog"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/dataCommon"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -61,6 +63,7 @@ func NewSpaceListView(masterUI masterUIInterface.MasterUIInterface,
 
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_ContainerMetric, events.Envelope_HttpStartStop}
 
 	org := org.FindOrgMetadata(orgId)
 	dataListView.SetTitle(fmt.Sprintf("Space List of Org %v", org.Name))
@@ -75,18 +78,18 @@ func NewSpaceListView(masterUI masterUIInterface.MasterUIInterface,
 
 func (asUI *SpaceListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, 'c', gocui.ModNone, asUI.copyAction); err != nil {
+	if err := keybinding.Set(g, viewName, 'c', gocui.ModNone, asUI.copyAction, "copy to clipboard"); err != nil {
 		log.Panicln(err)
 	}
 
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.CloseDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.CloseDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.CloseDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.CloseDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
 
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
 	return nil
@@ -128,6 +131,9 @@ func (asUI *SpaceListView) columnDefinitions() []*uiCommon.ListColumn {
 	columns = append(columns, columnTotalMemoryReservedPercentOfOrgQuota())
 	columns = append(columns, columnTotalMemoryUsed())
 
+	columns = append(columns, columnInstanceLimit())
+	columns = append(columns, columnTotalInstancesReservedPercentOfSpaceQuota())
+
 	columns = append(columns, columnTotalDiskReserved())
 	columns = append(columns, columnTotalDiskUsed())
 
@@ -235,11 +241,13 @@ func (asUI *SpaceListView) postProcessData() map[string]*DisplaySpace {
 		displaySpace := NewDisplaySpace(&aSpaceMetadata)
 		displaySpaceMap[spaceMetadata.Guid] = displaySpace
 		displaySpace.NumberOfApps = len(appsBySpaceMap[spaceMetadata.Guid])
+		displaySpace.InstanceLimit = -1
 
 		if spaceMetadata.QuotaGuid != "" {
 			spaceQuotaMd := spaceQuotaMdMgr.Find(spaceMetadata.QuotaGuid)
 			displaySpace.QuotaName = spaceQuotaMd.Name
 			displaySpace.MemoryLimitInBytes = int64(spaceQuotaMd.MemoryLimit) * util.MEGABYTE
+			displaySpace.InstanceLimit = spaceQuotaMd.AppInstanceLimit
 		} else {
 			displaySpace.QuotaName = "-none-"
 		}
@@ -257,6 +265,7 @@ func (asUI *SpaceListView) postProcessData() map[string]*DisplaySpace {
 			appMetadata := appMdMgr.FindAppMetadata(appStats.AppId)
 			displaySpace.TotalMemoryReserved += (int64(appMetadata.MemoryMB) * util.MEGABYTE) * int64(appMetadata.Instances)
 			displaySpace.TotalDiskReserved += (int64(appMetadata.DiskQuotaMB) * util.MEGABYTE) * int64(appMetadata.Instances)
+			displaySpace.TotalInstancesReserved += int(appMetadata.Instances)
 
 			if appStats.TotalTraffic != nil {
 				displaySpace.HttpAllCount += appStats.HttpAllCount
@@ -272,6 +281,9 @@ func (asUI *SpaceListView) postProcessData() map[string]*DisplaySpace {
 		if displaySpace.MemoryLimitInBytes > 0 {
 			displaySpace.TotalMemoryReservedPercentOfSpaceQuota = (float64(displaySpace.TotalMemoryReserved) / float64(displaySpace.MemoryLimitInBytes)) * 100
 		}
+		if displaySpace.InstanceLimit > 0 {
+			displaySpace.TotalInstancesReservedPercentOfSpaceQuota = (float64(displaySpace.TotalInstancesReserved) / float64(displaySpace.InstanceLimit)) * 100
+		}
 
 		org := org.FindOrgMetadata(orgId)
 		orgQuotaMdMgr := asUI.GetEventProcessor().GetMetadataManager().GetOrgQuotaMdManager()
@@ -27,6 +27,7 @@ import (
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/org"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/route"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/space"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -63,6 +64,7 @@ func NewRouteMapListView(masterUI masterUIInterface.MasterUIInterface,
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_HttpStartStop}
 
 	dataListView.SetTitle("Route Map List")
 	dataListView.HelpText = HelpText
@@ -99,13 +101,13 @@ func (asUI *RouteMapListView) columnDefinitions() []*uiCommon.ListColumn {
 
 func (asUI *RouteMapListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, 'x', gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, 'x', gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEsc, gocui.ModNone, asUI.closeAppDetailView, "close view"); err != nil {
 		log.Panicln(err)
 	}
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
 
@@ -148,7 +150,8 @@ func (asUI *RouteMapListView) seedAppsWithNoTraffic(routeStats *eventRoute.Route
 		return
 	}
 
-	appIds := route.FindAppIdsForRouteMetadata(asUI.GetEventProcessor().GetCliConnection(), asUI.routeId)
+	routeMappingMdMgr := asUI.GetEventProcessor().GetMetadataManager().GetRouteMappingMdManager()
+	appIds := routeMappingMdMgr.FindAppIdsForRoute(asUI.GetEventProcessor().GetCliConnection(), asUI.routeId)
 	for _, appId := range appIds {
 		appRouteStats := routeStats.FindAppRouteStats(appId)
 		if appRouteStats == nil {
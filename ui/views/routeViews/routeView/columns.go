@@ -299,7 +299,7 @@ func columnResponseContentLength() *uiCommon.ListColumn {
 		if stats.HttpAllCount == 0 {
 			return fmt.Sprintf("%9v", "--")
 		} else {
-			value := fmt.Sprintf("%9v", util.ByteSize(stats.ResponseContentLength).StringWithPrecision(1))
+			value := fmt.Sprintf("%9v", util.FormatBytes(uint64(stats.ResponseContentLength)))
 			return value
 		}
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/cloudfoundry/sonde-go/events"
 	"github.com/ecsteam/cloudfoundry-top-plugin/eventdata"
 	"github.com/ecsteam/cloudfoundry-top-plugin/metadata/route"
+	"github.com/ecsteam/cloudfoundry-top-plugin/ui/keybinding"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/masterUIInterface"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon"
 	"github.com/ecsteam/cloudfoundry-top-plugin/ui/uiCommon/views/dataView"
@@ -54,9 +55,13 @@ func NewRouteListView(masterUI masterUIInterface.MasterUIInterface,
 		eventProcessor, asUI, asUI.columnDefinitions(),
 		defaultSortColumns)
 
+	// Keep host and domain in view when scrolling
+	dataListView.GetListWidget().SetLockColumns(2)
+
 	dataListView.InitializeCallback = asUI.initializeCallback
 	dataListView.UpdateHeaderCallback = asUI.updateHeader
 	dataListView.GetListData = asUI.GetListData
+	dataListView.EventTypes = []events.Envelope_EventType{events.Envelope_HttpStartStop}
 
 	dataListView.SetTitle("Route List")
 	dataListView.HelpText = HelpText
@@ -92,7 +97,7 @@ func (asUI *RouteListView) columnDefinitions() []*uiCommon.ListColumn {
 
 func (asUI *RouteListView) initializeCallback(g *gocui.Gui, viewName string) error {
 
-	if err := g.SetKeybinding(viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction); err != nil {
+	if err := keybinding.Set(g, viewName, gocui.KeyEnter, gocui.ModNone, asUI.enterAction, "show detail of highlighted row"); err != nil {
 		log.Panicln(err)
 	}
 
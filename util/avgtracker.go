@@ -20,6 +20,7 @@ import (
 	"time"
 	//"gopkg.in/eapache/queue.v1"
 	"github.com/eapache/queue"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 )
 
 /*
@@ -89,7 +90,7 @@ func (r *AvgTracker) Track(val int64) {
 	r.mu.Lock()
 	r.removeOld()
 	r.totalValue = r.totalValue + val
-	r.timeQueue.Add(time.Now())
+	r.timeQueue.Add(clock.Now())
 	r.valueQueue.Add(val)
 	r.mu.Unlock()
 }
@@ -118,7 +119,7 @@ func (r *AvgTracker) Avg() float64 {
 func (r *AvgTracker) removeOld() {
 
 	if r.timeQueue.Length() > 0 {
-		now := time.Now()
+		now := clock.Now()
 		for r.timeQueue.Length() > 0 {
 			ts := r.timeQueue.Peek().(time.Time)
 			if now.Sub(ts) < r.interval {
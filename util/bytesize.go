@@ -63,6 +63,22 @@ func (b ByteSize) StringWithPrecision(precision int) string {
 	return fmt.Sprintf("%.0fB", b)
 }
 
+// FormatBytes is the common display format for all byte valued columns
+func FormatBytes(n uint64) string {
+	b := float64(n)
+	switch {
+	case b >= float64(TB):
+		return fmt.Sprintf("%.1fTiB", b/float64(TB))
+	case b >= float64(GB):
+		return fmt.Sprintf("%.1fGiB", b/float64(GB))
+	case b >= float64(MB):
+		return fmt.Sprintf("%.1fMiB", b/float64(MB))
+	case b >= float64(KB):
+		return fmt.Sprintf("%.1fKiB", b/float64(KB))
+	}
+	return fmt.Sprintf("%vB", n)
+}
+
 func main() {
 	fmt.Println(YB, ByteSize(1e13))
 }
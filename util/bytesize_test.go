@@ -0,0 +1,43 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FormatBytes", func() {
+	table.DescribeTable("unit boundaries and rounding",
+		func(n uint64, expected string) {
+			Expect(util.FormatBytes(n)).To(Equal(expected))
+		},
+		table.Entry("zero", uint64(0), "0B"),
+		table.Entry("bytes below 1KiB", uint64(1023), "1023B"),
+		table.Entry("1KiB", uint64(1024), "1.0KiB"),
+		table.Entry("rounds down", uint64(1075), "1.0KiB"),
+		table.Entry("rounds up", uint64(1076), "1.1KiB"),
+		table.Entry("fraction of KiB", uint64(1536), "1.5KiB"),
+		table.Entry("1MiB", uint64(util.MEGABYTE), "1.0MiB"),
+		table.Entry("fraction of MiB", uint64(util.MEGABYTE*5/2), "2.5MiB"),
+		table.Entry("1GiB", uint64(util.GIGABYTE), "1.0GiB"),
+		table.Entry("fraction of GiB", uint64(util.GIGABYTE*3/4), "768.0MiB"),
+		table.Entry("1TiB", uint64(util.TB), "1.0TiB"),
+		table.Entry("thousands of TiB", uint64(2048*util.TB), "2048.0TiB"),
+	)
+})
@@ -18,6 +18,7 @@ package util
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/cli/plugin"
 
@@ -43,3 +44,24 @@ func GetApiEndpointNoProtocol(cliConnection plugin.CliConnection) string {
 	}
 	return _apiUrl
 }
+
+// GetSystemDomain derives the foundation system domain from the CC API
+// endpoint (api.<system domain>).  Returns empty string if the endpoint
+// does not follow that convention.
+func GetSystemDomain(cliConnection plugin.CliConnection) string {
+	apiEndpoint, err := cliConnection.ApiEndpoint()
+	if err != nil {
+		toplog.Warn("Call to ApiEndpoint failed: %v", err)
+		return ""
+	}
+	url, err := url.Parse(apiEndpoint)
+	if err != nil {
+		toplog.Warn("Unable to parse api endpoint %v: %v", apiEndpoint, err)
+		return ""
+	}
+	host := url.Hostname()
+	if !strings.HasPrefix(host, "api.") {
+		return ""
+	}
+	return strings.TrimPrefix(host, "api.")
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/config"
+)
+
+// FormatCpuPercent is the common display format for CPU percent columns,
+// right justified in width using the configured precision (see
+// config.CpuPrecision)
+func FormatCpuPercent(value float64, width int) string {
+	precision := config.CpuPrecision()
+	if precision == config.CpuPrecisionAuto {
+		switch {
+		case value >= 100.0:
+			precision = 0
+		case value >= 10.0:
+			precision = 1
+		default:
+			precision = 2
+		}
+	}
+	return fmt.Sprintf("%*.*f", width, precision, value)
+}
+
+// CpuPerCore divides the CPU percent by the number of cell CPUs when per
+// core display is enabled (see config.IsCpuPerCore).  The value is returned
+// unchanged if the number of CPUs is not known.
+func CpuPerCore(value float64, numOfCpus int) float64 {
+	if !config.IsCpuPerCore() || numOfCpus <= 0 {
+		return value
+	}
+	return value / float64(numOfCpus)
+}
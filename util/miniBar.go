@@ -0,0 +1,39 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "strings"
+
+// MiniBar is a fixed width text bar filled in proportion to percent
+// (0-100), e.g., "###......." for 30.  Plain ASCII is used as the block
+// characters do not display on all terminals (see color.go).
+func MiniBar(percent float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := int(percent*float64(width)/100 + 0.5)
+	switch {
+	case filled < 0:
+		filled = 0
+	case filled > width:
+		filled = width
+	}
+	// Show any activity at all as at least one mark
+	if filled == 0 && percent > 0 {
+		filled = 1
+	}
+	return strings.Repeat("#", filled) + strings.Repeat(".", width-filled)
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/eapache/queue"
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
 )
 
 /*
@@ -54,7 +55,7 @@ func NewRateCounter(interval time.Duration) *RateCounter {
 func (r *RateCounter) Incr() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	now := time.Now()
+	now := clock.Now()
 	r.removeOldFromTime(now)
 	//fmt.Printf("now: %v\n", now)
 	r.queue.Add(now)
@@ -70,7 +71,7 @@ func (r *RateCounter) Rate() int {
 }
 
 func (r *RateCounter) removeOld() {
-	r.removeOldFromTime(time.Now())
+	r.removeOldFromTime(clock.Now())
 }
 
 func (r *RateCounter) removeOldFromTime(timeMark time.Time) {
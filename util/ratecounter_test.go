@@ -0,0 +1,97 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"time"
+
+	"github.com/ecsteam/cloudfoundry-top-plugin/clock"
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate tracking with a fake clock", func() {
+	var fakeClock *clock.FakeClock
+
+	BeforeEach(func() {
+		fakeClock = clock.NewFakeClock(time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC))
+		clock.SetClock(fakeClock)
+	})
+
+	AfterEach(func() {
+		clock.SetClock(nil)
+	})
+
+	Describe("RateCounter", func() {
+		It("counts the events within the interval", func() {
+			rateCounter := util.NewRateCounter(time.Minute)
+			for i := 0; i < 3; i++ {
+				rateCounter.Incr()
+				fakeClock.Advance(10 * time.Second)
+			}
+			Expect(rateCounter.Rate()).To(Equal(3))
+		})
+
+		It("drops events once they are a full interval old", func() {
+			rateCounter := util.NewRateCounter(time.Minute)
+			rateCounter.Incr()
+			fakeClock.Advance(30 * time.Second)
+			rateCounter.Incr()
+
+			fakeClock.Advance(30*time.Second - time.Nanosecond)
+			Expect(rateCounter.Rate()).To(Equal(2))
+			fakeClock.Advance(time.Nanosecond)
+			Expect(rateCounter.Rate()).To(Equal(1))
+			fakeClock.Advance(30 * time.Second)
+			Expect(rateCounter.Rate()).To(Equal(0))
+		})
+	})
+
+	Describe("AvgTracker", func() {
+		It("is -1 with nothing tracked", func() {
+			Expect(util.NewAvgTracker(time.Minute).Avg()).To(Equal(-1.0))
+		})
+
+		It("averages the values within the interval", func() {
+			avgTracker := util.NewAvgTracker(time.Minute)
+			avgTracker.Track(100)
+			fakeClock.Advance(40 * time.Second)
+			avgTracker.Track(200)
+			fakeClock.Advance(10 * time.Second)
+			avgTracker.Track(600)
+			Expect(avgTracker.Rate()).To(Equal(3))
+			Expect(avgTracker.Avg()).To(Equal(300.0))
+
+			fakeClock.Advance(10 * time.Second)
+			Expect(avgTracker.Rate()).To(Equal(2))
+			Expect(avgTracker.Avg()).To(Equal(400.0))
+		})
+
+		It("averages across trackers", func() {
+			tracker1 := util.NewAvgTracker(time.Minute)
+			tracker2 := util.NewAvgTracker(time.Minute)
+			tracker1.Track(100)
+			fakeClock.Advance(30 * time.Second)
+			tracker2.Track(200)
+			tracker2.Track(300)
+			Expect(util.AvgMultipleTrackers([]*util.AvgTracker{tracker1, tracker2})).To(Equal(200.0))
+
+			fakeClock.Advance(30 * time.Second)
+			Expect(util.AvgMultipleTrackers([]*util.AvgTracker{tracker1, tracker2})).To(Equal(250.0))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+// Block characters used by Sparkline lowest to highest.  This set displays
+// better on MacOS than the full eighths set (see color.go).
+var sparklineLevels = []rune("▁▂▃▅▆▇")
+
+// Plain ASCII levels used on MS Windows consoles
+var sparklineAsciiLevels = []rune("_.-=+*#")
+
+// Sparkline is one character per value scaled to the largest value,
+// e.g., "▁▁▃▇▅▁".  Zero values are shown as a space so gaps stand out.
+func Sparkline(values []int64) string {
+	levels := sparklineLevels
+	if IsMSWindows() {
+		levels = sparklineAsciiLevels
+	}
+	max := int64(0)
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+	line := make([]rune, len(values))
+	for i, value := range values {
+		switch {
+		case value <= 0:
+			line[i] = ' '
+		default:
+			level := int(value * int64(len(levels)-1) / max)
+			line[i] = levels[level]
+		}
+	}
+	return string(line)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2016 ECS Team, Inc. - All Rights Reserved
+// https://github.com/ECSTeam/cloudfoundry-top-plugin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"github.com/ecsteam/cloudfoundry-top-plugin/util"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sparkline", func() {
+
+	It("scales the values to the largest value", func() {
+		Expect(util.Sparkline([]int64{1, 2, 4, 6, 8, 10})).To(Equal("▁▂▃▅▆▇"))
+	})
+
+	It("shows the levels of small counts", func() {
+		Expect(util.Sparkline([]int64{1, 2, 3})).To(Equal("▂▅▇"))
+	})
+
+	It("shows zero values as a space", func() {
+		Expect(util.Sparkline([]int64{0, 5, 0, 5})).To(Equal(" ▇ ▇"))
+	})
+
+	It("is blank when all values are zero", func() {
+		Expect(util.Sparkline([]int64{0, 0, 0})).To(Equal("   "))
+	})
+
+	It("is empty without values", func() {
+		Expect(util.Sparkline(nil)).To(Equal(""))
+	})
+})
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -74,6 +75,20 @@ func formatDisplayDataInternal(value string, size int, leftJustified bool) strin
 	return fmt.Sprintf(format, value)
 }
 
+// Short human readable duration (e.g., 45s, 12m, 3h, 2d)
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%vs", int64(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%vm", int64(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%vh", int64(d/time.Hour))
+	default:
+		return fmt.Sprintf("%vd", int64(d/(24*time.Hour)))
+	}
+}
+
 // TODO: Old API -- replaced by FormatDisplayDataLeft
 func FormatDisplayData(value string, size int) string {
 	return formatDisplayDataInternal(value, size, true)